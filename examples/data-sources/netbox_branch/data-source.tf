@@ -0,0 +1,3 @@
+data "netbox_branch" "staging" {
+  name = "staging"
+}
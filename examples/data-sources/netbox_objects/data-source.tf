@@ -0,0 +1,19 @@
+# All zones of the netbox-dns plugin whose name contains "example",
+# except the ones tagged with "deprecated"
+data "netbox_objects" "zones" {
+  path = "plugins/netbox-dns/zones"
+
+  filter {
+    name  = "name__ic"
+    value = "example"
+  }
+
+  filter {
+    name  = "tag__n"
+    value = "deprecated"
+  }
+}
+
+output "zone_names" {
+  value = [for zone in data.netbox_objects.zones.objects : jsondecode(zone).name]
+}
@@ -0,0 +1,19 @@
+resource "netbox_branch" "datacenter_expansion" {
+  name        = "datacenter-expansion"
+  description = "New racks in DC2"
+
+  # Set to true after the changes were reviewed
+  merge = false
+}
+
+provider "netbox" {
+  alias      = "branch"
+  server_url = "https://netbox.example.com"
+  api_token  = "0123456789abcdef0123456789abcdef01234567"
+  branch     = netbox_branch.datacenter_expansion.schema_id
+}
+
+resource "netbox_site" "dc2" {
+  provider = netbox.branch
+  name     = "DC2"
+}
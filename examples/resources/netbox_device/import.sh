@@ -0,0 +1,3 @@
+# Devices can be imported by ID, name or site slug and name
+terraform import netbox_device.switch 42
+terraform import netbox_device.switch dc1/switch1
@@ -0,0 +1,3 @@
+# Interfaces can be imported by ID or device name and interface name
+terraform import netbox_device_interface.uplink 1337
+terraform import netbox_device_interface.uplink switch1/Ethernet1/1
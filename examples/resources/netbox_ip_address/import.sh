@@ -0,0 +1,4 @@
+# IP addresses can be imported by ID, address or address and VRF name
+terraform import netbox_ip_address.gateway 99
+terraform import netbox_ip_address.gateway 10.0.0.1/24
+terraform import netbox_ip_address.gateway 10.0.0.1/24@prod
@@ -0,0 +1,2 @@
+# Objects are imported by their path and ID
+terraform import netbox_object.session plugins/bgp/session/12
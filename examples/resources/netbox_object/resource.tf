@@ -0,0 +1,15 @@
+# A BGP session of the netbox-bgp plugin
+resource "netbox_object" "session" {
+  path = "plugins/bgp/session"
+  body = jsonencode({
+    name          = "router1-upstream"
+    device        = netbox_device.router1.id
+    local_address = netbox_ip_address.local.id
+    remote_as     = 65001
+    status        = "active"
+  })
+}
+
+output "session_url" {
+  value = jsondecode(netbox_object.session.json).url
+}
@@ -0,0 +1,4 @@
+# Prefixes can be imported by ID, prefix or VRF name and prefix
+terraform import netbox_prefix.lan 23
+terraform import netbox_prefix.lan 10.0.0.0/24
+terraform import netbox_prefix.lan prod/10.0.0.0/24
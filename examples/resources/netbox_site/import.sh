@@ -0,0 +1,4 @@
+# Sites can be imported by ID, name or any other field, e.g. the slug
+terraform import netbox_site.dc1 12
+terraform import netbox_site.dc1 "Data Center 1"
+terraform import netbox_site.dc1 slug:dc1
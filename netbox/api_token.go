@@ -0,0 +1,89 @@
+package netbox
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// apiTokenSource provides the Netbox API token for every request.
+type apiTokenSource interface {
+	Token() (string, error)
+}
+
+// staticAPIToken is a token that never changes.
+type staticAPIToken string
+
+func (t staticAPIToken) Token() (string, error) {
+	return string(t), nil
+}
+
+// fileAPIToken reads the token from a file. The file is read again whenever
+// its modification time or size changes, so tokens rotated by an external
+// agent are picked up during long running applies.
+type fileAPIToken struct {
+	path string
+
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+	size    int64
+}
+
+func (t *fileAPIToken) Token() (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	info, err := os.Stat(t.path)
+	if err != nil {
+		return "", fmt.Errorf("error reading API token file: %w", err)
+	}
+	if t.token != "" && info.ModTime().Equal(t.modTime) && info.Size() == t.size {
+		return t.token, nil
+	}
+
+	content, err := os.ReadFile(t.path)
+	if err != nil {
+		return "", fmt.Errorf("error reading API token file: %w", err)
+	}
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", fmt.Errorf("API token file %s is empty", t.path)
+	}
+
+	t.token = token
+	t.modTime = info.ModTime()
+	t.size = info.Size()
+	return t.token, nil
+}
+
+// runAPITokenCommand runs command in the system shell and returns its output
+// as the API token.
+func runAPITokenCommand(ctx context.Context, command string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("error running API token command: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	token := strings.TrimSpace(stdout.String())
+	if token == "" {
+		return "", fmt.Errorf("API token command did not print a token")
+	}
+	return token, nil
+}
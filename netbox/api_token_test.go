@@ -0,0 +1,171 @@
+package netbox
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fbreckle/go-netbox/netbox/client/status"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileAPITokenIsReloadedWhenChanged(t *testing.T) {
+	var authorization string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("first-token\n"), 0600))
+
+	config := Config{
+		APITokenFile: tokenFile,
+		ServerURL:    ts.URL,
+	}
+
+	client, err := config.Client()
+	assert.NoError(t, err)
+
+	_, err = client.Status.StatusList(status.NewStatusListParams(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Token first-token", authorization)
+
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("rotated-token\n"), 0600))
+	// Make sure the change is detected on file systems with coarse timestamps
+	assert.NoError(t, os.Chtimes(tokenFile, time.Now(), time.Now().Add(time.Minute)))
+
+	_, err = client.Status.StatusList(status.NewStatusListParams(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Token rotated-token", authorization)
+}
+
+func TestFileAPITokenErrors(t *testing.T) {
+	config := Config{
+		APITokenFile: filepath.Join(t.TempDir(), "missing"),
+		ServerURL:    "http://localhost",
+	}
+
+	_, err := config.Client()
+	assert.Error(t, err)
+
+	emptyFile := filepath.Join(t.TempDir(), "empty")
+	assert.NoError(t, os.WriteFile(emptyFile, []byte("\n"), 0600))
+	config.APITokenFile = emptyFile
+
+	_, err = config.Client()
+	assert.ErrorContains(t, err, "empty")
+}
+
+func TestRunAPITokenCommand(t *testing.T) {
+	token, err := runAPITokenCommand(context.Background(), "echo command-token")
+	assert.NoError(t, err)
+	assert.Equal(t, "command-token", token)
+
+	_, err = runAPITokenCommand(context.Background(), "exit 1")
+	assert.Error(t, err)
+
+	_, err = runAPITokenCommand(context.Background(), "echo")
+	assert.ErrorContains(t, err, "did not print a token")
+}
+
+func TestAPITokenCommand(t *testing.T) {
+	var authorization string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APITokenCommand: "echo command-token",
+		ServerURL:       ts.URL,
+	}
+
+	client, err := config.Client()
+	assert.NoError(t, err)
+
+	_, err = client.Status.StatusList(status.NewStatusListParams(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Token command-token", authorization)
+}
+
+func TestAPITokenPrecedence(t *testing.T) {
+	var authorization string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"netbox-version": "4.0.10"}`))
+	}))
+	defer ts.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("file-token\n"), 0o600))
+
+	configure := func(env, attributes map[string]string) (string, error) {
+		for _, envVar := range apiTokenEnvVars {
+			t.Setenv(envVar, env[envVar])
+		}
+		authorization = ""
+		server := Provider().GRPCProvider()
+		schemaResp, err := server.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+		assert.NoError(t, err)
+		configType := schemaResp.Provider.ValueType().(tftypes.Object)
+		values := map[string]tftypes.Value{}
+		for name, attributeType := range configType.AttributeTypes {
+			values[name] = tftypes.NewValue(attributeType, nil)
+		}
+		values["server_url"] = tftypes.NewValue(tftypes.String, ts.URL)
+		values["ignore_tags"] = tftypes.NewValue(configType.AttributeTypes["ignore_tags"], []tftypes.Value{})
+		for name, value := range attributes {
+			values[name] = tftypes.NewValue(tftypes.String, value)
+		}
+		config, err := tfprotov5.NewDynamicValue(configType, tftypes.NewValue(configType, values))
+		assert.NoError(t, err)
+		resp, err := server.ConfigureProvider(context.Background(), &tfprotov5.ConfigureProviderRequest{Config: &config})
+		assert.NoError(t, err)
+		for _, d := range resp.Diagnostics {
+			if d.Severity == tfprotov5.DiagnosticSeverityError {
+				return "", errors.New(d.Summary)
+			}
+		}
+		return authorization, nil
+	}
+
+	// Environment variables are used if nothing is configured
+	authorization, err := configure(map[string]string{"NETBOX_API_TOKEN": "env-token"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Token env-token", authorization)
+
+	// The configuration takes precedence over the environment
+	authorization, err = configure(map[string]string{"NETBOX_API_TOKEN": "env-token"}, map[string]string{"api_token_file": tokenFile})
+	assert.NoError(t, err)
+	assert.Equal(t, "Token file-token", authorization)
+
+	authorization, err = configure(map[string]string{"NETBOX_API_TOKEN_FILE": filepath.Join(t.TempDir(), "missing")}, map[string]string{"api_token": "config-token"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Token config-token", authorization)
+
+	authorization, err = configure(map[string]string{"NETBOX_API_TOKEN": "env-token", "NETBOX_API_TOKEN_FILE": tokenFile}, map[string]string{"api_token_command": "echo command-token"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Token command-token", authorization)
+
+	_, err = configure(nil, map[string]string{"api_token": "config-token", "api_token_file": tokenFile})
+	assert.EqualError(t, err, "only one of `api_token`, `api_token_file` and `api_token_command` can be set, got `api_token`, `api_token_file`")
+
+	_, err = configure(map[string]string{"NETBOX_API_TOKEN": "env-token", "NETBOX_API_TOKEN_COMMAND": "echo command-token"}, nil)
+	assert.EqualError(t, err, "only one of NETBOX_API_TOKEN, NETBOX_API_TOKEN_FILE and NETBOX_API_TOKEN_COMMAND can be set, got NETBOX_API_TOKEN, NETBOX_API_TOKEN_COMMAND")
+
+	_, err = configure(nil, nil)
+	assert.EqualError(t, err, "missing netbox API key")
+}
@@ -0,0 +1,160 @@
+package netbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// branchHeader is the header selecting the branch of the Netbox Branching
+// plugin a request operates on. Its value is the schema ID of the branch.
+const branchHeader = "X-NetBox-Branch"
+
+// branchingAPIPath is the path of the API of the Netbox Branching plugin,
+// relative to the API root.
+const branchingAPIPath = "/plugins/branching/"
+
+// branch is a branch of the Netbox Branching plugin.
+type branch struct {
+	ID          int64  `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	SchemaID    string `json:"schema_id,omitempty"`
+	Status      *struct {
+		Value string `json:"value"`
+	} `json:"status,omitempty"`
+	LastSync *string `json:"last_sync,omitempty"`
+}
+
+func (b *branch) status() string {
+	if b.Status == nil {
+		return ""
+	}
+	return b.Status.Value
+}
+
+type branchList struct {
+	Count   int64     `json:"count"`
+	Results []*branch `json:"results"`
+}
+
+// branchTransport is a transport that makes every request operate on a
+// branch. The branch is configured by schema ID or name and resolved to its
+// schema ID with the first request. If the resolution fails, e.g. as the
+// branch is not ready yet, it is tried again with the next request.
+type branchTransport struct {
+	original http.RoundTripper
+	// apiURL is the URL of the API root
+	apiURL *url.URL
+	branch string
+
+	resolution *branchResolution
+}
+
+type branchResolution struct {
+	mu       sync.Mutex
+	resolved bool
+	schemaID string
+}
+
+// get returns the resolved schema ID, calling resolve unless an earlier
+// call succeeded. Concurrent requests wait for a running resolution.
+func (res *branchResolution) get(resolve func() (string, error)) (string, error) {
+	res.mu.Lock()
+	defer res.mu.Unlock()
+	if res.resolved {
+		return res.schemaID, nil
+	}
+	schemaID, err := resolve()
+	if err != nil {
+		return "", err
+	}
+	res.schemaID, res.resolved = schemaID, true
+	return schemaID, nil
+}
+
+func newBranchTransport(original http.RoundTripper, apiURL *url.URL, branch string) branchTransport {
+	return branchTransport{
+		original:   original,
+		apiURL:     apiURL,
+		branch:     branch,
+		resolution: &branchResolution{},
+	}
+}
+
+// RoundTrip adds the branch header to all requests except the ones to the
+// API of the branching plugin itself, as branches are managed outside of
+// any branch.
+func (t branchTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if strings.Contains(r.URL.Path, branchingAPIPath) {
+		return t.original.RoundTrip(r)
+	}
+
+	schemaID, err := t.resolution.get(func() (string, error) {
+		return t.resolveBranch(r)
+	})
+	if err != nil {
+		if r.Body != nil {
+			r.Body.Close()
+		}
+		return nil, err
+	}
+
+	r = r.Clone(r.Context())
+	r.Header.Set(branchHeader, schemaID)
+	return t.original.RoundTrip(r)
+}
+
+// resolveBranch looks up the configured branch by name and by schema ID and
+// returns its schema ID. The branch has to be ready for use. The credentials
+// are taken from the request r.
+func (t branchTransport) resolveBranch(r *http.Request) (string, error) {
+	for _, filter := range []string{"name", "schema_id"} {
+		branches, err := t.listBranches(r, filter)
+		if err != nil {
+			return "", fmt.Errorf("error retrieving branch %s from netbox: %w", t.branch, err)
+		}
+		// Compare the results, as older versions of the plugin may not
+		// support all filters
+		for _, b := range branches {
+			if (filter == "name" && b.Name == t.branch) || (filter == "schema_id" && b.SchemaID == t.branch) {
+				if b.status() != "ready" {
+					return "", fmt.Errorf("branch %s is not ready for use, its status is %q", t.branch, b.status())
+				}
+				return b.SchemaID, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("branch %s not found in netbox, set `branch` to the schema ID or name of an existing branch", t.branch)
+}
+
+func (t branchTransport) listBranches(r *http.Request, filter string) ([]*branch, error) {
+	listURL := t.apiURL.JoinPath(branchingAPIPath, "branches/")
+	listURL.RawQuery = url.Values{filter: {t.branch}, "limit": {"0"}}.Encode()
+
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, listURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", r.Header.Get("Authorization"))
+
+	resp, err := t.original.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d, is the Netbox Branching plugin installed?", resp.StatusCode)
+	}
+
+	var list branchList
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, err
+	}
+	return list.Results, nil
+}
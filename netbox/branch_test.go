@@ -0,0 +1,239 @@
+package netbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/fbreckle/go-netbox/netbox/client/dcim"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBranchServer is a stub of a Netbox with the branching plugin. It
+// records the branch header of every request by path.
+type testBranchServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	headers  map[string]string
+	branches map[int64]*branch
+}
+
+func newTestBranchServer(t *testing.T) *testBranchServer {
+	s := &testBranchServer{
+		headers: map[string]string{},
+		branches: map[int64]*branch{
+			1: testBranch(1, "staging", "td5smq0f", "ready"),
+			2: testBranch(2, "provisioning", "a2c4e6g8", "provisioning"),
+		},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.headers[r.Method+" "+r.URL.Path] = r.Header.Get(branchHeader)
+		w.Header().Set("Content-Type", "application/json")
+
+		var response interface{}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/status/":
+			response = map[string]interface{}{"netbox-version": "4.0.10"}
+		case "GET /api/dcim/sites/":
+			response = map[string]interface{}{"count": 0, "results": []interface{}{}}
+		case "GET /api/plugins/branching/branches/":
+			list := branchList{Results: []*branch{}}
+			for _, b := range s.branches {
+				if b.Name == r.URL.Query().Get("name") || b.SchemaID == r.URL.Query().Get("schema_id") {
+					list.Results = append(list.Results, b)
+				}
+			}
+			list.Count = int64(len(list.Results))
+			response = list
+		case "POST /api/plugins/branching/branches/":
+			var data branch
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&data))
+			created := testBranch(3, data.Name, "x9y8z7w6", "ready")
+			created.Description = data.Description
+			s.branches[3] = created
+			w.WriteHeader(http.StatusCreated)
+			response = created
+		case "GET /api/plugins/branching/branches/3/":
+			if s.branches[3] == nil {
+				w.WriteHeader(http.StatusNotFound)
+				response = map[string]interface{}{"detail": "Not found."}
+			} else {
+				response = s.branches[3]
+			}
+		case "POST /api/plugins/branching/branches/3/merge/":
+			s.branches[3].Status.Value = "merged"
+			response = map[string]interface{}{"id": 10}
+		case "DELETE /api/plugins/branching/branches/3/":
+			delete(s.branches, 3)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			response = map[string]interface{}{"detail": "Not found."}
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	return s
+}
+
+func testBranch(id int64, name, schemaID, status string) *branch {
+	b := &branch{ID: id, Name: name, SchemaID: schemaID}
+	b.Status = &struct {
+		Value string `json:"value"`
+	}{Value: status}
+	return b
+}
+
+func (s *testBranchServer) header(request string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.headers[request]
+}
+
+func testConfigureBranchProvider(t *testing.T, serverURL string, branch string) (*providerState, error) {
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"server_url": serverURL,
+		"api_token":  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		"branch":     branch,
+	}))
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			return nil, errors.New(d.Summary)
+		}
+	}
+	return p.Meta().(*providerState), nil
+}
+
+func TestBranchHeader(t *testing.T) {
+	ts := newTestBranchServer(t)
+	defer ts.Close()
+
+	// The branch can be given by name or schema ID
+	for _, branch := range []string{"staging", "td5smq0f"} {
+		api, err := testConfigureBranchProvider(t, ts.URL, branch)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		assert.Equal(t, "td5smq0f", ts.header("GET /api/status/"), branch)
+
+		_, err = api.Dcim.DcimSitesList(dcim.NewDcimSitesListParams(), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "td5smq0f", ts.header("GET /api/dcim/sites/"), branch)
+
+		// Branches themselves are not managed within a branch
+		assert.Equal(t, "", ts.header("GET /api/plugins/branching/branches/"), branch)
+	}
+
+	_, err := testConfigureBranchProvider(t, ts.URL, "unknown")
+	assert.ErrorContains(t, err, "branch unknown not found in netbox")
+
+	_, err = testConfigureBranchProvider(t, ts.URL, "provisioning")
+	assert.ErrorContains(t, err, `branch provisioning is not ready for use, its status is "provisioning"`)
+}
+
+func TestBranchResolutionIsRetried(t *testing.T) {
+	ts := newTestBranchServer(t)
+	defer ts.Close()
+
+	apiURL, err := url.Parse(ts.URL + "/api")
+	assert.NoError(t, err)
+	client := &http.Client{Transport: newBranchTransport(http.DefaultTransport, apiURL, "provisioning")}
+
+	_, err = client.Get(ts.URL + "/api/dcim/sites/")
+	assert.ErrorContains(t, err, `branch provisioning is not ready for use, its status is "provisioning"`)
+
+	// Failed resolutions are not cached
+	ts.mu.Lock()
+	ts.branches[2].Status.Value = "ready"
+	ts.mu.Unlock()
+	resp, err := client.Get(ts.URL + "/api/dcim/sites/")
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+	}
+	assert.Equal(t, "a2c4e6g8", ts.header("GET /api/dcim/sites/"))
+
+	// Successful resolutions are kept
+	ts.mu.Lock()
+	ts.branches[2].Status.Value = "merged"
+	ts.mu.Unlock()
+	resp, err = client.Get(ts.URL + "/api/dcim/sites/")
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+	}
+}
+
+func TestNoBranchHeader(t *testing.T) {
+	ts := newTestBranchServer(t)
+	defer ts.Close()
+
+	_, err := testConfigureBranchProvider(t, ts.URL, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "", ts.header("GET /api/status/"))
+}
+
+func TestResourceNetboxBranchLifecycle(t *testing.T) {
+	ts := newTestBranchServer(t)
+	defer ts.Close()
+
+	api, err := testConfigureBranchProvider(t, ts.URL, "")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	r := resourceNetboxBranch()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":        "feature",
+		"description": "New feature",
+		"merge":       true,
+	})
+
+	diags := r.CreateContext(context.Background(), d, api)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "3", d.Id())
+	assert.Equal(t, "x9y8z7w6", d.Get("schema_id"))
+	assert.Equal(t, "merged", d.Get("status"))
+	assert.Equal(t, "New feature", d.Get("description"))
+
+	diags = r.DeleteContext(context.Background(), d, api)
+	assert.False(t, diags.HasError(), diags)
+
+	// A deleted branch is removed from the state
+	diags = r.ReadContext(context.Background(), d, api)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "", d.Id())
+}
+
+func TestDataSourceNetboxBranchRead(t *testing.T) {
+	ts := newTestBranchServer(t)
+	defer ts.Close()
+
+	api, err := testConfigureBranchProvider(t, ts.URL, "")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	r := dataSourceNetboxBranch()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"schema_id": "td5smq0f"})
+	diags := r.ReadContext(context.Background(), d, api)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "1", d.Id())
+	assert.Equal(t, "staging", d.Get("name"))
+	assert.Equal(t, "ready", d.Get("status"))
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "unknown"})
+	diags = r.ReadContext(context.Background(), d, api)
+	assert.True(t, diags.HasError())
+}
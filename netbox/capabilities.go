@@ -0,0 +1,101 @@
+package netbox
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// supportedNetboxVersions is the range of Netbox versions the provider was
+// successfully tested against.
+var supportedNetboxVersions = version.MustConstraints(version.NewConstraint(">= 4.0.0, < 4.1.0"))
+
+// netboxCapabilities maps resources and resource attributes to the first
+// Netbox version supporting them. Keys are either a resource type or a
+// resource type and a top-level attribute joined by a dot.
+var netboxCapabilities = map[string]string{
+	"netbox_config_template":           "3.5.0",
+	"netbox_device.config_template_id": "3.5.0",
+	"netbox_custom_field_choice_set":   "3.6.0",
+	"netbox_event_rule":                "3.7.0",
+	"netbox_virtual_disk":              "3.7.0",
+	"netbox_vpn_tunnel":                "3.7.0",
+	"netbox_vpn_tunnel_group":          "3.7.0",
+	"netbox_vpn_tunnel_termination":    "3.7.0",
+}
+
+// parseNetboxVersion parses the version reported by the Netbox status
+// endpoint. Suffixes like in `4.0.10-Docker-2.9.1` are ignored.
+func parseNetboxVersion(raw string) (*version.Version, error) {
+	v, err := version.NewVersion(raw)
+	if err != nil {
+		return nil, err
+	}
+	return v.Core(), nil
+}
+
+// netboxVersionAtLeast returns true if the Netbox server runs at least the
+// given version. If the version of the server is unknown, it is assumed to
+// be recent enough.
+func (state *providerState) netboxVersionAtLeast(minimum string) bool {
+	if state == nil || state.netboxVersion == nil {
+		return true
+	}
+	return state.netboxVersion.GreaterThanOrEqual(version.Must(version.NewVersion(minimum)))
+}
+
+// addCapabilityChecks adds a plan time check to every resource with entries
+// in netboxCapabilities, so that using a resource or attribute that is not
+// supported by the Netbox server fails before anything is changed.
+func addCapabilityChecks(resources map[string]*schema.Resource) {
+	requirements := map[string]map[string]string{}
+	for key, minimum := range netboxCapabilities {
+		resourceType, attribute, _ := strings.Cut(key, ".")
+		if requirements[resourceType] == nil {
+			requirements[resourceType] = map[string]string{}
+		}
+		requirements[resourceType][attribute] = minimum
+	}
+
+	for resourceType, attributes := range requirements {
+		r, ok := resources[resourceType]
+		if !ok {
+			continue
+		}
+		appendCustomizeDiff(r, capabilityCheck(resourceType, attributes))
+	}
+}
+
+func capabilityCheck(resourceType string, attributes map[string]string) schema.CustomizeDiffFunc {
+	// Check attributes in a stable order so the first error is deterministic
+	keys := make([]string, 0, len(attributes))
+	for attribute := range attributes {
+		keys = append(keys, attribute)
+	}
+	sort.Strings(keys)
+
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		state, ok := m.(*providerState)
+		if !ok || state.netboxVersion == nil {
+			return nil
+		}
+
+		for _, attribute := range keys {
+			minimum := attributes[attribute]
+			if state.netboxVersionAtLeast(minimum) {
+				continue
+			}
+			if attribute == "" {
+				return fmt.Errorf("%s requires Netbox %s or later, but the Netbox server runs version %s", resourceType, minimum, state.netboxVersion)
+			}
+			if d.GetRawConfig().IsKnown() && !d.GetRawConfig().IsNull() && !d.GetRawConfig().GetAttr(attribute).IsNull() {
+				return fmt.Errorf("the `%s` attribute of %s requires Netbox %s or later, but the Netbox server runs version %s", attribute, resourceType, minimum, state.netboxVersion)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,69 @@
+package netbox
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNetboxVersion(t *testing.T) {
+	for raw, expected := range map[string]string{
+		"4.0.10":              "4.0.10",
+		"4.0":                 "4.0.0",
+		"4.0.10-Docker-2.9.1": "4.0.10",
+		"v4.1.0-beta1":        "4.1.0",
+	} {
+		v, err := parseNetboxVersion(raw)
+		assert.NoError(t, err, raw)
+		assert.Equal(t, expected, v.String(), raw)
+	}
+
+	_, err := parseNetboxVersion("latest")
+	assert.Error(t, err)
+}
+
+func TestSupportedNetboxVersions(t *testing.T) {
+	for raw, supported := range map[string]bool{
+		"3.7.8":  false,
+		"4.0.0":  true,
+		"4.0.11": true,
+		"4.0.99": true,
+		"4.1.0":  false,
+	} {
+		v, err := parseNetboxVersion(raw)
+		assert.NoError(t, err)
+		assert.Equal(t, supported, supportedNetboxVersions.Check(v), raw)
+	}
+}
+
+func TestNetboxVersionAtLeast(t *testing.T) {
+	v, _ := parseNetboxVersion("3.6.9")
+	state := &providerState{netboxVersion: v}
+
+	assert.True(t, state.netboxVersionAtLeast("3.5.0"))
+	assert.True(t, state.netboxVersionAtLeast("3.6.9"))
+	assert.False(t, state.netboxVersionAtLeast("3.7.0"))
+
+	// An unknown version is assumed to support everything
+	assert.True(t, (&providerState{}).netboxVersionAtLeast("99.0.0"))
+}
+
+func TestCapabilityCheckResource(t *testing.T) {
+	r := Provider().ResourcesMap["netbox_vpn_tunnel_group"]
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "test",
+	})
+
+	oldVersion, _ := parseNetboxVersion("3.6.9")
+	_, err := r.Diff(context.Background(), nil, config, &providerState{netboxVersion: oldVersion})
+	assert.ErrorContains(t, err, "netbox_vpn_tunnel_group requires Netbox 3.7.0 or later, but the Netbox server runs version 3.6.9")
+
+	newVersion, _ := parseNetboxVersion("4.0.10")
+	_, err = r.Diff(context.Background(), nil, config, &providerState{netboxVersion: newVersion})
+	assert.NoError(t, err)
+
+	_, err = r.Diff(context.Background(), nil, config, &providerState{})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,378 @@
+package netbox
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// Acceptance tests record their traffic to Netbox to cassettes if
+// NETBOX_CASSETTE_MODE is `record`, and replay it from the cassettes instead
+// of talking to Netbox if it is `replay`. The traffic is assigned to the
+// running test, so tests must run with `-parallel 1` in both modes.
+const (
+	cassetteModeEnv    = "NETBOX_CASSETTE_MODE"
+	cassetteModeRecord = "record"
+	cassetteModeReplay = "replay"
+
+	// cassetteDir contains a cassette for every recorded test.
+	cassetteDir = "testdata/cassettes"
+	// cassetteRedacted replaces the API token in recordings.
+	cassetteRedacted = "REDACTED"
+	// cassetteReplayServerURL is the server URL if none is set during replay.
+	// The host is not part of the recordings, so any URL works.
+	cassetteReplayServerURL = "http://netbox.invalid"
+)
+
+// cassetteHeaders are the response headers kept in recordings.
+var cassetteHeaders = []string{"Content-Type", "Retry-After"}
+
+var (
+	activeCassetteMu sync.Mutex
+	activeCassette   *cassette
+
+	cassetteNamesMu sync.Mutex
+	cassetteNames   = map[string]int{}
+)
+
+// testAccProviderConfigure configures the provider of the acceptance tests
+// with a client recording or replaying cassettes.
+func testAccProviderConfigure(ctx context.Context, data *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	return configureProvider(ctx, data, func(original http.RoundTripper) http.RoundTripper {
+		return cassetteTransport{original: original}
+	})
+}
+
+func cassetteMode() string {
+	return os.Getenv(cassetteModeEnv)
+}
+
+// cassette is the recorded traffic to Netbox of a test.
+type cassette struct {
+	Interactions []*cassetteInteraction `json:"interactions"`
+
+	path string
+	mode string
+	// secrets are redacted in recordings.
+	secrets []string
+
+	mu sync.Mutex
+}
+
+type cassetteInteraction struct {
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+
+	replayed bool
+}
+
+type cassetteRequest struct {
+	Method string `json:"method"`
+	// URI is the path and query of the request.
+	URI  string `json:"uri"`
+	Body string `json:"body,omitempty"`
+}
+
+type cassetteResponse struct {
+	StatusCode int               `json:"status_code"`
+	Header     map[string]string `json:"header,omitempty"`
+	Body       string            `json:"body,omitempty"`
+}
+
+// testAccCassette records the traffic of the test to Netbox to its cassette
+// or replays it from the cassette, depending on NETBOX_CASSETTE_MODE. Tests
+// without a cassette are skipped during replay. Cassettes are only written
+// if the test succeeds.
+func testAccCassette(t *testing.T) {
+	mode := cassetteMode()
+	if mode == "" {
+		return
+	}
+	if mode != cassetteModeRecord && mode != cassetteModeReplay {
+		t.Fatalf("%s must be %q or %q, got %q", cassetteModeEnv, cassetteModeRecord, cassetteModeReplay, mode)
+	}
+	if parallel := flag.Lookup("test.parallel"); parallel == nil || parallel.Value.String() != "1" {
+		t.Fatalf("%s requires -parallel 1, as the traffic to Netbox is assigned to the running test", cassetteModeEnv)
+	}
+
+	c := &cassette{
+		path: filepath.Join(cassetteDir, strings.ReplaceAll(t.Name(), "/", "_")+".json"),
+		mode: mode,
+	}
+	if mode == cassetteModeReplay {
+		data, err := os.ReadFile(c.path)
+		if errors.Is(err, fs.ErrNotExist) {
+			t.Skipf("no cassette recorded at %s", c.path)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(data, c); err != nil {
+			t.Fatalf("error reading cassette %s: %s", c.path, err)
+		}
+		// Missing interactions are not going to turn up by retrying
+		if os.Getenv("NETBOX_MAX_RETRIES") == "" {
+			os.Setenv("NETBOX_MAX_RETRIES", "0")
+		}
+		if os.Getenv("NETBOX_SERVER_URL") == "" {
+			os.Setenv("NETBOX_SERVER_URL", cassetteReplayServerURL)
+		}
+		if os.Getenv("NETBOX_API_TOKEN") == "" {
+			os.Setenv("NETBOX_API_TOKEN", cassetteRedacted)
+		}
+	} else if token := os.Getenv("NETBOX_API_TOKEN"); token != "" {
+		c.secrets = append(c.secrets, token)
+	}
+
+	activeCassetteMu.Lock()
+	activeCassette = c
+	activeCassetteMu.Unlock()
+
+	t.Cleanup(func() {
+		activeCassetteMu.Lock()
+		activeCassette = nil
+		activeCassetteMu.Unlock()
+
+		if mode == cassetteModeRecord && !t.Failed() {
+			if err := c.save(); err != nil {
+				t.Errorf("error writing cassette %s: %s", c.path, err)
+			}
+		}
+	})
+}
+
+func (c *cassette) save() error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, append(data, '\n'), 0o644)
+}
+
+func (c *cassette) redact(s string) string {
+	for _, secret := range c.secrets {
+		s = strings.ReplaceAll(s, secret, cassetteRedacted)
+	}
+	return s
+}
+
+// record sends the request to Netbox and adds it to the cassette with its
+// response. Requests failing without a response are not recorded.
+func (c *cassette) record(original http.RoundTripper, r *http.Request, body []byte) (*http.Response, error) {
+	resp, err := original.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	header := map[string]string{}
+	for _, name := range cassetteHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			header[name] = value
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Interactions = append(c.Interactions, &cassetteInteraction{
+		Request: cassetteRequest{
+			Method: r.Method,
+			URI:    c.redact(r.URL.RequestURI()),
+			Body:   c.redact(string(body)),
+		},
+		Response: cassetteResponse{
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			Body:       c.redact(string(respBody)),
+		},
+	})
+	return resp, nil
+}
+
+// replay returns the response to the first interaction with the same method,
+// path, query and body as the request that was not replayed yet. Identical
+// requests, e.g. reading the same object several times, are answered in the
+// order they were recorded.
+func (c *cassette) replay(r *http.Request, body []byte) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, interaction := range c.Interactions {
+		request := interaction.Request
+		if interaction.replayed || request.Method != r.Method || request.URI != r.URL.RequestURI() || request.Body != string(body) {
+			continue
+		}
+		interaction.replayed = true
+
+		resp := &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{},
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       r,
+		}
+		for name, value := range interaction.Response.Header {
+			resp.Header.Set(name, value)
+		}
+		return resp, nil
+	}
+	return nil, fmt.Errorf("no recorded interaction left for %s %s in cassette %s, record it again with %s=%s", r.Method, r.URL.RequestURI(), c.path, cassetteModeEnv, cassetteModeRecord)
+}
+
+// cassetteTransport records or replays the requests of the active cassette.
+// Without an active cassette, requests are sent to Netbox as they are.
+type cassetteTransport struct {
+	original http.RoundTripper
+}
+
+func (t cassetteTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	activeCassetteMu.Lock()
+	c := activeCassette
+	activeCassetteMu.Unlock()
+	if c == nil {
+		return t.original.RoundTrip(r)
+	}
+
+	var body []byte
+	if r.Body != nil {
+		var err error
+		body, err = io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if c.mode == cassetteModeReplay {
+		return c.replay(r, body)
+	}
+	return c.record(t.original, r, body)
+}
+
+// cassetteRandomString returns a string of the given length from the
+// characters of charset that only depends on the calling test, key and how
+// often the test asked for a string with this key before. It stands in for
+// random strings in test names, so that the requests of tests are the same
+// when recording and replaying them.
+func cassetteRandomString(key string, length int, charset string) string {
+	key = callingTestName() + "/" + key
+
+	cassetteNamesMu.Lock()
+	n := cassetteNames[key]
+	cassetteNames[key]++
+	cassetteNamesMu.Unlock()
+
+	result := make([]byte, length)
+	var seed []byte
+	for i := range result {
+		if i%8 == 0 {
+			sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%d/%d", key, n, i)))
+			seed = sum[:]
+		}
+		result[i] = charset[binary.BigEndian.Uint32(seed[i%8*4:])%uint32(len(charset))]
+	}
+	return string(result)
+}
+
+// callingTestName returns the name of the test function of this package on
+// the call stack, or an empty string if there is none.
+func callingTestName() string {
+	pc := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pc[:runtime.Callers(2, pc)])
+	for {
+		frame, more := frames.Next()
+		name := frame.Function[strings.LastIndex(frame.Function, "/")+1:]
+		if strings.HasPrefix(name, "netbox.Test") {
+			name = strings.TrimPrefix(name, "netbox.")
+			if i := strings.Index(name, "."); i >= 0 {
+				name = name[:i]
+			}
+			return name
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+func TestCassette(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "1")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"token":%q,"request":%q}`, r.URL.Query().Get("token"), body)
+	}))
+	defer server.Close()
+
+	recorder := &cassette{mode: cassetteModeRecord, secrets: []string{"secret"}}
+	activeCassette = recorder
+	defer func() { activeCassette = nil }()
+	client := &http.Client{Transport: cassetteTransport{original: http.DefaultTransport}}
+
+	resp, err := client.Post(server.URL+"/api/dcim/sites/?token=secret", "application/json", strings.NewReader(`{"name":"a"}`))
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, `{"token":"secret","request":"{\"name\":\"a\"}"}`, string(body))
+	assert.Equal(t, []*cassetteInteraction{{
+		Request:  cassetteRequest{Method: "POST", URI: "/api/dcim/sites/?token=REDACTED", Body: `{"name":"a"}`},
+		Response: cassetteResponse{StatusCode: http.StatusCreated, Header: map[string]string{"Content-Type": "application/json"}, Body: `{"token":"REDACTED","request":"{\"name\":\"a\"}"}`},
+	}}, recorder.Interactions)
+
+	player := &cassette{mode: cassetteModeReplay, Interactions: recorder.Interactions}
+	player.Interactions[0].Request.URI = "/api/dcim/sites/"
+	activeCassette = player
+
+	_, err = client.Post(server.URL+"/api/dcim/sites/", "application/json", strings.NewReader(`{"name":"b"}`))
+	assert.ErrorContains(t, err, "no recorded interaction left for POST /api/dcim/sites/")
+
+	resp, err = client.Post("http://netbox.invalid/api/dcim/sites/", "application/json", strings.NewReader(`{"name":"a"}`))
+	assert.NoError(t, err)
+	body, _ = io.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.Equal(t, `{"token":"REDACTED","request":"{\"name\":\"a\"}"}`, string(body))
+
+	_, err = client.Post("http://netbox.invalid/api/dcim/sites/", "application/json", strings.NewReader(`{"name":"a"}`))
+	assert.Error(t, err, "interactions are only replayed once")
+}
+
+func TestCassetteRandomString(t *testing.T) {
+	a := cassetteRandomString("slug", 10, "abc")
+	b := cassetteRandomString("slug", 10, "abc")
+	assert.Len(t, a, 10)
+	assert.NotEqual(t, a, b)
+	assert.Regexp(t, "^[abc]+$", a)
+	assert.Equal(t, "TestCassetteRandomString", callingTestName())
+}
@@ -1,24 +1,51 @@
 package netbox
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net/http"
+	"os"
 	"time"
 
 	netboxclient "github.com/fbreckle/go-netbox/netbox/client"
+	"github.com/go-openapi/runtime"
 	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
 	"github.com/goware/urlx"
-	log "github.com/sirupsen/logrus"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Config struct for the netbox provider
 type Config struct {
 	APIToken                    string
+	APITokenFile                string
+	APITokenCommand             string
 	ServerURL                   string
 	AllowInsecureHTTPS          bool
 	Headers                     map[string]interface{}
 	RequestTimeout              int
 	StripTrailingSlashesFromURL bool
+	MaxRetries                  int
+	RetryMinWait                int
+	RetryMaxWait                int
+	MaxConcurrentRequests       int
+	RequestsPerSecond           float64
+	CACertFile                  string
+	CACertPEM                   string
+	ClientCertFile              string
+	ClientCertPEM               string
+	ClientKeyFile               string
+	ClientKeyPEM                string
+	IgnoreTagNames              []string
+	IgnoreTagNamePrefixes       []string
+	ReadOnly                    bool
+	Branch                      string
+
+	// wrapTransport wraps the HTTP transport of the client, if set. The
+	// acceptance tests use it to record and replay the traffic to Netbox.
+	wrapTransport func(http.RoundTripper) http.RoundTripper
 }
 
 // customHeaderTransport is a transport that adds the specified headers on
@@ -30,12 +57,20 @@ type customHeaderTransport struct {
 
 // Client does the heavy lifting of establishing a base Open API client to Netbox.
 func (cfg *Config) Client() (*netboxclient.NetBoxAPI, error) {
-	log.WithFields(log.Fields{
+	return cfg.ClientWithContext(context.Background())
+}
+
+// ClientWithContext is like Client, but logs through the terraform-plugin-log
+// logger in ctx. The HTTP traffic to Netbox is logged in the netbox_http
+// subsystem.
+func (cfg *Config) ClientWithContext(ctx context.Context) (*netboxclient.NetBoxAPI, error) {
+	tflog.Debug(ctx, "Initializing Netbox client", map[string]interface{}{
 		"server_url": cfg.ServerURL,
-	}).Debug("Initializing Netbox client")
+	})
 
-	if cfg.APIToken == "" {
-		return nil, fmt.Errorf("missing netbox API key")
+	tokenSource, err := cfg.apiTokenSource(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	// parse serverUrl
@@ -45,16 +80,27 @@ func (cfg *Config) Client() (*netboxclient.NetBoxAPI, error) {
 	}
 
 	desiredRuntimeClientSchemes := []string{parsedURL.Scheme}
-	log.WithFields(log.Fields{
+	tflog.Debug(ctx, "Initializing Netbox Open API runtime client", map[string]interface{}{
 		"host":    parsedURL.Host,
 		"schemes": desiredRuntimeClientSchemes,
-	}).Debug("Initializing Netbox Open API runtime client")
+	})
 
 	// build http client
 	clientOpts := httptransport.TLSClientOptions{
 		InsecureSkipVerify: cfg.AllowInsecureHTTPS,
 	}
 
+	caCertPool, err := cfg.loadCACertPool()
+	if err != nil {
+		return nil, err
+	}
+	clientOpts.LoadedCAPool = caCertPool
+
+	clientCert, err := cfg.loadClientCertificate()
+	if err != nil {
+		return nil, err
+	}
+
 	trans, err := httptransport.TLSTransport(clientOpts)
 	if err != nil {
 		return nil, err
@@ -62,10 +108,31 @@ func (cfg *Config) Client() (*netboxclient.NetBoxAPI, error) {
 
 	trans.(*http.Transport).Proxy = http.ProxyFromEnvironment
 
+	if clientCert != nil {
+		tflog.Debug(ctx, "Using client certificate for requests to Netbox")
+		trans.(*http.Transport).TLSClientConfig.Certificates = []tls.Certificate{*clientCert}
+	}
+
+	if cfg.wrapTransport != nil {
+		trans = cfg.wrapTransport(trans)
+	}
+
+	httpLogCtx := newHTTPLogContext(ctx)
+
+	// Values of custom headers may be secrets, so they are not logged
+	customHeaderNames := make([]string, 0, len(cfg.Headers))
+	for name := range cfg.Headers {
+		customHeaderNames = append(customHeaderNames, name)
+	}
+
+	// The logging transport is placed directly above the HTTP transport, so
+	// every attempt is logged with the headers that are actually sent
+	trans = newHTTPLoggingTransport(httpLogCtx, trans, customHeaderNames)
+
 	if cfg.Headers != nil && len(cfg.Headers) > 0 {
-		log.WithFields(log.Fields{
-			"custom_headers": cfg.Headers,
-		}).Debug("Setting custom headers on every request to Netbox")
+		tflog.Debug(ctx, "Setting custom headers on every request to Netbox", map[string]interface{}{
+			"custom_headers": customHeaderNames,
+		})
 
 		trans = customHeaderTransport{
 			original: trans,
@@ -73,19 +140,166 @@ func (cfg *Config) Client() (*netboxclient.NetBoxAPI, error) {
 		}
 	}
 
+	if cfg.MaxConcurrentRequests > 0 || cfg.RequestsPerSecond > 0 {
+		tflog.Debug(ctx, "Limiting requests to Netbox", map[string]interface{}{
+			"max_concurrent_requests": cfg.MaxConcurrentRequests,
+			"requests_per_second":     cfg.RequestsPerSecond,
+		})
+
+		trans = newLimitTransport(trans, cfg.MaxConcurrentRequests, cfg.RequestsPerSecond)
+	}
+
+	// The request timeout is enforced by the retry transport so that it
+	// applies to every single attempt instead of all attempts combined
+	trans = retryTransport{
+		original:       trans,
+		maxRetries:     cfg.MaxRetries,
+		minWait:        time.Second * time.Duration(cfg.RetryMinWait),
+		maxWait:        time.Second * time.Duration(cfg.RetryMaxWait),
+		attemptTimeout: time.Second * time.Duration(cfg.RequestTimeout),
+		logCtx:         httpLogCtx,
+	}
+
+	if cfg.Branch != "" {
+		tflog.Debug(ctx, "Sending all requests to a branch of the Netbox Branching plugin", map[string]interface{}{
+			"branch": cfg.Branch,
+		})
+
+		apiURL := *parsedURL
+		apiURL.Path = parsedURL.Path + netboxclient.DefaultBasePath
+		trans = newBranchTransport(trans, &apiURL, cfg.Branch)
+	}
+
+	ignored := cfg.ignoredTags()
+	if !ignored.isEmpty() {
+		trans = preserveIgnoredTagsTransport{
+			original: trans,
+			ignored:  ignored,
+		}
+	}
+
+	if cfg.ReadOnly {
+		tflog.Debug(ctx, "Refusing all requests to Netbox that could modify data")
+		trans = readOnlyTransport{original: trans}
+	}
+
 	httpClient := &http.Client{
 		Transport: trans,
-		Timeout:   time.Second * time.Duration(cfg.RequestTimeout),
 	}
 
 	transport := httptransport.NewWithClient(parsedURL.Host, parsedURL.Path+netboxclient.DefaultBasePath, desiredRuntimeClientSchemes, httpClient)
-	transport.DefaultAuthentication = httptransport.APIKeyAuth("Authorization", "header", fmt.Sprintf("Token %v", cfg.APIToken))
-	transport.SetLogger(log.StandardLogger())
-	netboxClient := netboxclient.New(transport, nil)
+	transport.DefaultAuthentication = runtime.ClientAuthInfoWriterFunc(func(r runtime.ClientRequest, _ strfmt.Registry) error {
+		token, err := tokenSource.Token()
+		if err != nil {
+			return err
+		}
+		return r.SetHeaderParam("Authorization", fmt.Sprintf("Token %v", token))
+	})
+	// The debug output of the runtime contains the API token, all traffic is
+	// logged by the logging transport instead
+	transport.Debug = false
+	netboxClient := netboxclient.New(contextDeadlineTransport{transport}, nil)
 
 	return netboxClient, nil
 }
 
+// ignoredTags returns the tags that are managed outside of Terraform.
+func (cfg *Config) ignoredTags() ignoredTags {
+	return ignoredTags{
+		names:        cfg.IgnoreTagNames,
+		namePrefixes: cfg.IgnoreTagNamePrefixes,
+	}
+}
+
+// apiTokenSource returns the source of the API token. Exactly one of the
+// token, the token file and the token command is expected to be set.
+func (cfg *Config) apiTokenSource(ctx context.Context) (apiTokenSource, error) {
+	switch {
+	case cfg.APITokenFile != "":
+		source := &fileAPIToken{path: cfg.APITokenFile}
+		// Read the file once to fail early if it is missing
+		if _, err := source.Token(); err != nil {
+			return nil, err
+		}
+		return source, nil
+	case cfg.APITokenCommand != "":
+		tflog.Debug(ctx, "Running command to retrieve the Netbox API token", map[string]interface{}{
+			"command": cfg.APITokenCommand,
+		})
+
+		token, err := runAPITokenCommand(ctx, cfg.APITokenCommand)
+		if err != nil {
+			return nil, err
+		}
+		return staticAPIToken(token), nil
+	case cfg.APIToken != "":
+		return staticAPIToken(cfg.APIToken), nil
+	}
+	return nil, fmt.Errorf("missing netbox API key")
+}
+
+// loadCACertPool returns the system certificate pool extended by the
+// configured CA certificates, or nil if no CA certificates are configured.
+func (cfg *Config) loadCACertPool() (*x509.CertPool, error) {
+	caCertPEM, err := readFileOrString(cfg.CACertFile, cfg.CACertPEM, "CA certificate")
+	if err != nil {
+		return nil, err
+	}
+	if len(caCertPEM) == 0 {
+		return nil, nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(caCertPEM) {
+		return nil, fmt.Errorf("no valid PEM encoded CA certificate found")
+	}
+	return pool, nil
+}
+
+// loadClientCertificate returns the configured client certificate used for
+// mutual TLS, or nil if no client certificate is configured.
+func (cfg *Config) loadClientCertificate() (*tls.Certificate, error) {
+	certPEM, err := readFileOrString(cfg.ClientCertFile, cfg.ClientCertPEM, "client certificate")
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := readFileOrString(cfg.ClientKeyFile, cfg.ClientKeyPEM, "client key")
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(certPEM) == 0 && len(keyPEM) == 0:
+		return nil, nil
+	case len(certPEM) == 0:
+		return nil, fmt.Errorf("a client key was given without a client certificate")
+	case len(keyPEM) == 0:
+		return nil, fmt.Errorf("a client certificate was given without a client key")
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("error loading client certificate: %w", err)
+	}
+	return &cert, nil
+}
+
+// readFileOrString returns the contents of the file at path if path is set
+// and value otherwise.
+func readFileOrString(path, value, description string) ([]byte, error) {
+	if path == "" {
+		return []byte(value), nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s file: %w", description, err)
+	}
+	return content, nil
+}
+
 // RoundTrip adds the headers specified in the transport on every request.
 func (t customHeaderTransport) RoundTrip(r *http.Request) (*http.Response, error) {
 	for key, value := range t.headers {
@@ -1,9 +1,20 @@
 package netbox
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/fbreckle/go-netbox/netbox/client/status"
 	"github.com/stretchr/testify/assert"
@@ -87,6 +98,148 @@ func TestAdditionalHeadersSet(t *testing.T) {
 	client.Status.StatusList(req, nil)
 }
 
-/* TODO
-func TestInvalidHttpsCertificate(t *testing.T) {}
-*/
+func TestInvalidHttpsCertificate(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:   "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL:  ts.URL,
+		MaxRetries: 3,
+	}
+
+	client, err := config.Client()
+	assert.NoError(t, err)
+
+	_, err = client.Status.StatusList(status.NewStatusListParams(), nil)
+	assert.ErrorContains(t, err, "certificate")
+}
+
+func TestCustomCACertificate(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	caCertPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	assert.NoError(t, os.WriteFile(caCertFile, caCertPEM, 0600))
+
+	for _, config := range []Config{
+		{
+			APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+			ServerURL: ts.URL,
+			CACertPEM: string(caCertPEM),
+		},
+		{
+			APIToken:   "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+			ServerURL:  ts.URL,
+			CACertFile: caCertFile,
+		},
+	} {
+		client, err := config.Client()
+		assert.NoError(t, err)
+
+		_, err = client.Status.StatusList(status.NewStatusListParams(), nil)
+		assert.NoError(t, err)
+	}
+}
+
+func TestInvalidCACertificate(t *testing.T) {
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: "https://localhost:8080",
+		CACertPEM: "not a certificate",
+	}
+
+	_, err := config.Client()
+	assert.Error(t, err)
+
+	config = Config{
+		APIToken:   "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL:  "https://localhost:8080",
+		CACertFile: filepath.Join(t.TempDir(), "missing.pem"),
+	}
+
+	_, err = config.Client()
+	assert.Error(t, err)
+}
+
+func TestClientCertificate(t *testing.T) {
+	certPEM, keyPEM := testGenerateClientCertificate(t)
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if assert.Len(t, r.TLS.PeerCertificates, 1) {
+			assert.Equal(t, "terraform", r.TLS.PeerCertificates[0].Subject.CommonName)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	ts.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	ts.StartTLS()
+	defer ts.Close()
+
+	certFile := filepath.Join(t.TempDir(), "client.pem")
+	assert.NoError(t, os.WriteFile(certFile, certPEM, 0600))
+	keyFile := filepath.Join(t.TempDir(), "client.key")
+	assert.NoError(t, os.WriteFile(keyFile, keyPEM, 0600))
+
+	for _, config := range []Config{
+		{
+			APIToken:           "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+			ServerURL:          ts.URL,
+			AllowInsecureHTTPS: true,
+			ClientCertPEM:      string(certPEM),
+			ClientKeyPEM:       string(keyPEM),
+		},
+		{
+			APIToken:           "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+			ServerURL:          ts.URL,
+			AllowInsecureHTTPS: true,
+			ClientCertFile:     certFile,
+			ClientKeyFile:      keyFile,
+		},
+	} {
+		client, err := config.Client()
+		assert.NoError(t, err)
+
+		_, err = client.Status.StatusList(status.NewStatusListParams(), nil)
+		assert.NoError(t, err)
+	}
+}
+
+func TestClientCertificateWithoutKey(t *testing.T) {
+	certPEM, _ := testGenerateClientCertificate(t)
+
+	config := Config{
+		APIToken:      "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL:     "https://localhost:8080",
+		ClientCertPEM: string(certPEM),
+	}
+
+	_, err := config.Client()
+	assert.ErrorContains(t, err, "without a client key")
+}
+
+func testGenerateClientCertificate(t *testing.T) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	return certPEM, keyPEM
+}
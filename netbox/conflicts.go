@@ -0,0 +1,141 @@
+package netbox
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const lastUpdatedKey = "last_updated"
+
+var lastUpdatedSchema = &schema.Schema{
+	Type:        schema.TypeString,
+	Computed:    true,
+	Description: "Time of the last change of the object in Netbox. It is used to detect changes made outside of Terraform between plan and apply, see `conflict_mode`.",
+}
+
+func formatLastUpdated(t *strfmt.DateTime) string {
+	if t == nil {
+		return ""
+	}
+	return t.String()
+}
+
+const (
+	conflictModeError  = "error"
+	conflictModeWarn   = "warn"
+	conflictModeIgnore = "ignore"
+)
+
+var conflictModeOptions = []string{conflictModeError, conflictModeWarn, conflictModeIgnore}
+
+// addConflictChecks adds a check for concurrent changes to the update
+// function of every resource with a `last_updated` attribute. Before an
+// object is updated, it is read again. If it was changed in Netbox since it
+// was last read and the changes overlap with the planned changes, the update
+// fails or warns about the overwritten changes, depending on
+// `conflict_mode`.
+func addConflictChecks(resources map[string]*schema.Resource) {
+	for resourceType, r := range resources {
+		if _, ok := r.Schema[lastUpdatedKey]; !ok || r.UpdateContext == nil {
+			continue
+		}
+		r.UpdateContext = conflictCheck(resourceType, r, r.UpdateContext)
+		appendCustomizeDiff(r, lastUpdatedDiff)
+	}
+}
+
+// lastUpdatedDiff marks `last_updated` as changing whenever an object is
+// going to be updated.
+func lastUpdatedDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	for _, key := range d.GetChangedKeysPrefix("") {
+		if key != lastUpdatedKey {
+			return d.SetNewComputed(lastUpdatedKey)
+		}
+	}
+	return nil
+}
+
+func conflictCheck(resourceType string, r *schema.Resource, update schema.UpdateContextFunc) schema.UpdateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		state, ok := m.(*providerState)
+		if !ok || state.conflictMode == conflictModeIgnore {
+			return update(ctx, d, m)
+		}
+
+		conflicts, diags := findConflicts(ctx, r, d, m)
+		if diags.HasError() {
+			return diags
+		}
+		if len(conflicts) > 0 {
+			attributes := "`" + strings.Join(conflicts, "`, `") + "`"
+			if state.conflictMode == conflictModeWarn {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("%s %s was changed in Netbox since it was last read", resourceType, d.Id()),
+					Detail:   fmt.Sprintf("The changes to %s made in Netbox were overwritten.", attributes),
+				})
+			} else {
+				return append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  fmt.Sprintf("%s %s was changed in Netbox since it was last read", resourceType, d.Id()),
+					Detail:   fmt.Sprintf("%s were changed in Netbox and would be overwritten. Refresh and plan again to review the changes, or set `conflict_mode` to `warn` to overwrite them.", attributes),
+				})
+			}
+		}
+		return append(diags, update(ctx, d, m)...)
+	}
+}
+
+// findConflicts reads the object again and returns the attributes that are
+// going to be updated, but were changed in Netbox since the object was last
+// read.
+func findConflicts(ctx context.Context, r *schema.Resource, d *schema.ResourceData, m interface{}) ([]string, diag.Diagnostics) {
+	lastUpdated, _ := d.GetChange(lastUpdatedKey)
+	if lastUpdated == "" {
+		return nil, nil
+	}
+
+	// Read the object into a copy of the prior state, so the current values
+	// are converted exactly like during a refresh
+	current := r.Data(nil)
+	current.SetId(d.Id())
+	for key := range r.Schema {
+		old, _ := d.GetChange(key)
+		current.Set(key, old)
+	}
+	diags := r.ReadContext(ctx, current, m)
+	if diags.HasError() || current.Id() == "" || current.Get(lastUpdatedKey) == lastUpdated {
+		return nil, diags
+	}
+
+	var conflicts []string
+	for key := range r.Schema {
+		if key == lastUpdatedKey || !d.HasChange(key) {
+			continue
+		}
+		old, _ := d.GetChange(key)
+		if !attributeValuesEqual(old, current.Get(key)) {
+			conflicts = append(conflicts, key)
+		}
+	}
+	sort.Strings(conflicts)
+	return conflicts, diags
+}
+
+func attributeValuesEqual(a, b interface{}) bool {
+	if set, ok := a.(*schema.Set); ok {
+		other, ok := b.(*schema.Set)
+		return ok && set.Equal(other)
+	}
+	return reflect.DeepEqual(a, b)
+}
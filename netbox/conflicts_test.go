@@ -0,0 +1,89 @@
+package netbox
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConflictChecks(t *testing.T) {
+	var updated bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodGet {
+			updated = true
+		}
+		w.Write([]byte(`{"id": 1, "name": "test", "slug": "test", "status": {"value": "active"}, "description": "changed in netbox", "last_updated": "2024-02-01T00:00:00.000Z"}`))
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL,
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+
+	r := Provider().ResourcesMap["netbox_site"]
+	update := func(mode string, changes map[string]interface{}) diag.Diagnostics {
+		updated = false
+		state := &providerState{NetBoxAPI: client, tags: newTagCache(), conflictMode: mode}
+		oldState := &terraform.InstanceState{
+			ID: "1",
+			Attributes: map[string]string{
+				"id":           "1",
+				"name":         "test",
+				"slug":         "test",
+				"status":       "active",
+				"description":  "old",
+				"last_updated": "2024-01-01T00:00:00.000Z",
+			},
+		}
+		config := map[string]interface{}{
+			"name":        "test",
+			"slug":        "test",
+			"status":      "active",
+			"description": "old",
+		}
+		for k, v := range changes {
+			config[k] = v
+		}
+		diff, err := r.Diff(context.Background(), oldState, terraform.NewResourceConfigRaw(config), state)
+		assert.NoError(t, err)
+		assert.True(t, diff.Attributes[lastUpdatedKey].NewComputed)
+		d, err := schema.InternalMap(r.Schema).Data(oldState, diff)
+		assert.NoError(t, err)
+		return r.UpdateContext(context.Background(), d, state)
+	}
+
+	// Changes made in Netbox to an attribute that is changed, too, fail the
+	// update
+	diags := update(conflictModeError, map[string]interface{}{"description": "new"})
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Detail, "`description`")
+	assert.False(t, updated)
+
+	// or overwrite them with a warning
+	diags = update(conflictModeWarn, map[string]interface{}{"description": "new"})
+	assert.False(t, diags.HasError(), diags)
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, diag.Warning, diags[0].Severity)
+		assert.Contains(t, diags[0].Detail, "`description`")
+	}
+	assert.True(t, updated)
+
+	diags = update(conflictModeIgnore, map[string]interface{}{"description": "new"})
+	assert.Empty(t, diags)
+	assert.True(t, updated)
+
+	// Changes to other attributes do not conflict
+	diags = update(conflictModeError, map[string]interface{}{"facility": "new"})
+	assert.Empty(t, diags)
+	assert.True(t, updated)
+}
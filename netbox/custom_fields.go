@@ -1,6 +1,13 @@
 package netbox
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/fbreckle/go-netbox/netbox/client/extras"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -16,6 +23,53 @@ var customFieldsSchema = &schema.Schema{
 	},
 }
 
+const customFieldsJSONKey = "custom_fields_json"
+
+var customFieldsJSONSchema = &schema.Schema{
+	Type:         schema.TypeString,
+	Optional:     true,
+	ValidateFunc: validateCustomFieldsJSON,
+	DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+		equal, _ := jsonSemanticCompare(oldValue, newValue)
+		return equal
+	},
+	DiffSuppressOnRefresh: true,
+	Description:           "Custom fields as a JSON object, e.g. `jsonencode({ rating = 5, active = true })`. Unlike `custom_fields`, this supports all types of custom fields. Object and multi-object custom fields are set to the ID or a list of IDs of the referenced objects. Custom fields set here take precedence over the ones set in `custom_fields`. Only the custom fields set here are read back from Netbox. To clear a custom field, set it to `null`.",
+}
+
+func validateCustomFieldsJSON(i interface{}, k string) ([]string, []error) {
+	if _, err := decodeCustomFieldsJSON(i.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%q is not a valid JSON object: %w", k, err)}
+	}
+	return nil, nil
+}
+
+// decodeCustomFieldsJSON decodes the value of custom_fields_json. Numbers are
+// kept as json.Number, so that they are sent to Netbox exactly as given.
+func decodeCustomFieldsJSON(value string) (map[string]interface{}, error) {
+	if value == "" {
+		return nil, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader([]byte(value)))
+	decoder.UseNumber()
+	var customFields map[string]interface{}
+	if err := decoder.Decode(&customFields); err != nil {
+		return nil, err
+	}
+	if customFields == nil {
+		return nil, errors.New("expected an object")
+	}
+	return customFields, nil
+}
+
+// getTypedCustomFields returns the decoded custom_fields_json of a resource.
+func getTypedCustomFields(d *schema.ResourceData) map[string]interface{} {
+	value, _ := d.Get(customFieldsJSONKey).(string)
+	// The JSON is validated during plan
+	customFields, _ := decodeCustomFieldsJSON(value)
+	return customFields
+}
+
 func getCustomFields(cf interface{}) map[string]interface{} {
 	cfm, ok := cf.(map[string]interface{})
 	if !ok || len(cfm) == 0 {
@@ -23,3 +77,132 @@ func getCustomFields(cf interface{}) map[string]interface{} {
 	}
 	return cfm
 }
+
+// getCustomFieldsFromResourceData returns the custom fields to send to Netbox
+// for a resource of the given object type, e.g. `dcim.site`. These are the
+// custom fields of the resource merged with the provider default custom fields
+// assigned to the object type. Values set on the resource take precedence.
+// If there are no custom fields, nil is returned.
+func getCustomFieldsFromResourceData(api *providerState, d *schema.ResourceData, objectType string) interface{} {
+	customFields := map[string]interface{}{}
+	for name, value := range api.defaultCustomFields {
+		if slices.Contains(api.defaultCustomFieldObjectTypes[name], objectType) {
+			customFields[name] = value
+		}
+	}
+	if cf, ok := d.GetOk(customFieldsKey); ok {
+		for name, value := range cf.(map[string]interface{}) {
+			customFields[name] = value
+		}
+	}
+	for name, value := range getTypedCustomFields(d) {
+		customFields[name] = value
+	}
+
+	if len(customFields) == 0 {
+		return nil
+	}
+	return customFields
+}
+
+// getResourceCustomFields returns the custom fields of a resource as they are
+// stored in its `custom_fields` attribute. Custom fields set in
+// `custom_fields_json`, provider default custom fields and ignored custom
+// fields are left out unless they are also set in `custom_fields`, so that
+// they do not show up as drift.
+func getResourceCustomFields(api *providerState, d *schema.ResourceData, cf interface{}) map[string]interface{} {
+	cfm := getCustomFields(cf)
+	typedCustomFields := getTypedCustomFields(d)
+	if cfm == nil || (len(api.defaultCustomFields) == 0 && len(api.ignoredCustomFields) == 0 && len(typedCustomFields) == 0) {
+		return cfm
+	}
+
+	// Resources without a `custom_fields` attribute have no custom fields set
+	// explicitly
+	resourceCustomFields, _ := d.Get(customFieldsKey).(map[string]interface{})
+	filtered := map[string]interface{}{}
+	for name, value := range cfm {
+		_, isDefault := api.defaultCustomFields[name]
+		_, isTyped := typedCustomFields[name]
+		hidden := isDefault || isTyped || slices.Contains(api.ignoredCustomFields, name)
+		if _, ok := resourceCustomFields[name]; hidden && !ok {
+			continue
+		}
+		filtered[name] = value
+	}
+	return getCustomFields(filtered)
+}
+
+// getResourceCustomFieldsJSON returns the custom fields of a resource as
+// they are stored in its `custom_fields_json` attribute. Only the custom
+// fields already set in the attribute are returned. References to objects
+// are returned as IDs, as they are set.
+func getResourceCustomFieldsJSON(d *schema.ResourceData, cf interface{}) (string, error) {
+	typedCustomFields := getTypedCustomFields(d)
+	if typedCustomFields == nil {
+		return "", nil
+	}
+
+	cfm, _ := cf.(map[string]interface{})
+	result := make(map[string]interface{}, len(typedCustomFields))
+	for name, configured := range typedCustomFields {
+		result[name] = normalizeCustomFieldValue(cfm[name], configured)
+	}
+
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// normalizeCustomFieldValue converts a custom field value read from Netbox to
+// the form it is configured in. Netbox returns the objects referenced by
+// object and multi-object custom fields as nested objects, but they are set
+// by ID. As JSON custom fields can contain anything, the configured value
+// decides whether a nested object is replaced by its ID.
+func normalizeCustomFieldValue(value interface{}, configured interface{}) interface{} {
+	switch c := configured.(type) {
+	case json.Number:
+		if object, ok := value.(map[string]interface{}); ok {
+			if id, ok := object["id"]; ok {
+				return id
+			}
+		}
+	case []interface{}:
+		values, ok := value.([]interface{})
+		if !ok || len(c) == 0 {
+			return value
+		}
+		if _, ok := c[0].(json.Number); !ok {
+			return value
+		}
+		normalized := make([]interface{}, len(values))
+		for i, v := range values {
+			normalized[i] = normalizeCustomFieldValue(v, c[0])
+		}
+		return normalized
+	}
+	return value
+}
+
+// getCustomFieldObjectTypes looks up the custom fields with the given names
+// and returns the object types each of them is assigned to.
+func getCustomFieldObjectTypes(api *providerState, customFields map[string]interface{}) (map[string][]string, error) {
+	objectTypes := map[string][]string{}
+	for name := range customFields {
+		params := extras.NewExtrasCustomFieldsListParams()
+		params.Name = &name
+		res, err := api.Extras.ExtrasCustomFieldsList(params, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving custom field %s from netbox: %w", name, err)
+		}
+		if *res.GetPayload().Count == 0 {
+			return nil, fmt.Errorf("custom field %s set in `default_custom_fields` does not exist in netbox", name)
+		}
+		field := res.GetPayload().Results[0]
+		// Netbox versions before 4.0 call the object types content types
+		objectTypes[name] = append(field.ObjectTypes, field.ContentTypes...)
+	}
+	return objectTypes, nil
+}
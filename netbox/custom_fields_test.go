@@ -0,0 +1,152 @@
+package netbox
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func testCustomFieldsProviderState() *providerState {
+	return &providerState{
+		defaultCustomFields: map[string]interface{}{
+			"owner":       "team-x",
+			"cost_center": "1234",
+		},
+		defaultCustomFieldObjectTypes: map[string][]string{
+			"owner":       {"dcim.site", "dcim.device"},
+			"cost_center": {"dcim.site"},
+		},
+		ignoredCustomFields: []string{"last_seen"},
+	}
+}
+
+func TestGetCustomFieldsFromResourceData(t *testing.T) {
+	api := testCustomFieldsProviderState()
+	s := map[string]*schema.Schema{customFieldsKey: customFieldsSchema}
+
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		customFieldsKey: map[string]interface{}{
+			"owner":  "team-y",
+			"rating": "5",
+		},
+	})
+	assert.Equal(t, map[string]interface{}{
+		"owner":       "team-y",
+		"cost_center": "1234",
+		"rating":      "5",
+	}, getCustomFieldsFromResourceData(api, d, "dcim.site"))
+
+	// Default custom fields are only added to the object types they are
+	// assigned to
+	empty := schema.TestResourceDataRaw(t, s, map[string]interface{}{})
+	assert.Equal(t, map[string]interface{}{
+		"owner": "team-x",
+	}, getCustomFieldsFromResourceData(api, empty, "dcim.device"))
+	assert.Nil(t, getCustomFieldsFromResourceData(api, empty, "ipam.prefix"))
+}
+
+func TestGetResourceCustomFields(t *testing.T) {
+	api := testCustomFieldsProviderState()
+	s := map[string]*schema.Schema{customFieldsKey: customFieldsSchema}
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		customFieldsKey: map[string]interface{}{
+			"owner": "team-y",
+		},
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"owner":  "team-y",
+		"rating": "5",
+	}, getResourceCustomFields(api, d, map[string]interface{}{
+		"owner":       "team-y",
+		"cost_center": "1234",
+		"last_seen":   "2024-01-01",
+		"rating":      "5",
+	}))
+
+	assert.Nil(t, getResourceCustomFields(api, d, map[string]interface{}{
+		"cost_center": "1234",
+	}))
+
+	// Custom fields of resources without a `custom_fields` attribute
+	withoutAttribute := schema.TestResourceDataRaw(t, map[string]*schema.Schema{"name": {Type: schema.TypeString, Optional: true}}, map[string]interface{}{})
+	assert.Equal(t, map[string]interface{}{
+		"rating": "5",
+	}, getResourceCustomFields(api, withoutAttribute, map[string]interface{}{
+		"owner":  "team-y",
+		"rating": "5",
+	}))
+}
+
+func TestValidateCustomFieldsJSON(t *testing.T) {
+	for value, valid := range map[string]bool{
+		``:                         true,
+		`{}`:                       true,
+		`{"rating": 5}`:            true,
+		`{"rating": 5`:             false,
+		`[1, 2]`:                   false,
+		`"text"`:                   false,
+		`null`:                     false,
+		`{"refs": [1, 2], "a": 1}`: true,
+	} {
+		_, errs := validateCustomFieldsJSON(value, customFieldsJSONKey)
+		assert.Equal(t, valid, len(errs) == 0, value)
+	}
+}
+
+func TestGetCustomFieldsFromResourceDataJSON(t *testing.T) {
+	s := map[string]*schema.Schema{
+		customFieldsKey:     customFieldsSchema,
+		customFieldsJSONKey: customFieldsJSONSchema,
+	}
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		customFieldsKey: map[string]interface{}{
+			"owner":  "team-y",
+			"rating": "5",
+		},
+		customFieldsJSONKey: `{"rating": 5, "active": true, "price": 12345678901234567890, "refs": [1, 2], "cleared": null}`,
+	})
+
+	customFields := getCustomFieldsFromResourceData(&providerState{}, d, "dcim.site")
+	encoded, err := json.Marshal(customFields)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"owner": "team-y", "rating": 5, "active": true, "price": 12345678901234567890, "refs": [1, 2], "cleared": null}`, string(encoded))
+	// Large numbers are sent exactly as given
+	assert.Contains(t, string(encoded), "12345678901234567890")
+}
+
+func TestGetResourceCustomFieldsJSON(t *testing.T) {
+	s := map[string]*schema.Schema{
+		customFieldsKey:     customFieldsSchema,
+		customFieldsJSONKey: customFieldsJSONSchema,
+	}
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		customFieldsJSONKey: `{"rating": 5, "active": true, "tenant": 3, "sites": [1, 2], "data": {"id": 7, "foo": "bar"}, "choices": ["a", "b"], "missing": null}`,
+	})
+
+	// As read from Netbox
+	cf := map[string]interface{}{
+		"rating":  float64(5),
+		"active":  true,
+		"tenant":  map[string]interface{}{"id": float64(3), "name": "Tenant", "url": "http://netbox/api/tenancy/tenants/3/"},
+		"sites":   []interface{}{map[string]interface{}{"id": float64(1)}, map[string]interface{}{"id": float64(2)}},
+		"data":    map[string]interface{}{"id": float64(7), "foo": "bar"},
+		"choices": []interface{}{"a", "b"},
+		"comment": "text",
+	}
+
+	cfJSON, err := getResourceCustomFieldsJSON(d, cf)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"rating": 5, "active": true, "tenant": 3, "sites": [1, 2], "data": {"id": 7, "foo": "bar"}, "choices": ["a", "b"], "missing": null}`, cfJSON)
+
+	// Custom fields in custom_fields_json are not shown in custom_fields
+	assert.Equal(t, map[string]interface{}{"comment": "text"}, getResourceCustomFields(&providerState{}, d, cf))
+
+	// Nothing is read back if custom_fields_json is not used
+	empty := schema.TestResourceDataRaw(t, s, map[string]interface{}{})
+	cfJSON, err = getResourceCustomFieldsJSON(empty, cf)
+	assert.NoError(t, err)
+	assert.Equal(t, "", cfJSON)
+}
@@ -49,6 +49,7 @@ func TestAccNetboxAsnDataSource_basic(t *testing.T) {
 	setUp := testAccNetboxAsnSetUp(testName)
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: setUp,
@@ -2,42 +2,32 @@ package netbox
 
 import (
 	"errors"
-	"fmt"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
+	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/go-openapi/runtime"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+var asnsFilters = &dataSourceFilters{
+	params: func() runtime.ClientRequestWriter { return ipam.NewIpamAsnsListParams() },
+}
+
 func dataSourceNetboxAsns() *schema.Resource {
 	return &schema.Resource{
 		Read:        dataSourceNetboxAsnsRead,
 		Description: `:meta:subcategory:IP Address Management (IPAM):`,
 		Schema: map[string]*schema.Schema{
-			"filter": {
-				Type:     schema.TypeSet,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"value": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-					},
-				},
-			},
+			"filter": asnsFilters.schema(),
 			"limit": {
 				Type:             schema.TypeInt,
 				Optional:         true,
-				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
-				Default:          0,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "The maximum number of objects to return. If unset or `0`, all matching objects are returned.",
 			},
+			"page_size": pageSizeSchema,
 			"asns": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -64,46 +54,29 @@ func dataSourceNetboxAsns() *schema.Resource {
 }
 
 func dataSourceNetboxAsnsRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	params := ipam.NewIpamAsnsListParams()
+	query := asnsFilters.query(d)
 
-	if limitValue, ok := d.GetOk("limit"); ok {
-		params.Limit = int64ToPtr(int64(limitValue.(int)))
-	}
-
-	if filter, ok := d.GetOk("filter"); ok {
-		var filterParams = filter.(*schema.Set)
-		for _, f := range filterParams.List() {
-			k := f.(map[string]interface{})["name"]
-			v := f.(map[string]interface{})["value"]
-			vString := v.(string)
-			switch k {
-			case "asn":
-				params.Asn = &vString
-			case "asn__gte":
-				params.AsnGte = &vString
-			case "asn__lte":
-				params.AsnLte = &vString
-			case "asn__n":
-				params.Asnn = &vString
-			default:
-				return fmt.Errorf("'%s' is not a supported filter parameter", k)
-			}
+	pageSize, limit := getPagination(d)
+	filteredAsns, err := listAll(pageSize, limit, nil, func(offset, limit int64) ([]*models.ASN, int64, error) {
+		params.Offset = &offset
+		params.Limit = &limit
+		res, err := api.Ipam.IpamAsnsList(params, nil, withQueryParams(query))
+		if err != nil {
+			return nil, 0, err
 		}
-	}
-
-	res, err := api.Ipam.IpamAsnsList(params, nil)
+		return res.GetPayload().Results, *res.GetPayload().Count, nil
+	})
 	if err != nil {
 		return err
 	}
 
-	if *res.GetPayload().Count == int64(0) {
+	if len(filteredAsns) == 0 {
 		return errors.New("no result")
 	}
 
-	filteredAsns := res.GetPayload().Results
-
 	var s []map[string]interface{}
 	for _, v := range filteredAsns {
 		var mapping = make(map[string]interface{})
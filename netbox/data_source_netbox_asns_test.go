@@ -72,6 +72,7 @@ func TestAccNetboxAsnsDataSource_basic(t *testing.T) {
 	// These ASNs then interfere with the __n filter test
 	resource.Test(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: setUp,
@@ -14,6 +14,7 @@ func TestAccNetboxAvailablePrefixesDataSource_basic(t *testing.T) {
 	testName := testAccGetTestName(testSlug)
 	resource.Test(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
@@ -63,6 +64,7 @@ func TestAccNetboxAvailablePrefixesDataSource_without_vrf(t *testing.T) {
 	testName := testAccGetTestName(testSlug)
 	resource.Test(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
@@ -105,6 +107,7 @@ func TestAccNetboxAvailablePrefixesDataSource_none_available(t *testing.T) {
 	testName := testAccGetTestName(testSlug)
 	resource.Test(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
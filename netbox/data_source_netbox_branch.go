@@ -0,0 +1,84 @@
+package netbox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceNetboxBranch() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNetboxBranchRead,
+		Description: `:meta:subcategory:Branching:Requires the [Netbox Branching plugin](https://github.com/netboxlabs/netbox-branching).`,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "schema_id"},
+			},
+			"schema_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "schema_id"},
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_sync": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceNetboxBranchRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	filter := "name"
+	if _, ok := d.GetOk("schema_id"); ok {
+		filter = "schema_id"
+	}
+	value := d.Get(filter).(string)
+
+	var list branchList
+	err := api.rawAPIRequest(ctx, http.MethodGet, branchingAPIPath+"branches/", url.Values{filter: {value}, "limit": {"0"}}, nil, &list)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var result *branch
+	for _, b := range list.Results {
+		if (filter == "name" && b.Name == value) || (filter == "schema_id" && b.SchemaID == value) {
+			result = b
+			break
+		}
+	}
+	if result == nil {
+		return diag.FromErr(fmt.Errorf("no branch found matching %s %s", filter, value))
+	}
+
+	d.SetId(strconv.FormatInt(result.ID, 10))
+	d.Set("name", result.Name)
+	d.Set("schema_id", result.SchemaID)
+	d.Set("description", result.Description)
+	d.Set("status", result.status())
+	if result.LastSync != nil {
+		d.Set("last_sync", *result.LastSync)
+	} else {
+		d.Set("last_sync", nil)
+	}
+	return nil
+}
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/extras"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -140,7 +139,7 @@ func dataSourceNetboxConfigContext() *schema.Resource {
 }
 
 func dataSourceNetboxConfigContextRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 	params := extras.NewExtrasConfigContextsListParams()
@@ -2,38 +2,33 @@ package netbox
 
 import (
 	"errors"
-	"fmt"
 	"regexp"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/go-openapi/runtime"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+var deviceInterfacesFilters = &dataSourceFilters{
+	params: func() runtime.ClientRequestWriter { return dcim.NewDcimInterfacesListParams() },
+}
+
 func dataSourceNetboxDeviceInterfaces() *schema.Resource {
 	return &schema.Resource{
 		Read:        dataSourceNetboxDeviceInterfaceRead,
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):`,
 		Schema: map[string]*schema.Schema{
-			"filter": {
-				Type:     schema.TypeSet,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"value": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-					},
-				},
+			"filter": deviceInterfacesFilters.schema(),
+			"limit": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "The maximum number of objects to return, after `name_regex` is applied. If unset or `0`, all matching objects are returned.",
 			},
+			"page_size": pageSizeSchema,
 			"name_regex": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -136,52 +131,40 @@ func dataSourceNetboxDeviceInterfaces() *schema.Resource {
 }
 
 func dataSourceNetboxDeviceInterfaceRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	params := dcim.NewDcimInterfacesListParams()
+	query := deviceInterfacesFilters.query(d)
 
-	if filter, ok := d.GetOk("filter"); ok {
-		var filterParams = filter.(*schema.Set)
-		for _, f := range filterParams.List() {
-			k := f.(map[string]interface{})["name"]
-			v := f.(map[string]interface{})["value"]
-			vString := v.(string)
-			switch k {
-			case "mac_address":
-				params.MacAddress = &vString
-			case "name":
-				params.Name = &vString
-			case "tag":
-				params.Tag = []string{vString} //TODO: switch schema to list?
-			case "device_id":
-				params.DeviceID = &vString
-			default:
-				return fmt.Errorf("'%s' is not a supported filter parameter", k)
-			}
+	var keep func(*models.Interface) bool
+	if nameRegex, ok := d.GetOk("name_regex"); ok {
+		r := regexp.MustCompile(nameRegex.(string))
+		keep = func(dcimInterface *models.Interface) bool {
+			return r.MatchString(*dcimInterface.Name)
 		}
 	}
 
-	res, err := api.Dcim.DcimInterfacesList(params, nil)
+	// count is the number of objects before name_regex is applied
+	var count int64
+	pageSize, limit := getPagination(d)
+	filteredInterfaces, err := listAll(pageSize, limit, keep, func(offset, limit int64) ([]*models.Interface, int64, error) {
+		params.Offset = &offset
+		params.Limit = &limit
+		res, err := api.Dcim.DcimInterfacesList(params, nil, withQueryParams(query))
+		if err != nil {
+			return nil, 0, err
+		}
+		count = *res.GetPayload().Count
+		return res.GetPayload().Results, count, nil
+	})
 	if err != nil {
 		return err
 	}
 
-	if *res.GetPayload().Count == int64(0) {
+	if count == 0 {
 		return errors.New("no result")
 	}
 
-	var filteredInterfaces []*models.Interface
-	if nameRegex, ok := d.GetOk("name_regex"); ok {
-		r := regexp.MustCompile(nameRegex.(string))
-		for _, dcimInterface := range res.GetPayload().Results {
-			if r.MatchString(*dcimInterface.Name) {
-				filteredInterfaces = append(filteredInterfaces, dcimInterface)
-			}
-		}
-	} else {
-		filteredInterfaces = res.GetPayload().Results
-	}
-
 	var s []map[string]interface{}
 	for _, v := range filteredInterfaces {
 		var mapping = make(map[string]interface{})
@@ -13,6 +13,7 @@ func TestAccNetboxDeviceInterfacesDataSource_basic(t *testing.T) {
 	dependencies := testAccNetboxDeviceInterfacesDataSourceDependencies(testName)
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: dependencies,
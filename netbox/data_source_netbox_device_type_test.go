@@ -13,6 +13,7 @@ func TestAccNetboxDeviceTypeDataSource_basic(t *testing.T) {
 	setUp := testAccNetboxDeviceTypeSetUp(testName)
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: setUp,
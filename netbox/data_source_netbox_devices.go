@@ -5,49 +5,43 @@ package netbox
 
 import (
 	"encoding/json"
-	"fmt"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/go-openapi/runtime"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"net"
 	"regexp"
-	"strings"
 )
 
+var devicesFilters = &dataSourceFilters{
+	params: func() runtime.ClientRequestWriter { return dcim.NewDcimDevicesListParams() },
+	// Filters supported by former versions of the provider
+	aliases: map[string]filterAlias{
+		"tags": {filter: "tag", separator: ","},
+	},
+}
+
 func dataSourceNetboxDevices() *schema.Resource {
 	return &schema.Resource{
 		Read:        dataSourceNetboxDevicesRead,
 		Description: ":meta:subcategory:Data Center Inventory Management (DCIM):",
 		Schema: map[string]*schema.Schema{
-			"filter": {
-				Type:     schema.TypeSet,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"value": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-					},
-				},
-			},
+			"filter": devicesFilters.schema(),
 			"name_regex": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ValidateFunc: validation.StringIsValidRegExp,
 			},
 			"limit": {
-				Type:     schema.TypeInt,
-				Optional: true,
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "The maximum number of objects to return, after `name_regex` is applied. If unset or `0`, all matching objects are returned.",
 			},
+			"page_size": pageSizeSchema,
 			"devices": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -158,80 +152,33 @@ func dataSourceNetboxDevices() *schema.Resource {
 }
 
 func dataSourceNetboxDevicesRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	params := dcim.NewDcimDevicesListParams()
+	query := devicesFilters.query(d)
 
-	if filter, ok := d.GetOk("filter"); ok {
-		var filterParams = filter.(*schema.Set)
-		for _, f := range filterParams.List() {
-			k := f.(map[string]interface{})["name"]
-			v := f.(map[string]interface{})["value"]
-			switch k {
-			case "asset_tag":
-				var assetTagString = v.(string)
-				params.AssetTag = &assetTagString
-			case "cluster_id":
-				var clusterString = v.(string)
-				params.ClusterID = &clusterString
-			case "device_type_id":
-				var deviceTypeIDString = v.(string)
-				params.DeviceTypeID = &deviceTypeIDString
-			case "name":
-				var nameString = v.(string)
-				params.Name = &nameString
-			case "region":
-				var regionString = v.(string)
-				params.Region = &regionString
-			case "role_id":
-				var roleIDString = v.(string)
-				params.RoleID = &roleIDString
-			case "site_id":
-				var siteIDString = v.(string)
-				params.SiteID = &siteIDString
-			case "location_id":
-				var locationIDString = v.(string)
-				params.LocationID = &locationIDString
-			case "rack_id":
-				var rackIDString = v.(string)
-				params.RackID = &rackIDString
-			case "tenant_id":
-				var tenantIDString = v.(string)
-				params.TenantID = &tenantIDString
-			case "tags":
-				var tagsString = v.(string)
-				params.Tag = strings.Split(tagsString, ",")
-			case "status":
-				var statusString = v.(string)
-				params.Status = &statusString
-			default:
-				return fmt.Errorf("'%s' is not a supported filter parameter", k)
-			}
+	var keep func(*models.DeviceWithConfigContext) bool
+	if nameRegex, ok := d.GetOk("name_regex"); ok {
+		r := regexp.MustCompile(nameRegex.(string))
+		keep = func(device *models.DeviceWithConfigContext) bool {
+			return r.MatchString(*device.Name)
 		}
 	}
 
-	if limit, ok := d.GetOk("limit"); ok {
-		limitInt := int64(limit.(int))
-		params.Limit = &limitInt
-	}
-
-	res, err := api.Dcim.DcimDevicesList(params, nil)
+	pageSize, limit := getPagination(d)
+	filteredDevices, err := listAll(pageSize, limit, keep, func(offset, limit int64) ([]*models.DeviceWithConfigContext, int64, error) {
+		params.Offset = &offset
+		params.Limit = &limit
+		res, err := api.Dcim.DcimDevicesList(params, nil, withQueryParams(query))
+		if err != nil {
+			return nil, 0, err
+		}
+		return res.GetPayload().Results, *res.GetPayload().Count, nil
+	})
 	if err != nil {
 		return err
 	}
 
-	var filteredDevices []*models.DeviceWithConfigContext
-	if nameRegex, ok := d.GetOk("name_regex"); ok {
-		r := regexp.MustCompile(nameRegex.(string))
-		for _, device := range res.GetPayload().Results {
-			if r.MatchString(*device.Name) {
-				filteredDevices = append(filteredDevices, device)
-			}
-		}
-	} else {
-		filteredDevices = res.GetPayload().Results
-	}
-
 	var s []map[string]interface{}
 	for _, device := range filteredDevices {
 		var mapping = make(map[string]interface{})
@@ -19,6 +19,7 @@ func TestAccNetboxDevicesDataSource_basic(t *testing.T) {
 	dependencies := testAccNetboxDeviceDataSourceDependencies(testName)
 	resource.Test(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: dependencies,
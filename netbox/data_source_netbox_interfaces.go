@@ -2,45 +2,37 @@ package netbox
 
 import (
 	"errors"
-	"fmt"
 	"regexp"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/virtualization"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/go-openapi/runtime"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+var interfacesFilters = &dataSourceFilters{
+	params: func() runtime.ClientRequestWriter { return virtualization.NewVirtualizationInterfacesListParams() },
+	// Filters supported by former versions of the provider
+	aliases: map[string]filterAlias{
+		"vm_id": {filter: "virtual_machine_id"},
+	},
+}
+
 func dataSourceNetboxInterfaces() *schema.Resource {
 	return &schema.Resource{
 		Read:        dataSourceNetboxInterfaceRead,
 		Description: `:meta:subcategory:Virtualization:`,
 		Schema: map[string]*schema.Schema{
-			"filter": {
-				Type:     schema.TypeSet,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"value": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-					},
-				},
-			},
+			"filter": interfacesFilters.schema(),
 			"limit": {
 				Type:             schema.TypeInt,
 				Optional:         true,
-				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
-				Default:          0,
-				Description:      "The limit of objects to return from the API lookup.",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "The maximum number of objects to return, after `name_regex` is applied. If unset or `0`, all matching objects are returned.",
 			},
+			"page_size": pageSizeSchema,
 			"name_regex": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -143,56 +135,40 @@ func dataSourceNetboxInterfaces() *schema.Resource {
 }
 
 func dataSourceNetboxInterfaceRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	params := virtualization.NewVirtualizationInterfacesListParams()
+	query := interfacesFilters.query(d)
 
-	params.Limit = getOptionalInt(d, "limit")
-
-	if filter, ok := d.GetOk("filter"); ok {
-		var filterParams = filter.(*schema.Set)
-		for _, f := range filterParams.List() {
-			k := f.(map[string]interface{})["name"]
-			v := f.(map[string]interface{})["value"]
-			vString := v.(string)
-			switch k {
-			case "cluster_id":
-				params.ClusterID = &vString
-			case "mac_address":
-				params.MacAddress = &vString
-			case "name":
-				params.Name = &vString
-			case "tag":
-				params.Tag = []string{vString} //TODO: switch schema to list?
-			case "vm_id":
-				params.VirtualMachineID = &vString
-			default:
-				return fmt.Errorf("'%s' is not a supported filter parameter", k)
-			}
+	var keep func(*models.VMInterface) bool
+	if nameRegex, ok := d.GetOk("name_regex"); ok {
+		r := regexp.MustCompile(nameRegex.(string))
+		keep = func(vmInterface *models.VMInterface) bool {
+			return r.MatchString(*vmInterface.Name)
 		}
 	}
 
-	res, err := api.Virtualization.VirtualizationInterfacesList(params, nil)
+	// count is the number of objects before name_regex is applied
+	var count int64
+	pageSize, limit := getPagination(d)
+	filteredInterfaces, err := listAll(pageSize, limit, keep, func(offset, limit int64) ([]*models.VMInterface, int64, error) {
+		params.Offset = &offset
+		params.Limit = &limit
+		res, err := api.Virtualization.VirtualizationInterfacesList(params, nil, withQueryParams(query))
+		if err != nil {
+			return nil, 0, err
+		}
+		count = *res.GetPayload().Count
+		return res.GetPayload().Results, count, nil
+	})
 	if err != nil {
 		return err
 	}
 
-	if *res.GetPayload().Count == int64(0) {
+	if count == 0 {
 		return errors.New("no result")
 	}
 
-	var filteredInterfaces []*models.VMInterface
-	if nameRegex, ok := d.GetOk("name_regex"); ok {
-		r := regexp.MustCompile(nameRegex.(string))
-		for _, vmInterface := range res.GetPayload().Results {
-			if r.MatchString(*vmInterface.Name) {
-				filteredInterfaces = append(filteredInterfaces, vmInterface)
-			}
-		}
-	} else {
-		filteredInterfaces = res.GetPayload().Results
-	}
-
 	var s []map[string]interface{}
 	for _, v := range filteredInterfaces {
 		var mapping = make(map[string]interface{})
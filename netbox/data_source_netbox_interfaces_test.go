@@ -14,6 +14,7 @@ func TestAccNetboxInterfacesDataSource_basic(t *testing.T) {
 	dependencies := testAccNetboxInterfacesDataSourceDependencies(testName)
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: dependencies,
@@ -2,43 +2,38 @@ package netbox
 
 import (
 	"errors"
-	"fmt"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/go-openapi/runtime"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+var ipAddressesFilters = &dataSourceFilters{
+	params: func() runtime.ClientRequestWriter { return ipam.NewIpamIPAddressesListParams() },
+	// Filters supported by former versions of the provider
+	aliases: map[string]filterAlias{
+		"ip_address":      {filter: "address"},
+		"parent_prefix":   {filter: "parent"},
+		"vm_interface_id": {filter: "vminterface_id"},
+	},
+}
+
 func dataSourceNetboxIPAddresses() *schema.Resource {
 	return &schema.Resource{
 		Read:        dataSourceNetboxIPAddressesRead,
 		Description: `:meta:subcategory:IP Address Management (IPAM):`,
 		Schema: map[string]*schema.Schema{
-			"filter": {
-				Type:     schema.TypeSet,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"value": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-					},
-				},
-			},
+			"filter": ipAddressesFilters.schema(),
 			"limit": {
 				Type:             schema.TypeInt,
 				Optional:         true,
-				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
-				Default:          1000,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "The maximum number of objects to return. If unset or `0`, all matching objects are returned.",
 			},
+			"page_size": pageSizeSchema,
 			"ip_addresses": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -137,62 +132,29 @@ func dataSourceNetboxIPAddresses() *schema.Resource {
 }
 
 func dataSourceNetboxIPAddressesRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	params := ipam.NewIpamIPAddressesListParams()
-
-	if limitValue, ok := d.GetOk("limit"); ok {
-		params.Limit = int64ToPtr(int64(limitValue.(int)))
-	}
-
-	if filter, ok := d.GetOk("filter"); ok {
-		var filterParams = filter.(*schema.Set)
-		var tags []string
-		for _, f := range filterParams.List() {
-			k := f.(map[string]interface{})["name"]
-			v := f.(map[string]interface{})["value"]
-			vString := v.(string)
-			switch k {
-			case "dns_name":
-				params.DNSName = &vString
-			case "interface_id":
-				params.InterfaceID = &vString
-			case "device_id":
-				params.DeviceID = &vString
-			case "ip_address":
-				params.Address = &vString
-			case "vm_interface_id":
-				params.VminterfaceID = &vString
-			case "role":
-				params.Role = &vString
-			case "status":
-				params.Status = &vString
-			case "vrf":
-				params.Vrf = &vString
-			case "tenant":
-				params.Tenant = &vString
-			case "parent_prefix":
-				params.Parent = &vString
-			case "tag":
-				tags = append(tags, vString)
-				params.Tag = tags
-			default:
-				return fmt.Errorf("'%s' is not a supported filter parameter", k)
-			}
+	query := ipAddressesFilters.query(d)
+
+	pageSize, limit := getPagination(d)
+	filteredIPAddresses, err := listAll(pageSize, limit, nil, func(offset, limit int64) ([]*models.IPAddress, int64, error) {
+		params.Offset = &offset
+		params.Limit = &limit
+		res, err := api.Ipam.IpamIPAddressesList(params, nil, withQueryParams(query))
+		if err != nil {
+			return nil, 0, err
 		}
-	}
-
-	res, err := api.Ipam.IpamIPAddressesList(params, nil)
+		return res.GetPayload().Results, *res.GetPayload().Count, nil
+	})
 	if err != nil {
 		return err
 	}
 
-	if *res.GetPayload().Count == int64(0) {
+	if len(filteredIPAddresses) == 0 {
 		return errors.New("no result")
 	}
 
-	filteredIPAddresses := res.GetPayload().Results
-
 	var s []map[string]interface{}
 	for _, v := range filteredIPAddresses {
 		var mapping = make(map[string]interface{})
@@ -13,6 +13,7 @@ func TestAccNetboxIpAddressesDataSource_basic(t *testing.T) {
 	testIP := "203.0.113.1/24"
 	resource.Test(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNetboxIPAddressFullDependencies(testName) + fmt.Sprintf(`
@@ -46,6 +47,7 @@ func TestAccNetboxIpAddressesDataSource_filter(t *testing.T) {
 	testIP1 := "203.0.113.2/24"
 	resource.Test(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNetboxIPAddressFullDependencies(testName) + fmt.Sprintf(`
@@ -85,6 +87,7 @@ func TestAccNetboxIpAddressesDataSource_filter2(t *testing.T) {
 	testIP1 := "203.0.113.2/24"
 	resource.Test(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNetboxIPAddressFullDependencies(testName) + fmt.Sprintf(`
@@ -127,6 +130,7 @@ func TestAccNetboxIpAddressesDataSource_filter_parent_prefix(t *testing.T) {
 	testIP1 := "203.0.200.1/24"
 	resource.Test(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNetboxIPAddressFullDependencies(testName) + fmt.Sprintf(`
@@ -170,6 +174,7 @@ func TestAccNetboxIpAddressesDataSource_multiple(t *testing.T) {
 	testName := testAccGetTestName(testSlug)
 	resource.Test(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNetboxIPAddressFullDependencies(testName) + fmt.Sprintf(`
@@ -211,6 +216,7 @@ func TestAccNetboxIpAddressesDataSource_flattenTenant(t *testing.T) {
 	testName := testAccGetTestName(testSlug)
 	resource.Test(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNetboxIPAddressFullDependencies(testName) + fmt.Sprintf(`
@@ -276,6 +282,7 @@ func TestAccNetboxIpAddressessDataSource_many(t *testing.T) {
 	testName := testAccGetTestName(testSlug)
 	resource.Test(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNetboxIPAddressesDataSourceDependenciesMany(testName) + fmt.Sprintf(`
@@ -313,6 +320,7 @@ func TestAccNetboxIpAddressesDataSource_filter_tags(t *testing.T) {
 	testIP2 := "203.0.113.3/24"
 	resource.Test(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNetboxIPAddressFullDependencies(testName) + fmt.Sprintf(`
@@ -12,6 +12,7 @@ func TestAccNetboxIpRangeDataSource_basic(t *testing.T) {
 	testEndIP := "10.0.0.150/24"
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
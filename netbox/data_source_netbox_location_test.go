@@ -13,6 +13,7 @@ func TestAccNetboxLocationDataSource_basic(t *testing.T) {
 	testNameSub := testAccGetTestName(testSlug)
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
@@ -1,40 +1,26 @@
 package netbox
 
 import (
-	"fmt"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
+	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/go-openapi/runtime"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+var locationsFilters = &dataSourceFilters{
+	params: func() runtime.ClientRequestWriter { return dcim.NewDcimLocationsListParams() },
+}
+
 func dataSourceNetboxLocations() *schema.Resource {
 	return &schema.Resource{
 		Read:        dataSourceNetboxLocationsRead,
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):`,
 		Schema: map[string]*schema.Schema{
-			"filter": {
-				Type:        schema.TypeSet,
-				Optional:    true,
-				Description: "A list of filter to apply to the API query when requesting locations.",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "The name of the field to filter on. Supported fields are: .",
-						},
-						"value": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "The value to pass to the specified filter.",
-						},
-					},
-				},
-			},
+			"filter": locationsFilters.schema(),
 			"tags": {
 				Type: schema.TypeSet,
 				Elem: &schema.Schema{
@@ -46,10 +32,10 @@ func dataSourceNetboxLocations() *schema.Resource {
 			"limit": {
 				Type:             schema.TypeInt,
 				Optional:         true,
-				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
-				Default:          0,
-				Description:      "The limit of objects to return from the API lookup.",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "The maximum number of objects to return. If unset or `0`, all matching objects are returned.",
 			},
+			"page_size": pageSizeSchema,
 			"locations": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -95,41 +81,9 @@ func dataSourceNetboxLocations() *schema.Resource {
 }
 
 func dataSourceNetboxLocationsRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 	params := dcim.NewDcimLocationsListParams()
-
-	if limitValue, ok := d.GetOk("limit"); ok {
-		params.Limit = int64ToPtr(int64(limitValue.(int)))
-	}
-
-	if filter, ok := d.GetOk("filter"); ok {
-		var filterParams = filter.(*schema.Set)
-		for _, f := range filterParams.List() {
-			k := f.(map[string]interface{})["name"]
-			v := f.(map[string]interface{})["value"]
-			vString := v.(string)
-			switch k {
-			case "name":
-				params.Name = &vString
-			case "slug":
-				params.Slug = &vString
-			case "site":
-				params.Site = &vString
-			case "site_id":
-				params.SiteID = &vString
-			case "parent_id":
-				params.ParentID = &vString
-			case "tenant":
-				params.Tenant = &vString
-			case "tenant_id":
-				params.TenantID = &vString
-			case "status":
-				params.Status = &vString
-			default:
-				return fmt.Errorf("'%s' is not a supported filter parameter", k)
-			}
-		}
-	}
+	query := locationsFilters.query(d)
 	if tags, ok := d.GetOk("tags"); ok {
 		tagSet := tags.(*schema.Set)
 		for _, tag := range tagSet.List() {
@@ -137,14 +91,20 @@ func dataSourceNetboxLocationsRead(d *schema.ResourceData, m interface{}) error
 			params.Tag = append(params.Tag, tagV)
 		}
 	}
-	res, err := api.Dcim.DcimLocationsList(params, nil)
-
+	pageSize, limit := getPagination(d)
+	filteredLocations, err := listAll(pageSize, limit, nil, func(offset, limit int64) ([]*models.Location, int64, error) {
+		params.Offset = &offset
+		params.Limit = &limit
+		res, err := api.Dcim.DcimLocationsList(params, nil, withQueryParams(query))
+		if err != nil {
+			return nil, 0, err
+		}
+		return res.GetPayload().Results, *res.GetPayload().Count, nil
+	})
 	if err != nil {
 		return err
 	}
 
-	filteredLocations := res.GetPayload().Results
-
 	var s []map[string]any
 	for _, v := range filteredLocations {
 		var mapping = make(map[string]any)
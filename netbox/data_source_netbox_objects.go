@@ -0,0 +1,106 @@
+package netbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// objectsFilters accepts every filter, as the endpoint is only known at
+// runtime.
+var objectsFilters = &dataSourceFilters{}
+
+func dataSourceNetboxObjects() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNetboxObjectsRead,
+		Description: `:meta:subcategory:Extras:Lists the objects of any endpoint of the Netbox REST API, e.g. of models not covered by other data sources or of plugins.
+
+Filters are passed to the endpoint as query parameters, so all filters of the endpoint are supported, including lookup expressions like ` + "`name__ic`" + ` or ` + "`tag__n`" + ` and custom field filters like ` + "`cf_owner`" + `. Filters with the same name match objects matching any of the values.`,
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringMatch(objectPathRegexp, "must be an API path like `plugins/bgp/session`"),
+				Description:  "The path of the list endpoint, relative to the API root, e.g. `plugins/bgp/session` or `dcim/devices`.",
+			},
+			"filter": objectsFilters.schema(),
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum number of objects to return. If unset or `0`, all matching objects are returned.",
+			},
+			"page_size": pageSizeSchema,
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"objects": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The objects as returned by Netbox, each as a JSON object. Use `jsondecode` to access their fields.",
+			},
+		},
+	}
+}
+
+func dataSourceNetboxObjectsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	query := objectsFilters.query(d)
+
+	pageSize, limit := getPagination(d)
+	objects, err := listAll(pageSize, limit, nil, func(offset, limit int64) ([]map[string]interface{}, int64, error) {
+		query.Set("offset", strconv.FormatInt(offset, 10))
+		query.Set("limit", strconv.FormatInt(limit, 10))
+		var page struct {
+			Count   int64             `json:"count"`
+			Results []json.RawMessage `json:"results"`
+		}
+		err := api.rawAPIRequest(ctx, http.MethodGet, objectEndpoint(d.Get("path").(string)), query, nil, &page)
+		if err != nil {
+			return nil, 0, err
+		}
+		objects := make([]map[string]interface{}, 0, len(page.Results))
+		for _, result := range page.Results {
+			object, err := decodeObjectJSON(string(result))
+			if err != nil {
+				return nil, 0, err
+			}
+			objects = append(objects, object)
+		}
+		return objects, page.Count, nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := make([]int64, 0, len(objects))
+	objectsJSON := make([]string, 0, len(objects))
+	for _, object := range objects {
+		objectID, err := strconv.ParseInt(fmt.Sprint(object["id"]), 10, 64)
+		if err != nil {
+			return diag.Errorf("object without numeric `id` returned by Netbox: %v", object)
+		}
+		ids = append(ids, objectID)
+		objectJSON, err := json.Marshal(object)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		objectsJSON = append(objectsJSON, string(objectJSON))
+	}
+
+	d.SetId(id.UniqueId())
+	d.Set("ids", ids)
+	d.Set("objects", objectsJSON)
+	return nil
+}
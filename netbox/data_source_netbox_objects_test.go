@@ -0,0 +1,122 @@
+package netbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccNetboxObjectsDataSource_basic(t *testing.T) {
+	testSlug := "objects_ds_basic"
+	testName := testAccGetTestName(testSlug)
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "netbox_tenant" "test_1" {
+  name = "%[1]s_1"
+}
+
+resource "netbox_tenant" "test_2" {
+  name = "%[1]s_2"
+}
+
+data "netbox_objects" "test" {
+  depends_on = [netbox_tenant.test_1, netbox_tenant.test_2]
+
+  path = "tenancy/tenants"
+  filter {
+    name  = "name__ic"
+    value = "%[1]s"
+  }
+  filter {
+    name  = "name__n"
+    value = "%[1]s_2"
+  }
+}`, testName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.netbox_objects.test", "ids.#", "1"),
+					resource.TestCheckResourceAttrPair("data.netbox_objects.test", "ids.0", "netbox_tenant.test_1", "id"),
+					resource.TestCheckResourceAttr("data.netbox_objects.test", "objects.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceNetboxObjectsRead(t *testing.T) {
+	var queries []url.Values
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		queries = append(queries, r.URL.Query())
+		// 5 objects, at most 2 per page
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		if limit > 2 {
+			limit = 2
+		}
+		results := []map[string]interface{}{}
+		for i := offset; i < offset+limit && i < 5; i++ {
+			results = append(results, map[string]interface{}{"id": i + 1, "name": fmt.Sprintf("session%d", i+1)})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"count": 5, "results": results})
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL,
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+	state := &providerState{NetBoxAPI: client}
+
+	r := dataSourceNetboxObjects()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"path": "plugins/bgp/session",
+		"filter": []interface{}{
+			map[string]interface{}{"name": "name__ic", "value": "session"},
+			map[string]interface{}{"name": "cf_owner", "value": "network"},
+		},
+	})
+	diags := r.ReadContext(context.Background(), d, state)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, []interface{}{1, 2, 3, 4, 5}, d.Get("ids"))
+	assert.Equal(t, `{"id":3,"name":"session3"}`, d.Get("objects.2"))
+	if assert.Len(t, queries, 3) {
+		assert.Equal(t, "session", queries[0].Get("name__ic"))
+		assert.Equal(t, "network", queries[0].Get("cf_owner"))
+		assert.Equal(t, "4", queries[2].Get("offset"))
+	}
+
+	queries = nil
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"path":  "plugins/bgp/session",
+		"limit": 3,
+	})
+	diags = r.ReadContext(context.Background(), d, state)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, []interface{}{1, 2, 3}, d.Get("ids"))
+	if assert.Len(t, queries, 2) {
+		assert.Equal(t, "1", queries[1].Get("limit"))
+	}
+
+	// Pagination parameters are rejected at plan time
+	diags = r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"path":   "plugins/bgp/session",
+		"filter": []interface{}{map[string]interface{}{"name": "offset", "value": "2"}},
+	}))
+	assert.True(t, diags.HasError())
+}
@@ -1,47 +1,31 @@
 package netbox
 
 import (
-	"fmt"
-	"strconv"
-
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
+	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/go-openapi/runtime"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+var prefixesFilters = &dataSourceFilters{
+	params: func() runtime.ClientRequestWriter { return ipam.NewIpamPrefixesListParams() },
+}
+
 func dataSourceNetboxPrefixes() *schema.Resource {
 	return &schema.Resource{
 		Read:        dataSourceNetboxPrefixesRead,
 		Description: `:meta:subcategory:IP Address Management (IPAM):`,
 		Schema: map[string]*schema.Schema{
-			"filter": {
-				Type:        schema.TypeSet,
-				Optional:    true,
-				Description: "A list of filters to apply to the API query when requesting prefixes.",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "The name of the field to filter on. Supported fields are: `prefix`, `contains`, `vlan_vid`, `vrf_id`, `vlan_id`, `status`, `site_id`, & `tag`.",
-						},
-						"value": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "The value to pass to the specified filter.",
-						},
-					},
-				},
-			},
+			"filter": prefixesFilters.schema(),
 			"limit": {
 				Type:             schema.TypeInt,
 				Optional:         true,
-				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
-				Default:          0,
-				Description:      "The limit of objects to return from the API lookup.",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "The maximum number of objects to return. If unset or `0`, all matching objects are returned.",
 			},
+			"page_size": pageSizeSchema,
 			"prefixes": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -88,54 +72,25 @@ func dataSourceNetboxPrefixes() *schema.Resource {
 }
 
 func dataSourceNetboxPrefixesRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	params := ipam.NewIpamPrefixesListParams()
+	query := prefixesFilters.query(d)
 
-	if limitValue, ok := d.GetOk("limit"); ok {
-		params.Limit = int64ToPtr(int64(limitValue.(int)))
-	}
-
-	if filter, ok := d.GetOk("filter"); ok {
-		var filterParams = filter.(*schema.Set)
-		for _, f := range filterParams.List() {
-			k := f.(map[string]interface{})["name"]
-			v := f.(map[string]interface{})["value"]
-			vString := v.(string)
-			switch k {
-			case "prefix":
-				params.Prefix = &vString
-			case "vlan_vid":
-				float, err := strconv.ParseFloat(vString, 64)
-				if err != nil {
-					return err
-				}
-				params.VlanVid = &float
-			case "contains":
-				params.Contains = &vString
-			case "vrf_id":
-				params.VrfID = &vString
-			case "vlan_id":
-				params.VlanID = &vString
-			case "status":
-				params.Status = &vString
-			case "site_id":
-				params.SiteID = &vString
-			case "tag":
-				params.Tag = []string{vString}
-			default:
-				return fmt.Errorf("'%s' is not a supported filter parameter", k)
-			}
+	pageSize, limit := getPagination(d)
+	filteredPrefixes, err := listAll(pageSize, limit, nil, func(offset, limit int64) ([]*models.Prefix, int64, error) {
+		params.Offset = &offset
+		params.Limit = &limit
+		res, err := api.Ipam.IpamPrefixesList(params, nil, withQueryParams(query))
+		if err != nil {
+			return nil, 0, err
 		}
-	}
-
-	res, err := api.Ipam.IpamPrefixesList(params, nil)
+		return res.GetPayload().Results, *res.GetPayload().Count, nil
+	})
 	if err != nil {
 		return err
 	}
 
-	filteredPrefixes := res.GetPayload().Results
-
 	var s []map[string]interface{}
 	for _, v := range filteredPrefixes {
 		var mapping = make(map[string]interface{})
@@ -2,42 +2,32 @@ package netbox
 
 import (
 	"errors"
-	"fmt"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
+	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/go-openapi/runtime"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+var racksFilters = &dataSourceFilters{
+	params: func() runtime.ClientRequestWriter { return dcim.NewDcimRacksListParams() },
+}
+
 func dataSourceNetboxRacks() *schema.Resource {
 	return &schema.Resource{
 		Read:        dataSourceNetboxRacksRead,
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):`,
 		Schema: map[string]*schema.Schema{
-			"filter": {
-				Type:     schema.TypeSet,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"value": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-					},
-				},
-			},
+			"filter": racksFilters.schema(),
 			"limit": {
 				Type:             schema.TypeInt,
 				Optional:         true,
-				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
-				Default:          0,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "The maximum number of objects to return. If unset or `0`, all matching objects are returned.",
 			},
+			"page_size": pageSizeSchema,
 			"racks": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -148,88 +138,29 @@ func dataSourceNetboxRacks() *schema.Resource {
 }
 
 func dataSourceNetboxRacksRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	params := dcim.NewDcimRacksListParams()
+	query := racksFilters.query(d)
 
-	if limitValue, ok := d.GetOk("limit"); ok {
-		params.Limit = int64ToPtr(int64(limitValue.(int)))
-	}
-
-	if filter, ok := d.GetOk("filter"); ok {
-		var filterParams = filter.(*schema.Set)
-		for _, f := range filterParams.List() {
-			k := f.(map[string]interface{})["name"]
-			v := f.(map[string]interface{})["value"]
-			vString := v.(string)
-			switch k {
-			case "asset_tag":
-				params.AssetTag = &vString
-			case "contact":
-				params.Contact = &vString
-			case "contact_group":
-				params.ContactGroup = &vString
-			case "contact_role":
-				params.ContactRole = &vString
-			case "desc_units":
-				params.DescUnits = &vString
-			case "facility_id":
-				params.FacilityID = &vString
-			case "id":
-				params.ID = &vString
-			case "location_id":
-				params.LocationID = &vString
-			case "max_weight":
-				params.MaxWeight = &vString
-			case "mounting_depth":
-				params.MountingDepth = &vString
-			case "name":
-				params.Name = &vString
-			case "outer_depth":
-				params.OuterDepth = &vString
-			case "outer_unit":
-				params.OuterUnit = &vString
-			case "outer_width":
-				params.OuterWidth = &vString
-			case "region_id":
-				params.RegionID = &vString
-			case "role_id":
-				params.RoleID = &vString
-			case "serial":
-				params.Serial = &vString
-			case "site_id":
-				params.SiteID = &vString
-			case "status":
-				params.Status = &vString
-			case "tenant_id":
-				params.TenantID = &vString
-			case "type":
-				params.Type = &vString
-			case "u_height":
-				params.UHeight = &vString
-			case "weight":
-				params.Weight = &vString
-			case "weight_unit":
-				params.WeightUnit = &vString
-			case "width":
-				params.Width = &vString
-			default:
-				return fmt.Errorf("'%s' is not a supported filter parameter", k)
-			}
+	pageSize, limit := getPagination(d)
+	filteredRacks, err := listAll(pageSize, limit, nil, func(offset, limit int64) ([]*models.Rack, int64, error) {
+		params.Offset = &offset
+		params.Limit = &limit
+		res, err := api.Dcim.DcimRacksList(params, nil, withQueryParams(query))
+		if err != nil {
+			return nil, 0, err
 		}
-	}
-
-	res, err := api.Dcim.DcimRacksList(params, nil)
+		return res.GetPayload().Results, *res.GetPayload().Count, nil
+	})
 	if err != nil {
 		return err
 	}
 
-	if *res.GetPayload().Count == int64(0) {
+	if len(filteredRacks) == 0 {
 		return errors.New("no result")
 	}
 
-	filteredRacks := res.GetPayload().Results
-
 	var s []map[string]interface{}
 	for _, v := range filteredRacks {
 		var mapping = make(map[string]interface{})
@@ -29,6 +29,7 @@ func TestAccNetboxRouteTarget_basic(t *testing.T) {
 	testName := testAccGetTestName(testSlug)
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: getNetboxDataSourceRouteTargetConfig(testName),
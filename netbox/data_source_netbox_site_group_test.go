@@ -11,6 +11,7 @@ func TestAccNetboxSiteGroupDataSource_basic(t *testing.T) {
 	testName := testAccGetTestName("sitegrp_ds_basic")
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
@@ -76,6 +76,7 @@ func TestAccNetboxSiteDataSource_basic(t *testing.T) {
 	setUp := testAccNetboxSiteSetUp(testName)
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: setUp,
@@ -2,42 +2,32 @@ package netbox
 
 import (
 	"errors"
-	"fmt"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/extras"
+	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/go-openapi/runtime"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+var tagsFilters = &dataSourceFilters{
+	params: func() runtime.ClientRequestWriter { return extras.NewExtrasTagsListParams() },
+}
+
 func dataSourceNetboxTags() *schema.Resource {
 	return &schema.Resource{
 		Read:        dataSourceNetboxTagsRead,
 		Description: `:meta:subcategory:Extras:`,
 		Schema: map[string]*schema.Schema{
-			"filter": {
-				Type:     schema.TypeSet,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"value": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-					},
-				},
-			},
+			"filter": tagsFilters.schema(),
 			"limit": {
 				Type:             schema.TypeInt,
 				Optional:         true,
-				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
-				Default:          0,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "The maximum number of objects to return. If unset or `0`, all matching objects are returned.",
 			},
+			"page_size": pageSizeSchema,
 			"tags": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -71,72 +61,30 @@ func dataSourceNetboxTags() *schema.Resource {
 }
 
 func dataSourceNetboxTagsRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	params := extras.NewExtrasTagsListParams()
+	query := tagsFilters.query(d)
 
-	if limitValue, ok := d.GetOk("limit"); ok {
-		params.Limit = int64ToPtr(int64(limitValue.(int)))
-	}
-
-	if filter, ok := d.GetOk("filter"); ok {
-		filterParams := filter.(*schema.Set)
-		for _, f := range filterParams.List() {
-			k := f.(map[string]interface{})["name"]
-			v := f.(map[string]interface{})["value"]
-			vString := v.(string)
-			switch k {
-			case "id":
-				params.ID = &vString
-			case "id__gt":
-				params.IDGt = &vString
-			case "id__gte":
-				params.IDGte = &vString
-			case "id__lt":
-				params.IDLt = &vString
-			case "id__lte":
-				params.IDLte = &vString
-			case "name":
-				params.Name = &vString
-			case "name__ic":
-				params.NameIc = &vString
-			case "name__niew":
-				params.NameNiew = &vString
-			case "name__iew":
-				params.NameIew = &vString
-			case "name__nisw":
-				params.NameNisw = &vString
-			case "name__isw":
-				params.NameIsw = &vString
-			case "slug":
-				params.Slug = &vString
-			case "slug__ic":
-				params.SlugIc = &vString
-			case "slug__niew":
-				params.SlugNiew = &vString
-			case "slug__iew":
-				params.SlugIew = &vString
-			case "slug__nisw":
-				params.SlugNisw = &vString
-			case "slug__isw":
-				params.SlugIsw = &vString
-			default:
-				return fmt.Errorf("'%s' is not a supported filter parameter", k)
-			}
+	pageSize, limit := getPagination(d)
+	results, err := listAll(pageSize, limit, nil, func(offset, limit int64) ([]*models.Tag, int64, error) {
+		params.Offset = &offset
+		params.Limit = &limit
+		res, err := api.Extras.ExtrasTagsList(params, nil, withQueryParams(query))
+		if err != nil {
+			return nil, 0, err
 		}
-	}
-
-	res, err := api.Extras.ExtrasTagsList(params, nil)
+		return res.GetPayload().Results, *res.GetPayload().Count, nil
+	})
 	if err != nil {
 		return err
 	}
 
-	if *res.GetPayload().Count == int64(0) {
+	if len(results) == 0 {
 		return errors.New("no result")
 	}
 
 	var s []map[string]interface{}
-	results := res.GetPayload().Results
 	for _, v := range results {
 		mapping := make(map[string]interface{})
 
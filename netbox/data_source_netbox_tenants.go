@@ -2,43 +2,32 @@ package netbox
 
 import (
 	"errors"
-	"fmt"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/tenancy"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/go-openapi/runtime"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+var tenantsFilters = &dataSourceFilters{
+	params: func() runtime.ClientRequestWriter { return tenancy.NewTenancyTenantsListParams() },
+}
+
 func dataSourceNetboxTenants() *schema.Resource {
 	return &schema.Resource{
 		Read:        dataSourceNetboxTenantsRead,
 		Description: `:meta:subcategory:Tenancy:`,
 		Schema: map[string]*schema.Schema{
-			"filter": {
-				Type:     schema.TypeSet,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"value": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-					},
-				},
-			},
+			"filter": tenantsFilters.schema(),
 			"limit": {
 				Type:             schema.TypeInt,
 				Optional:         true,
-				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
-				Default:          1000,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "The maximum number of objects to return. If unset or `0`, all matching objects are returned.",
 			},
+			"page_size": pageSizeSchema,
 			"tenants": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -149,42 +138,29 @@ func dataSourceNetboxTenants() *schema.Resource {
 }
 
 func dataSourceNetboxTenantsRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	params := tenancy.NewTenancyTenantsListParams()
-
-	if limitValue, ok := d.GetOk("limit"); ok {
-		params.Limit = int64ToPtr(int64(limitValue.(int)))
-	}
-
-	if filter, ok := d.GetOk("filter"); ok {
-		var filterParams = filter.(*schema.Set)
-		for _, f := range filterParams.List() {
-			k := f.(map[string]interface{})["name"]
-			v := f.(map[string]interface{})["value"]
-			vString := v.(string)
-			switch k {
-			case "name":
-				params.Name = &vString
-			case "slug":
-				params.Slug = &vString
-			default:
-				return fmt.Errorf("'%s' is not a supported filter parameter", k)
-			}
+	query := tenantsFilters.query(d)
+
+	pageSize, limit := getPagination(d)
+	filteredTenants, err := listAll(pageSize, limit, nil, func(offset, limit int64) ([]*models.Tenant, int64, error) {
+		params.Offset = &offset
+		params.Limit = &limit
+		res, err := api.Tenancy.TenancyTenantsList(params, nil, withQueryParams(query))
+		if err != nil {
+			return nil, 0, err
 		}
-	}
-
-	res, err := api.Tenancy.TenancyTenantsList(params, nil)
+		return res.GetPayload().Results, *res.GetPayload().Count, nil
+	})
 	if err != nil {
 		return err
 	}
 
-	if *res.GetPayload().Count == int64(0) {
+	if len(filteredTenants) == 0 {
 		return errors.New("no result")
 	}
 
-	filteredTenants := res.GetPayload().Results
-
 	var s []map[string]interface{}
 	for _, v := range filteredTenants {
 		var mapping = make(map[string]interface{})
@@ -3,47 +3,38 @@ package netbox
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 	"regexp"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/virtualization"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/go-openapi/runtime"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+var virtualMachinesFilters = &dataSourceFilters{
+	params: func() runtime.ClientRequestWriter { return virtualization.NewVirtualizationVirtualMachinesListParams() },
+}
+
 func dataSourceNetboxVirtualMachine() *schema.Resource {
 	return &schema.Resource{
 		Read:        dataSourceNetboxVirtualMachineRead,
 		Description: `:meta:subcategory:Virtualization:`,
 		Schema: map[string]*schema.Schema{
-			"filter": {
-				Type:     schema.TypeSet,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"value": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-					},
-				},
-			},
+			"filter": virtualMachinesFilters.schema(),
 			"name_regex": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ValidateFunc: validation.StringIsValidRegExp,
 			},
 			"limit": {
-				Type:     schema.TypeInt,
-				Optional: true,
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "The maximum number of objects to return, after `name_regex` is applied. If unset or `0`, all matching objects are returned.",
 			},
+			"page_size": pageSizeSchema,
 			"vms": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -152,73 +143,40 @@ func dataSourceNetboxVirtualMachine() *schema.Resource {
 }
 
 func dataSourceNetboxVirtualMachineRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	params := virtualization.NewVirtualizationVirtualMachinesListParams()
+	query := virtualMachinesFilters.query(d)
 
-	if filter, ok := d.GetOk("filter"); ok {
-		var filterParams = filter.(*schema.Set)
-		var tags []string
-		for _, f := range filterParams.List() {
-			k := f.(map[string]interface{})["name"]
-			v := f.(map[string]interface{})["value"]
-			vString := v.(string)
-			switch k {
-			case "cluster_id":
-				params.ClusterID = &vString
-			case "cluster_group":
-				params.ClusterGroup = &vString
-			case "device_id":
-				params.Name = &vString
-			case "device":
-				params.Name = &vString
-			case "name":
-				params.Name = &vString
-			case "region":
-				params.Region = &vString
-			case "role":
-				params.Role = &vString
-			case "site":
-				params.Site = &vString
-			case "tenant_id":
-				params.TenantID = &vString
-			case "tag":
-				tags = append(tags, vString)
-				params.Tag = tags
-			case "status":
-				params.Status = &vString
-			default:
-				return fmt.Errorf("'%s' is not a supported filter parameter", k)
-			}
+	var keep func(*models.VirtualMachineWithConfigContext) bool
+	if nameRegex, ok := d.GetOk("name_regex"); ok {
+		r := regexp.MustCompile(nameRegex.(string))
+		keep = func(vm *models.VirtualMachineWithConfigContext) bool {
+			return r.MatchString(*vm.Name)
 		}
 	}
 
-	if limit, ok := d.GetOk("limit"); ok {
-		limitInt := int64(limit.(int))
-		params.Limit = &limitInt
-	}
-
-	res, err := api.Virtualization.VirtualizationVirtualMachinesList(params, nil)
+	// count is the number of objects before name_regex is applied
+	var count int64
+	pageSize, limit := getPagination(d)
+	filteredVms, err := listAll(pageSize, limit, keep, func(offset, limit int64) ([]*models.VirtualMachineWithConfigContext, int64, error) {
+		params.Offset = &offset
+		params.Limit = &limit
+		res, err := api.Virtualization.VirtualizationVirtualMachinesList(params, nil, withQueryParams(query))
+		if err != nil {
+			return nil, 0, err
+		}
+		count = *res.GetPayload().Count
+		return res.GetPayload().Results, count, nil
+	})
 	if err != nil {
 		return err
 	}
 
-	if *res.GetPayload().Count == int64(0) {
+	if count == 0 {
 		return errors.New("no result")
 	}
 
-	var filteredVms []*models.VirtualMachineWithConfigContext
-	if nameRegex, ok := d.GetOk("name_regex"); ok {
-		r := regexp.MustCompile(nameRegex.(string))
-		for _, vm := range res.GetPayload().Results {
-			if r.MatchString(*vm.Name) {
-				filteredVms = append(filteredVms, vm)
-			}
-		}
-	} else {
-		filteredVms = res.GetPayload().Results
-	}
-
 	var s []map[string]interface{}
 	for _, v := range filteredVms {
 		var mapping = make(map[string]interface{})
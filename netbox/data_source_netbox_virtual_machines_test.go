@@ -13,6 +13,7 @@ func TestAccNetboxVirtualMachinesDataSource_basic(t *testing.T) {
 	dependencies := testAccNetboxVirtualMachineDataSourceDependencies(testName)
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: dependencies,
@@ -78,6 +79,7 @@ func TestAccNetboxVirtualMachinesDataSource_tags(t *testing.T) {
 	dependencies := testAccNetboxVirtualMachineDataSourceDependenciesWithTags(testName)
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: dependencies,
@@ -100,6 +102,7 @@ func TestAccNetboxVirtualMachinesDataSource_status(t *testing.T) {
 	dependencies := testAccNetboxVirtualMachineDataSourceDependenciesWithStatus(testName)
 	resource.Test(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: dependencies,
@@ -16,6 +16,7 @@ func TestAccNetboxVlanGroupDataSource_basic(t *testing.T) {
 	extendedSetUp := testAccNetboxVlanGroupSetUpMore(testSlug, anotherSlug, testName)
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: setUp,
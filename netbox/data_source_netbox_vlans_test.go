@@ -65,6 +65,7 @@ func TestAccNetboxVlansDataSource_basic(t *testing.T) {
 	// These Vlans then interfere with the __n filter test
 	resource.Test(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: setUp,
@@ -2,42 +2,32 @@ package netbox
 
 import (
 	"errors"
-	"fmt"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
+	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/go-openapi/runtime"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+var vrfsFilters = &dataSourceFilters{
+	params: func() runtime.ClientRequestWriter { return ipam.NewIpamVrfsListParams() },
+}
+
 func dataSourceNetboxVrfs() *schema.Resource {
 	return &schema.Resource{
 		Read:        dataSourceNetboxVrfsRead,
 		Description: `:meta:subcategory:IP Address Management (IPAM):`,
 		Schema: map[string]*schema.Schema{
-			"filter": {
-				Type:     schema.TypeSet,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"value": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-					},
-				},
-			},
+			"filter": vrfsFilters.schema(),
 			"limit": {
 				Type:             schema.TypeInt,
 				Optional:         true,
-				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
-				Default:          0,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "The maximum number of objects to return. If unset or `0`, all matching objects are returned.",
 			},
+			"page_size": pageSizeSchema,
 			"vrfs": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -71,66 +61,29 @@ func dataSourceNetboxVrfs() *schema.Resource {
 }
 
 func dataSourceNetboxVrfsRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	params := ipam.NewIpamVrfsListParams()
+	query := vrfsFilters.query(d)
 
-	if limitValue, ok := d.GetOk("limit"); ok {
-		params.Limit = int64ToPtr(int64(limitValue.(int)))
-	}
-
-	if filter, ok := d.GetOk("filter"); ok {
-		var filterParams = filter.(*schema.Set)
-		var tags []string
-		for _, f := range filterParams.List() {
-			k := f.(map[string]interface{})["name"]
-			v := f.(map[string]interface{})["value"]
-			vString := v.(string)
-			switch k {
-			case "id":
-				params.ID = &vString
-			case "name":
-				params.Name = &vString
-			case "description":
-				params.Description = &vString
-			case "rd":
-				params.Rd = &vString
-			case "tenant":
-				params.Tenant = &vString
-			case "tenant__n":
-				params.Tenantn = &vString
-			case "tenant_group":
-				params.TenantGroup = &vString
-			case "tenant_group__n":
-				params.TenantGroupn = &vString
-			case "tenant_group_id":
-				params.TenantGroupID = &vString
-			case "tenant_group_id__n":
-				params.TenantGroupIDn = &vString
-			case "tenant_id":
-				params.TenantID = &vString
-			case "tenant_id__n":
-				params.TenantIDn = &vString
-			case "tag":
-				tags = append(tags, vString)
-				params.Tag = tags
-			default:
-				return fmt.Errorf("'%s' is not a supported filter parameter", k)
-			}
+	pageSize, limit := getPagination(d)
+	filteredVrfs, err := listAll(pageSize, limit, nil, func(offset, limit int64) ([]*models.VRF, int64, error) {
+		params.Offset = &offset
+		params.Limit = &limit
+		res, err := api.Ipam.IpamVrfsList(params, nil, withQueryParams(query))
+		if err != nil {
+			return nil, 0, err
 		}
-	}
-
-	res, err := api.Ipam.IpamVrfsList(params, nil)
+		return res.GetPayload().Results, *res.GetPayload().Count, nil
+	})
 	if err != nil {
 		return err
 	}
 
-	if *res.GetPayload().Count == int64(0) {
+	if len(filteredVrfs) == 0 {
 		return errors.New("no result")
 	}
 
-	filteredVrfs := res.GetPayload().Results
-
 	var s []map[string]interface{}
 	for _, v := range filteredVrfs {
 		var mapping = make(map[string]interface{})
@@ -0,0 +1,1258 @@
+package netbox
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"os"
+	"os/exec"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode"
+
+	"github.com/fbreckle/go-netbox/netbox/client/dcim"
+	"github.com/fbreckle/go-netbox/netbox/client/extras"
+	"github.com/fbreckle/go-netbox/netbox/client/tenancy"
+	"github.com/fbreckle/go-netbox/netbox/models"
+	openapierrors "github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	fakeNetboxVersion = "4.0.0"
+	fakeNetboxToken   = "0123456789abcdef0123456789abcdef01234567"
+	// fakeNetboxPageSize is the default page size of Netbox
+	fakeNetboxPageSize = 50
+	// fakeNetboxMaxPageSize is the default maximum page size of Netbox
+	fakeNetboxMaxPageSize = 1000
+)
+
+// testFakeNetbox starts a fake Netbox for the test and points the provider to
+// it via the environment, so the test can run with resource.UnitTest without
+// a real Netbox. The test is skipped if the Terraform CLI is not installed, as
+// the test framework would download it otherwise. Tests using the fake Netbox
+// must not run in parallel.
+// testUnitProviders are the providers of the tests against the fake Netbox.
+var testUnitProviders = map[string]*schema.Provider{
+	"netbox": Provider(),
+}
+
+func testFakeNetbox(t *testing.T) *fakeNetbox {
+	t.Helper()
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") == "" {
+		if _, err := exec.LookPath("terraform"); err != nil {
+			t.Skip("the Terraform CLI is required to run tests against the fake Netbox, install it or set TF_ACC_TERRAFORM_PATH")
+		}
+	}
+
+	f := newFakeNetbox()
+	t.Cleanup(f.Close)
+	t.Setenv("NETBOX_SERVER_URL", f.URL)
+	t.Setenv("NETBOX_API_TOKEN", fakeNetboxToken)
+	t.Setenv("NETBOX_MAX_RETRIES", "0")
+	return f
+}
+
+// fakeNetbox is an in-memory Netbox API server. It supports listing,
+// reading, creating, updating and deleting the objects of every endpoint of
+// the go-netbox client. Like Netbox, it validates the objects written to it
+// against the writable models of the endpoints, rejects references to
+// missing objects and duplicate slugs, and returns references as nested
+// objects and choices as objects with value and label.
+//
+// Lists can be filtered by the fields of the objects, by the IDs of
+// referenced objects (e.g. `site_id`), by the slugs or names of referenced
+// objects (e.g. `site`), by tag, by custom field (e.g. `cf_owner`) and by `q`,
+// with the common lookup expressions like `name__ic` or `vid__gte`. Of the
+// special filters of Netbox, only those of IP addresses, IP ranges and
+// prefixes used by the provider are supported. Other filters fail the
+// request, so that tests don't silently pass with ignored filters. Available
+// IP addresses and prefixes are allocated like Netbox does.
+type fakeNetbox struct {
+	*httptest.Server
+
+	// endpoints are the endpoints by their path relative to the API root,
+	// e.g. `dcim/sites`.
+	endpoints map[string]*fakeEndpoint
+	// models are the endpoints by the name of their model, e.g. `Site`.
+	models map[string]*fakeEndpoint
+
+	mu      sync.Mutex
+	objects map[string]map[int64]map[string]interface{}
+	nextID  map[string]int64
+}
+
+type fakeEndpoint struct {
+	path string
+	// model is the type of the objects returned by the endpoint.
+	model reflect.Type
+	// writable is the type of the objects written to the endpoint.
+	writable reflect.Type
+}
+
+func newFakeNetbox() *fakeNetbox {
+	f := &fakeNetbox{
+		endpoints: map[string]*fakeEndpoint{},
+		models:    map[string]*fakeEndpoint{},
+		objects:   map[string]map[int64]map[string]interface{}{},
+		nextID:    map[string]int64{},
+	}
+	f.discoverEndpoints()
+	// The API token belongs to the admin user, like in the Netbox used for
+	// the acceptance tests
+	f.objects["users/users"] = map[int64]map[string]interface{}{
+		1: {"id": json.Number("1"), "username": "admin", "is_active": true, "is_staff": true},
+	}
+	f.nextID["users/users"] = 1
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	return f
+}
+
+var fakeObjectPathRegexp = regexp.MustCompile(`^/([a-z-]+/[a-z0-9-]+)/(\{id\}/)?$`)
+
+// discoverEndpoints finds the endpoints of the go-netbox client and their
+// models by calling every operation with a recording transport.
+func (f *fakeNetbox) discoverEndpoints() {
+	recorder := &operationRecorder{}
+	endpoint := func(path string) *fakeEndpoint {
+		if f.endpoints[path] == nil {
+			f.endpoints[path] = &fakeEndpoint{path: path}
+		}
+		return f.endpoints[path]
+	}
+	for _, service := range recorder.services() {
+		for i := 0; i < service.NumMethod(); i++ {
+			method := service.Method(i)
+			params, operation := recorder.record(method)
+			if operation == nil {
+				continue
+			}
+			match := fakeObjectPathRegexp.FindStringSubmatch(operation.PathPattern)
+			if match == nil {
+				continue
+			}
+			path, isObject := match[1], match[2] != ""
+			switch {
+			case isObject && operation.Method == http.MethodGet:
+				payload, ok := method.Type().Out(0).Elem().FieldByName("Payload")
+				if ok && payload.Type.Kind() == reflect.Pointer && payload.Type.Elem().Kind() == reflect.Struct {
+					endpoint(path).model = payload.Type.Elem()
+					// Nested devices and virtual machines reference the
+					// models with config context
+					f.models[strings.TrimSuffix(payload.Type.Elem().Name(), "WithConfigContext")] = endpoint(path)
+				}
+			case !isObject && operation.Method == http.MethodPost:
+				data, ok := params.Elem().Type().FieldByName("Data")
+				if ok && data.Type.Kind() == reflect.Pointer && data.Type.Elem().Kind() == reflect.Struct {
+					endpoint(path).writable = data.Type.Elem()
+				}
+			}
+		}
+	}
+	for path, endpoint := range f.endpoints {
+		if endpoint.model == nil || endpoint.writable == nil {
+			delete(f.endpoints, path)
+		}
+	}
+}
+
+// count returns the number of objects of an endpoint.
+func (f *fakeNetbox) count(path string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.objects[path])
+}
+
+func (f *fakeNetbox) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "Token "+fakeNetboxToken {
+		f.writeJSON(w, http.StatusForbidden, map[string]interface{}{"detail": "Invalid token"})
+		return
+	}
+
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/"), "/")
+	if path == "status" && r.Method == http.MethodGet {
+		f.writeJSON(w, http.StatusOK, map[string]interface{}{"netbox-version": fakeNetboxVersion})
+		return
+	}
+	if match := fakeAvailablePathRegexp.FindStringSubmatch(path); match != nil {
+		f.available(w, r, f.endpoints[match[1]], match[2], match[3])
+		return
+	}
+	if endpoint, ok := f.endpoints[path]; ok {
+		switch r.Method {
+		case http.MethodGet:
+			f.list(w, r, endpoint)
+		case http.MethodPost:
+			f.write(w, r, endpoint, 0)
+		default:
+			f.writeJSON(w, http.StatusMethodNotAllowed, map[string]interface{}{"detail": fmt.Sprintf("Method %q not allowed.", r.Method)})
+		}
+		return
+	}
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		endpoint, ok := f.endpoints[path[:i]]
+		id, err := strconv.ParseInt(path[i+1:], 10, 64)
+		if ok && err == nil {
+			if _, exists := f.objects[endpoint.path][id]; !exists {
+				f.writeJSON(w, http.StatusNotFound, map[string]interface{}{"detail": "No " + endpoint.model.Name() + " matches the given query."})
+				return
+			}
+			switch r.Method {
+			case http.MethodGet:
+				f.writeJSON(w, http.StatusOK, f.render(endpoint, f.objects[endpoint.path][id], endpoint.model))
+			case http.MethodPut, http.MethodPatch:
+				f.write(w, r, endpoint, id)
+			case http.MethodDelete:
+				delete(f.objects[endpoint.path], id)
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				f.writeJSON(w, http.StatusMethodNotAllowed, map[string]interface{}{"detail": fmt.Sprintf("Method %q not allowed.", r.Method)})
+			}
+			return
+		}
+	}
+	f.writeJSON(w, http.StatusNotFound, map[string]interface{}{"detail": "Not found."})
+}
+
+func (f *fakeNetbox) writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func (f *fakeNetbox) list(w http.ResponseWriter, r *http.Request, endpoint *fakeEndpoint) {
+	query := r.URL.Query()
+	limit, offset := int64(fakeNetboxPageSize), int64(0)
+	if v := query.Get("limit"); v != "" {
+		limit, _ = strconv.ParseInt(v, 10, 64)
+		if limit <= 0 || limit > fakeNetboxMaxPageSize {
+			limit = fakeNetboxMaxPageSize
+		}
+	}
+	if v := query.Get("offset"); v != "" {
+		offset, _ = strconv.ParseInt(v, 10, 64)
+	}
+
+	var ids []int64
+	for id, object := range f.objects[endpoint.path] {
+		matches := true
+		for name, values := range query {
+			switch name {
+			case "limit", "offset", "brief", "ordering", "format":
+				continue
+			}
+			ok, err := f.matches(endpoint, object, name, values)
+			if err != nil {
+				f.writeJSON(w, http.StatusBadRequest, map[string]interface{}{name: []string{err.Error()}})
+				return
+			}
+			matches = matches && ok
+		}
+		if matches {
+			ids = append(ids, id)
+		}
+	}
+	// Most models are ordered by name, number, prefix or address in Netbox
+	objects := f.objects[endpoint.path]
+	sort.Slice(ids, func(i, j int) bool {
+		for _, field := range []string{"name", "asn", "vid", "prefix", "address"} {
+			if c := fakeCompare(fmt.Sprint(objects[ids[i]][field]), fmt.Sprint(objects[ids[j]][field])); c != 0 {
+				return c < 0
+			}
+		}
+		return ids[i] < ids[j]
+	})
+
+	results := []interface{}{}
+	for i := offset; i < offset+limit && i < int64(len(ids)); i++ {
+		results = append(results, f.render(endpoint, f.objects[endpoint.path][ids[i]], endpoint.model))
+	}
+	var next interface{}
+	if offset+limit < int64(len(ids)) {
+		nextQuery := r.URL.Query()
+		nextQuery.Set("limit", strconv.FormatInt(limit, 10))
+		nextQuery.Set("offset", strconv.FormatInt(offset+limit, 10))
+		next = f.URL + r.URL.Path + "?" + nextQuery.Encode()
+	}
+	f.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"count":    len(ids),
+		"next":     next,
+		"previous": nil,
+		"results":  results,
+	})
+}
+
+var fakeAvailablePathRegexp = regexp.MustCompile(`^(ipam/prefixes|ipam/ip-ranges)/([0-9]+)/(available-ips|available-prefixes)$`)
+
+// available lists or creates the available IP addresses or prefixes of a
+// prefix or IP range. Like Netbox, new IP addresses and prefixes are
+// allocated from the lowest available ones and get the VRF of their parent.
+func (f *fakeNetbox) available(w http.ResponseWriter, r *http.Request, endpoint *fakeEndpoint, id, kind string) {
+	parentID, _ := strconv.ParseInt(id, 10, 64)
+	parent, ok := f.objects[endpoint.path][parentID]
+	if !ok || (kind == "available-prefixes" && endpoint.path != "ipam/prefixes") {
+		f.writeJSON(w, http.StatusNotFound, map[string]interface{}{"detail": "Not found."})
+		return
+	}
+
+	var available []netip.Prefix
+	var child *fakeEndpoint
+	if kind == "available-ips" {
+		child = f.endpoints["ipam/ip-addresses"]
+		available = f.availableIPs(endpoint, parent)
+	} else {
+		child = f.endpoints["ipam/prefixes"]
+		prefix, _ := netip.ParsePrefix(fmt.Sprint(parent["prefix"]))
+		available = f.availablePrefixes(prefix, parent["vrf"])
+	}
+
+	if r.Method == http.MethodGet {
+		results := []interface{}{}
+		for _, prefix := range available {
+			result := map[string]interface{}{
+				"family": 6,
+				"vrf":    f.renderValue(parent["vrf"], fakeFieldType(endpoint.model, "vrf")),
+			}
+			if prefix.Addr().Is4() {
+				result["family"] = 4
+			}
+			if kind == "available-ips" {
+				result["address"] = prefix.String()
+			} else {
+				result["prefix"] = prefix.String()
+			}
+			results = append(results, result)
+			if len(results) == fakeNetboxPageSize {
+				break
+			}
+		}
+		f.writeJSON(w, http.StatusOK, results)
+		return
+	}
+	if r.Method != http.MethodPost {
+		f.writeJSON(w, http.StatusMethodNotAllowed, map[string]interface{}{"detail": fmt.Sprintf("Method %q not allowed.", r.Method)})
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	var body interface{}
+	if err := decoder.Decode(&body); err != nil {
+		f.writeJSON(w, http.StatusBadRequest, map[string]interface{}{"detail": err.Error()})
+		return
+	}
+	requests, isList := body.([]interface{})
+	if !isList {
+		requests = []interface{}{body}
+	}
+
+	var created []interface{}
+	for _, request := range requests {
+		request, _ := request.(map[string]interface{})
+		var allocated netip.Prefix
+		if kind == "available-ips" {
+			if len(available) == 0 {
+				break
+			}
+			allocated, available = available[0], available[1:]
+		} else {
+			length, err := strconv.Atoi(fmt.Sprint(request["prefix_length"]))
+			if err != nil {
+				f.writeJSON(w, http.StatusBadRequest, map[string]interface{}{"prefix_length": []string{"This field is required."}})
+				return
+			}
+			for _, prefix := range available {
+				if prefix.Bits() <= length {
+					allocated = netip.PrefixFrom(prefix.Addr(), length)
+					break
+				}
+			}
+			if !allocated.IsValid() {
+				break
+			}
+		}
+
+		f.nextID[child.path]++
+		childID := f.nextID[child.path]
+		now := time.Now().UTC().Format(time.RFC3339Nano)
+		object := map[string]interface{}{
+			"id":           json.Number(strconv.FormatInt(childID, 10)),
+			"vrf":          parent["vrf"],
+			"created":      now,
+			"last_updated": now,
+		}
+		if kind == "available-ips" {
+			object["address"] = allocated.String()
+		} else {
+			object["prefix"] = allocated.String()
+		}
+		fakeComputeFields(child, object)
+		if f.objects[child.path] == nil {
+			f.objects[child.path] = map[int64]map[string]interface{}{}
+		}
+		f.objects[child.path][childID] = object
+		created = append(created, f.render(child, object, child.model))
+		if kind == "available-prefixes" {
+			prefix, _ := netip.ParsePrefix(fmt.Sprint(parent["prefix"]))
+			available = f.availablePrefixes(prefix, parent["vrf"])
+		}
+	}
+
+	if len(created) < len(requests) {
+		f.writeJSON(w, http.StatusConflict, map[string]interface{}{"detail": "Insufficient resources are available to satisfy the request"})
+		return
+	}
+	if isList {
+		f.writeJSON(w, http.StatusCreated, created)
+	} else {
+		f.writeJSON(w, http.StatusCreated, created[0])
+	}
+}
+
+// availableIPs returns the IP addresses of a prefix or IP range that are not
+// used by IP addresses in its VRF, with the mask length of the prefix.
+func (f *fakeNetbox) availableIPs(endpoint *fakeEndpoint, parent map[string]interface{}) []netip.Prefix {
+	var first, last netip.Addr
+	var bits int
+	if endpoint.path == "ipam/prefixes" {
+		prefix, err := netip.ParsePrefix(fmt.Sprint(parent["prefix"]))
+		if err != nil {
+			return nil
+		}
+		prefix = prefix.Masked()
+		bits = prefix.Bits()
+		first = prefix.Addr()
+		for last = first; prefix.Contains(last.Next()); last = last.Next() {
+		}
+		// The network and broadcast addresses of IPv4 prefixes are not
+		// available
+		if first.Is4() && bits < 31 {
+			first, last = first.Next(), last.Prev()
+		}
+	} else {
+		start, err := netip.ParsePrefix(fmt.Sprint(parent["start_address"]))
+		if err != nil {
+			return nil
+		}
+		end, err := netip.ParsePrefix(fmt.Sprint(parent["end_address"]))
+		if err != nil {
+			return nil
+		}
+		first, last, bits = start.Addr(), end.Addr(), start.Bits()
+	}
+
+	used := map[netip.Addr]bool{}
+	for _, ip := range f.objects["ipam/ip-addresses"] {
+		if address, err := netip.ParsePrefix(fmt.Sprint(ip["address"])); err == nil && fmt.Sprint(ip["vrf"]) == fmt.Sprint(parent["vrf"]) {
+			used[address.Addr()] = true
+		}
+	}
+
+	var available []netip.Prefix
+	for addr := first; addr.IsValid() && addr.Compare(last) <= 0 && len(available) < fakeNetboxMaxPageSize; addr = addr.Next() {
+		if !used[addr] {
+			available = append(available, netip.PrefixFrom(addr, bits))
+		}
+	}
+	return available
+}
+
+// availablePrefixes returns the largest prefixes within a prefix that do not
+// overlap with other prefixes within it in its VRF, in ascending order.
+func (f *fakeNetbox) availablePrefixes(parent netip.Prefix, vrf interface{}) []netip.Prefix {
+	var children []netip.Prefix
+	for _, object := range f.objects["ipam/prefixes"] {
+		prefix, err := netip.ParsePrefix(fmt.Sprint(object["prefix"]))
+		if err == nil && fmt.Sprint(object["vrf"]) == fmt.Sprint(vrf) && prefix.Bits() > parent.Bits() && parent.Contains(prefix.Addr()) {
+			children = append(children, prefix.Masked())
+		}
+	}
+
+	var available []netip.Prefix
+	var split func(prefix netip.Prefix)
+	split = func(prefix netip.Prefix) {
+		overlapping := false
+		for _, child := range children {
+			if child.Overlaps(prefix) {
+				if child.Bits() <= prefix.Bits() {
+					return
+				}
+				overlapping = true
+			}
+		}
+		if !overlapping {
+			available = append(available, prefix)
+			return
+		}
+		split(netip.PrefixFrom(prefix.Addr(), prefix.Bits()+1))
+		upper := prefix.Addr().AsSlice()
+		upper[prefix.Bits()/8] |= 0x80 >> (prefix.Bits() % 8)
+		addr, _ := netip.AddrFromSlice(upper)
+		split(netip.PrefixFrom(addr, prefix.Bits()+1))
+	}
+	split(parent.Masked())
+	return available
+}
+
+// fakeLookups are the lookup expressions supported by the fake Netbox. They
+// return whether a value of an object matches a value of a filter.
+var fakeLookups = map[string]func(candidate, value string) bool{
+	"":   func(candidate, value string) bool { return candidate == value },
+	"ie": strings.EqualFold,
+	"ic": func(candidate, value string) bool {
+		return strings.Contains(strings.ToLower(candidate), strings.ToLower(value))
+	},
+	"isw": func(candidate, value string) bool {
+		return strings.HasPrefix(strings.ToLower(candidate), strings.ToLower(value))
+	},
+	"iew": func(candidate, value string) bool {
+		return strings.HasSuffix(strings.ToLower(candidate), strings.ToLower(value))
+	},
+	"gt":  func(candidate, value string) bool { return fakeCompare(candidate, value) > 0 },
+	"gte": func(candidate, value string) bool { return fakeCompare(candidate, value) >= 0 },
+	"lt":  func(candidate, value string) bool { return fakeCompare(candidate, value) < 0 },
+	"lte": func(candidate, value string) bool { return fakeCompare(candidate, value) <= 0 },
+	"empty": func(candidate, value string) bool {
+		return (candidate == "" || candidate == "null") == (value == "true")
+	},
+}
+
+// fakeSpecialFilters are filters of Netbox that don't filter by a field of
+// the objects, by endpoint and name. They return whether an object matches a
+// value of the filter.
+var fakeSpecialFilters = map[string]map[string]func(object map[string]interface{}, value string) bool{
+	"ipam/ip-addresses": {
+		"parent":         fakeWithin("address", true),
+		"mask_length":    fakeMaskLength("address"),
+		"interface_id":   fakeAssignedTo("dcim.interface"),
+		"vminterface_id": fakeAssignedTo("virtualization.vminterface"),
+	},
+	"ipam/ip-ranges": {
+		"contains": fakeRangeContains,
+	},
+	"ipam/prefixes": {
+		"within":         fakeWithin("prefix", false),
+		"within_include": fakeWithin("prefix", true),
+		"contains":       fakeContains("prefix"),
+		"mask_length":    fakeMaskLength("prefix"),
+	},
+}
+
+// matches returns whether an object matches any of the values of a filter.
+func (f *fakeNetbox) matches(endpoint *fakeEndpoint, object map[string]interface{}, name string, values []string) (bool, error) {
+	if filter, ok := fakeSpecialFilters[endpoint.path][name]; ok {
+		for _, value := range values {
+			if filter(object, value) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	if name == "q" {
+		display := strings.ToLower(fakeDisplay(object))
+		for _, value := range values {
+			if strings.Contains(display, strings.ToLower(value)) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	// Lookups prefixed with `n` are negated, e.g. `name__nic`
+	name, lookupName, _ := strings.Cut(name, "__")
+	lookup, negated := fakeLookups[lookupName], false
+	if lookup == nil && strings.HasPrefix(lookupName, "n") {
+		lookup, negated = fakeLookups[strings.TrimPrefix(lookupName, "n")], true
+	}
+	if lookup == nil {
+		return false, fmt.Errorf("the fake Netbox does not support the lookup expression `%s`", lookupName)
+	}
+
+	var candidates []string
+	switch {
+	case name == "id":
+		candidates = []string{fmt.Sprint(object["id"])}
+	case name == "tag" && lookupName == "":
+		// Objects must have all tags
+		tags := f.referenceKeys(object["tags"], fakeFieldType(endpoint.model, "tags"), false)
+		for _, value := range values {
+			if !slices.Contains(tags, value) {
+				return false, nil
+			}
+		}
+		return true, nil
+	case name == "tag":
+		candidates = f.referenceKeys(object["tags"], fakeFieldType(endpoint.model, "tags"), false)
+	case strings.HasPrefix(name, "cf_"):
+		customFields, _ := object["custom_fields"].(map[string]interface{})
+		candidates = []string{fakeFilterValue(customFields[strings.TrimPrefix(name, "cf_")])}
+	case strings.HasSuffix(name, "_id") && fakeIsReference(fakeFieldType(endpoint.model, strings.TrimSuffix(name, "_id"))):
+		candidates = f.referenceKeys(object[strings.TrimSuffix(name, "_id")], fakeFieldType(endpoint.model, strings.TrimSuffix(name, "_id")), true)
+	case fakeIsReference(fakeFieldType(endpoint.model, name)):
+		candidates = f.referenceKeys(object[name], fakeFieldType(endpoint.model, name), false)
+	case fakeFieldType(endpoint.model, name) != nil:
+		candidates = []string{fakeFilterValue(object[name])}
+	case f.referencedField(endpoint, object, name) != nil:
+		candidates = f.referencedField(endpoint, object, name)
+	default:
+		return false, fmt.Errorf("the fake Netbox does not support this filter")
+	}
+
+	for _, value := range values {
+		for _, candidate := range candidates {
+			if lookup(candidate, value) {
+				return !negated, nil
+			}
+		}
+	}
+	return negated, nil
+}
+
+// fakeAssignedTo returns a filter matching objects assigned to an object of
+// a type by its ID.
+func fakeAssignedTo(objectType string) func(object map[string]interface{}, value string) bool {
+	return func(object map[string]interface{}, value string) bool {
+		return object["assigned_object_type"] == objectType && fmt.Sprint(object["assigned_object_id"]) == value
+	}
+}
+
+// fakeCompare compares two numbers, or two strings if they are no numbers.
+func fakeCompare(a, b string) int {
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+// fakeWithin returns a filter matching objects whose address or prefix in
+// field lies within a prefix.
+func fakeWithin(field string, include bool) func(object map[string]interface{}, value string) bool {
+	return func(object map[string]interface{}, value string) bool {
+		parent, err := netip.ParsePrefix(value)
+		if err != nil {
+			return false
+		}
+		prefix, err := netip.ParsePrefix(fmt.Sprint(object[field]))
+		if err != nil {
+			return false
+		}
+		if prefix.Masked() == parent.Masked() {
+			return include
+		}
+		return prefix.Bits() >= parent.Bits() && parent.Contains(prefix.Addr())
+	}
+}
+
+// fakeContains returns a filter matching objects whose prefix in field
+// contains an address or prefix.
+func fakeContains(field string) func(object map[string]interface{}, value string) bool {
+	return func(object map[string]interface{}, value string) bool {
+		prefix, err := netip.ParsePrefix(fmt.Sprint(object[field]))
+		if err != nil {
+			return false
+		}
+		child, err := netip.ParsePrefix(value)
+		if err != nil {
+			addr, err := netip.ParseAddr(value)
+			if err != nil {
+				return false
+			}
+			child = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		return child.Bits() >= prefix.Bits() && prefix.Contains(child.Addr())
+	}
+}
+
+// fakeRangeContains matches IP ranges containing an address.
+func fakeRangeContains(object map[string]interface{}, value string) bool {
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return false
+		}
+		addr = prefix.Addr()
+	}
+	start, errStart := netip.ParsePrefix(fmt.Sprint(object["start_address"]))
+	end, errEnd := netip.ParsePrefix(fmt.Sprint(object["end_address"]))
+	return errStart == nil && errEnd == nil && start.Addr().Compare(addr) <= 0 && addr.Compare(end.Addr()) <= 0
+}
+
+// fakeMaskLength returns a filter matching objects whose address or prefix
+// in field has a mask length.
+func fakeMaskLength(field string) func(object map[string]interface{}, value string) bool {
+	return func(object map[string]interface{}, value string) bool {
+		prefix, err := netip.ParsePrefix(fmt.Sprint(object[field]))
+		return err == nil && strconv.Itoa(prefix.Bits()) == value
+	}
+}
+
+// referencedField returns the value of a field of an object referenced by
+// an object for filters like `vlan_vid`, or nil if name is no such filter.
+func (f *fakeNetbox) referencedField(endpoint *fakeEndpoint, object map[string]interface{}, name string) []string {
+	for i := range name {
+		if name[i] != '_' {
+			continue
+		}
+		field, referencedField := name[:i], name[i+1:]
+		t := fakeFieldType(endpoint.model, field)
+		if !fakeIsReference(t) || t.Kind() == reflect.Slice {
+			continue
+		}
+		referencedEndpoint, referenced := f.reference(object[field], t)
+		if referencedEndpoint == nil || fakeFieldType(referencedEndpoint.model, referencedField) == nil {
+			return nil
+		}
+		return []string{fakeFilterValue(referenced[referencedField])}
+	}
+	return nil
+}
+
+// referenceKeys returns the keys a reference or a list of references can be
+// filtered by, either the IDs or the slugs and names of the referenced
+// objects. Missing references are matched by `null`.
+func (f *fakeNetbox) referenceKeys(value interface{}, t reflect.Type, byID bool) []string {
+	if value == nil {
+		return []string{"null"}
+	}
+	if values, ok := value.([]interface{}); ok {
+		var keys []string
+		for _, value := range values {
+			keys = append(keys, f.referenceKeys(value, t.Elem(), byID)...)
+		}
+		return keys
+	}
+	_, object := f.reference(value, t)
+	if object == nil {
+		return nil
+	}
+	if byID {
+		return []string{fmt.Sprint(object["id"])}
+	}
+	return []string{fakeFilterValue(object["slug"]), fakeFilterValue(object["name"])}
+}
+
+// reference returns the endpoint and the object referenced by a value, which
+// is either an ID or an object with the ID, the slug or the name of the
+// referenced object. t is the type of the nested model of the reference.
+func (f *fakeNetbox) reference(value interface{}, t reflect.Type) (*fakeEndpoint, map[string]interface{}) {
+	// Models of references are named like the referenced model, with a
+	// prefix like in NestedDevice, ComponentNestedModule or FrontPortRearPort
+	var endpoint *fakeEndpoint
+	name := fakeStructType(t).Name()
+	for i := range name {
+		if unicode.IsUpper(rune(name[i])) && f.models[name[i:]] != nil {
+			endpoint = f.models[name[i:]]
+			break
+		}
+	}
+	if endpoint == nil {
+		return nil, nil
+	}
+	switch v := value.(type) {
+	case json.Number:
+		id, _ := v.Int64()
+		return endpoint, f.objects[endpoint.path][id]
+	case string:
+		id, _ := strconv.ParseInt(v, 10, 64)
+		return endpoint, f.objects[endpoint.path][id]
+	case map[string]interface{}:
+		for _, key := range []string{"id", "slug", "name"} {
+			if v[key] == nil {
+				continue
+			}
+			for _, object := range f.objects[endpoint.path] {
+				if fmt.Sprint(object[key]) == fmt.Sprint(v[key]) {
+					return endpoint, object
+				}
+			}
+			return endpoint, nil
+		}
+	}
+	return endpoint, nil
+}
+
+// write creates the object with the body of the request, or updates it if
+// id is not 0.
+func (f *fakeNetbox) write(w http.ResponseWriter, r *http.Request, endpoint *fakeEndpoint, id int64) {
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	var body map[string]interface{}
+	if err := decoder.Decode(&body); err != nil {
+		f.writeJSON(w, http.StatusBadRequest, map[string]interface{}{"non_field_errors": []string{"Invalid data. Expected a dictionary, but got " + err.Error() + "."}})
+		return
+	}
+
+	// Like Netbox, leading and trailing whitespace is stripped from strings
+	// and numbers are accepted as strings
+	for field, value := range body {
+		value, ok := value.(string)
+		if !ok {
+			continue
+		}
+		switch t := fakeFieldType(endpoint.writable, field); {
+		case t == nil:
+		case fakeStructType(t) == nil && (t.Kind() == reflect.String || t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.String):
+			body[field] = strings.TrimSpace(value)
+		case fakeIsNumber(t):
+			if _, err := strconv.ParseFloat(value, 64); err == nil {
+				body[field] = json.Number(value)
+			}
+		}
+	}
+
+	// Fields missing in the body keep their values, even for PUT requests
+	object := map[string]interface{}{}
+	for field, value := range f.objects[endpoint.path][id] {
+		object[field] = value
+	}
+	for field, value := range body {
+		object[field] = value
+	}
+
+	if errs := f.validate(endpoint, id, body, object); len(errs) > 0 {
+		f.writeJSON(w, http.StatusBadRequest, errs)
+		return
+	}
+
+	fakeComputeFields(endpoint, object)
+
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+	status := http.StatusOK
+	if id == 0 {
+		f.nextID[endpoint.path]++
+		id = f.nextID[endpoint.path]
+		object["id"] = json.Number(strconv.FormatInt(id, 10))
+		object["created"] = now
+		status = http.StatusCreated
+	}
+	object["last_updated"] = now
+	if f.objects[endpoint.path] == nil {
+		f.objects[endpoint.path] = map[int64]map[string]interface{}{}
+	}
+	f.objects[endpoint.path][id] = object
+
+	f.writeJSON(w, status, f.render(endpoint, object, endpoint.model))
+}
+
+// fakeScopedSlugs are the endpoints whose slugs are only unique within a
+// site or another scope, which the fake Netbox does not check.
+var fakeScopedSlugs = map[string]bool{
+	"dcim/locations":   true,
+	"ipam/vlan-groups": true,
+}
+
+// fakeStatusDefaults are the default statuses of the models whose default
+// status is not `active`.
+var fakeStatusDefaults = map[string]string{
+	"Cable": "connected",
+}
+
+// fakeComputeFields sets the fields of an object that Netbox sets by default
+// or computes from other fields.
+func fakeComputeFields(endpoint *fakeEndpoint, object map[string]interface{}) {
+	if fakeIsChoice(fakeFieldType(endpoint.model, "status")) && object["status"] == nil {
+		if status, ok := fakeStatusDefaults[endpoint.model.Name()]; ok {
+			object["status"] = status
+		} else {
+			object["status"] = "active"
+		}
+	}
+	if mac, ok := object["mac_address"].(string); ok {
+		object["mac_address"] = strings.ToUpper(mac)
+	}
+	if fakeIsChoice(fakeFieldType(endpoint.model, "family")) {
+		for _, field := range []string{"address", "prefix", "start_address"} {
+			if prefix, err := netip.ParsePrefix(fmt.Sprint(object[field])); err == nil && prefix.Addr().Is4() {
+				object["family"] = map[string]interface{}{"value": json.Number("4"), "label": "IPv4"}
+			} else if err == nil {
+				object["family"] = map[string]interface{}{"value": json.Number("6"), "label": "IPv6"}
+			}
+		}
+	}
+}
+
+// validate validates an object written to an endpoint like Netbox does. It
+// returns the errors by field.
+func (f *fakeNetbox) validate(endpoint *fakeEndpoint, id int64, body, object map[string]interface{}) map[string][]string {
+	errs := map[string][]string{}
+
+	for field, value := range body {
+		t := fakeFieldType(endpoint.model, field)
+		if !fakeIsReference(t) || value == nil {
+			continue
+		}
+		values, isList := value.([]interface{})
+		if !isList {
+			values = []interface{}{value}
+		}
+		for _, value := range values {
+			if referenced, object := f.reference(value, t); referenced != nil && object == nil {
+				errs[field] = append(errs[field], fmt.Sprintf("Related object not found using the provided attributes: %v", value))
+			}
+		}
+	}
+
+	if slug, ok := object["slug"]; ok && !fakeScopedSlugs[endpoint.path] {
+		for otherID, other := range f.objects[endpoint.path] {
+			if otherID != id && other["slug"] == slug {
+				errs["slug"] = append(errs["slug"], strings.ToLower(endpoint.model.Name())+" with this slug already exists.")
+			}
+		}
+	}
+
+	// Fields computed by Netbox are not validated
+	writableFields := map[string]interface{}{}
+	for field, value := range object {
+		if fakeFieldType(endpoint.writable, field) != nil && field != "family" {
+			writableFields[field] = value
+		}
+	}
+	encoded, err := json.Marshal(writableFields)
+	if err != nil {
+		errs["non_field_errors"] = append(errs["non_field_errors"], err.Error())
+		return errs
+	}
+	writable := reflect.New(endpoint.writable)
+	if err := json.Unmarshal(encoded, writable.Interface()); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			field := strings.Split(typeErr.Field, ".")[0]
+			errs[field] = append(errs[field], fmt.Sprintf("Expected a %s, got %s.", typeErr.Type, typeErr.Value))
+		} else {
+			errs["non_field_errors"] = append(errs["non_field_errors"], err.Error())
+		}
+		return errs
+	}
+	if validatable, ok := writable.Interface().(interface{ Validate(strfmt.Registry) error }); ok {
+		if err := validatable.Validate(strfmt.Default); err != nil {
+			fakeValidationErrors(err, body, errs)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// fakeValidationErrors adds the errors of the validation of a go-netbox model
+// to errs in the form of Netbox.
+func fakeValidationErrors(err error, body map[string]interface{}, errs map[string][]string) {
+	var composite *openapierrors.CompositeError
+	if errors.As(err, &composite) {
+		for _, err := range composite.Errors {
+			fakeValidationErrors(err, body, errs)
+		}
+		return
+	}
+	var validation *openapierrors.Validation
+	if !errors.As(err, &validation) {
+		errs["non_field_errors"] = append(errs["non_field_errors"], err.Error())
+		return
+	}
+	field := strings.Split(validation.Name, ".")[0]
+	value, inBody := body[field]
+	switch {
+	case validation.Code() == openapierrors.RequiredFailCode && field == validation.Name && inBody && value == nil:
+		errs[field] = append(errs[field], "This field may not be null.")
+	case validation.Code() == openapierrors.RequiredFailCode && field == validation.Name:
+		errs[field] = append(errs[field], "This field is required.")
+	default:
+		errs[field] = append(errs[field], validation.Error())
+	}
+}
+
+// fakeReverseFields are the fields Netbox computes from the objects
+// referencing an object, by endpoint and name. They are computed from the
+// objects of an endpoint referencing the object by a field.
+var fakeReverseFields = map[string]map[string]struct{ endpoint, field string }{
+	"ipam/ip-addresses": {
+		"nat_outside": {"ipam/ip-addresses", "nat_inside"},
+	},
+}
+
+// render returns an object in the form of the model t as returned by
+// Netbox.
+func (f *fakeNetbox) render(endpoint *fakeEndpoint, object map[string]interface{}, t reflect.Type) map[string]interface{} {
+	rendered := map[string]interface{}{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		switch name {
+		case "", "-":
+			continue
+		case "url":
+			rendered[name] = fmt.Sprintf("%s/api/%s/%v/", f.URL, endpoint.path, object["id"])
+			continue
+		case "display":
+			rendered[name] = fakeDisplay(object)
+			continue
+		}
+		if reverse, ok := fakeReverseFields[endpoint.path][name]; ok {
+			referencing := []interface{}{}
+			for id, other := range f.objects[reverse.endpoint] {
+				if fmt.Sprint(other[reverse.field]) == fmt.Sprint(object["id"]) {
+					referencing = append(referencing, json.Number(strconv.FormatInt(id, 10)))
+				}
+			}
+			rendered[name] = f.renderValue(referencing, field.Type)
+			continue
+		}
+		value, ok := object[name]
+		if !ok {
+			switch name {
+			case "tags":
+				rendered[name] = []interface{}{}
+			case "custom_fields":
+				rendered[name] = map[string]interface{}{}
+			}
+			continue
+		}
+		// Nested models have the values of choices only
+		if choice, ok := value.(map[string]interface{}); ok && t != endpoint.model && !fakeIsChoice(field.Type) && len(choice) == 2 && choice["label"] != nil {
+			value = choice["value"]
+		}
+		rendered[name] = f.renderValue(value, field.Type)
+	}
+	return rendered
+}
+
+func (f *fakeNetbox) renderValue(value interface{}, t reflect.Type) interface{} {
+	if value == nil {
+		return nil
+	}
+	switch {
+	case fakeIsChoice(t):
+		if choice, ok := value.(map[string]interface{}); ok {
+			return choice
+		}
+		return map[string]interface{}{"value": value, "label": fmt.Sprint(value)}
+	case fakeIsReference(t) && t.Kind() != reflect.Slice:
+		endpoint, object := f.reference(value, t)
+		if endpoint == nil {
+			return value
+		}
+		// References to deleted objects are cleared
+		if object == nil {
+			return nil
+		}
+		return f.render(endpoint, object, fakeStructType(t))
+	case t.Kind() == reflect.Slice:
+		values, ok := value.([]interface{})
+		if !ok {
+			return value
+		}
+		rendered := []interface{}{}
+		for _, value := range values {
+			if value := f.renderValue(value, t.Elem()); value != nil {
+				rendered = append(rendered, value)
+			}
+		}
+		return rendered
+	}
+	return value
+}
+
+// fakeDisplay returns the display name of an object.
+func fakeDisplay(object map[string]interface{}) string {
+	for _, field := range []string{"name", "address", "prefix", "model", "label"} {
+		if value, ok := object[field].(string); ok && value != "" {
+			return value
+		}
+	}
+	return fmt.Sprint(object["id"])
+}
+
+// fakeFilterValue returns a value of an object in the form it is filtered
+// by.
+func fakeFilterValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return fmt.Sprint(v["value"])
+	}
+	return fmt.Sprint(value)
+}
+
+// fakeFieldType returns the type of the field of the model t with the JSON
+// name, or nil if there is none.
+func fakeFieldType(t reflect.Type, name string) reflect.Type {
+	for i := 0; i < t.NumField(); i++ {
+		if strings.Split(t.Field(i).Tag.Get("json"), ",")[0] == name {
+			return t.Field(i).Type
+		}
+	}
+	return nil
+}
+
+// fakeStructType returns the struct type of a field type, dereferencing
+// pointers and slices.
+func fakeStructType(t reflect.Type) reflect.Type {
+	for t != nil && (t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice) {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	return t
+}
+
+// fakeIsReference returns whether a field type is a reference or a list of
+// references to other objects. References are usually nested models, but
+// some are full models.
+func fakeIsReference(t reflect.Type) bool {
+	s := fakeStructType(t)
+	if s == nil {
+		return false
+	}
+	_, ok := s.FieldByName("ID")
+	return ok
+}
+
+// fakeIsNumber returns whether a field type is a number.
+func fakeIsNumber(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int64, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// fakeIsChoice returns whether a field type is a choice with value and label.
+func fakeIsChoice(t reflect.Type) bool {
+	if t == nil || t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct {
+		return false
+	}
+	_, hasValue := t.Elem().FieldByName("Value")
+	_, hasLabel := t.Elem().FieldByName("Label")
+	return hasValue && hasLabel
+}
+
+func TestFakeNetbox(t *testing.T) {
+	f := newFakeNetbox()
+	defer f.Close()
+
+	for _, path := range []string{"dcim/sites", "dcim/devices", "ipam/ip-addresses", "tenancy/tenants", "virtualization/interfaces", "extras/tags"} {
+		assert.Contains(t, f.endpoints, path)
+	}
+
+	config := Config{
+		APIToken:  fakeNetboxToken,
+		ServerURL: f.URL,
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+
+	group, err := client.Tenancy.TenancyTenantGroupsCreate(tenancy.NewTenancyTenantGroupsCreateParams().WithData(&models.WritableTenantGroup{Name: strToPtr("group"), Slug: strToPtr("group")}), nil)
+	assert.NoError(t, err)
+	_, err = client.Extras.ExtrasTagsCreate(extras.NewExtrasTagsCreateParams().WithData(&models.Tag{Name: strToPtr("tag"), Slug: strToPtr("tag")}), nil)
+	assert.NoError(t, err)
+
+	// References are returned as nested objects, tags are found by name
+	tenant, err := client.Tenancy.TenancyTenantsCreate(tenancy.NewTenancyTenantsCreateParams().WithData(&models.WritableTenant{
+		Name:  strToPtr("tenant"),
+		Slug:  strToPtr("tenant"),
+		Group: &group.Payload.ID,
+		Tags:  []*models.NestedTag{{Name: strToPtr("tag"), Slug: strToPtr("tag")}},
+	}), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "group", *tenant.Payload.Group.Name)
+	if assert.Len(t, tenant.Payload.Tags, 1) {
+		assert.NotZero(t, tenant.Payload.Tags[0].ID)
+	}
+	assert.NotNil(t, tenant.Payload.LastUpdated)
+
+	// Choices are returned with value and label
+	site, err := client.Dcim.DcimSitesCreate(dcim.NewDcimSitesCreateParams().WithData(&models.WritableSite{
+		Name:   strToPtr("site"),
+		Slug:   strToPtr("site"),
+		Status: "planned",
+		Tenant: &tenant.Payload.ID,
+		Tags:   []*models.NestedTag{},
+	}), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "planned", *site.Payload.Status.Value)
+
+	// Updates keep the fields missing in the body
+	_, err = client.Dcim.DcimSitesUpdate(dcim.NewDcimSitesUpdateParams().WithID(site.Payload.ID).WithData(&models.WritableSite{Name: strToPtr("site"), Slug: strToPtr("site"), Description: "updated", Tags: []*models.NestedTag{}}), nil)
+	assert.NoError(t, err)
+	read, err := client.Dcim.DcimSitesRead(dcim.NewDcimSitesReadParams().WithID(site.Payload.ID), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "updated", read.Payload.Description)
+	assert.Equal(t, tenant.Payload.ID, read.Payload.Tenant.ID)
+
+	// Lists are filtered by fields and references
+	for _, filter := range []func(*tenancy.TenancyTenantsListParams){
+		func(p *tenancy.TenancyTenantsListParams) { p.Name = strToPtr("tenant") },
+		func(p *tenancy.TenancyTenantsListParams) { p.Group = strToPtr("group") },
+		func(p *tenancy.TenancyTenantsListParams) { p.Tag = []string{"tag"} },
+	} {
+		params := tenancy.NewTenancyTenantsListParams()
+		filter(params)
+		list, err := client.Tenancy.TenancyTenantsList(params, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), *list.Payload.Count)
+	}
+	list, err := client.Tenancy.TenancyTenantsList(tenancy.NewTenancyTenantsListParams().WithName(strToPtr("other")), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), *list.Payload.Count)
+	list, err = client.Tenancy.TenancyTenantsList(tenancy.NewTenancyTenantsListParams().WithNameIc(strToPtr("TEN")), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), *list.Payload.Count)
+	list, err = client.Tenancy.TenancyTenantsList(tenancy.NewTenancyTenantsListParams().WithNamen(strToPtr("tenant")), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), *list.Payload.Count)
+	_, err = client.Tenancy.TenancyTenantsList(tenancy.NewTenancyTenantsListParams(), nil, withQueryParams(url.Values{"name__regex": {"^t"}}))
+	assert.Error(t, err)
+
+	// Invalid objects are rejected like Netbox does
+	_, err = client.Tenancy.TenancyTenantsCreate(tenancy.NewTenancyTenantsCreateParams().WithData(&models.WritableTenant{Name: strToPtr("other"), Slug: strToPtr("tenant"), Tags: []*models.NestedTag{}}), nil)
+	diags := netboxErrorDiagnostics(err)
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "tenant with this slug already exists.", diags[0].Summary)
+	}
+	missing := int64(42)
+	_, err = client.Tenancy.TenancyTenantsCreate(tenancy.NewTenancyTenantsCreateParams().WithData(&models.WritableTenant{Name: strToPtr("other"), Slug: strToPtr("other"), Group: &missing, Tags: []*models.NestedTag{}}), nil)
+	diags = netboxErrorDiagnostics(err)
+	if assert.Len(t, diags, 1) {
+		assert.Contains(t, diags[0].Detail, "`group`")
+	}
+
+	_, err = client.Dcim.DcimSitesDelete(dcim.NewDcimSitesDeleteParams().WithID(site.Payload.ID), nil)
+	assert.NoError(t, err)
+	_, err = client.Dcim.DcimSitesRead(dcim.NewDcimSitesReadParams().WithID(site.Payload.ID), nil)
+	var notFound *dcim.DcimSitesReadDefault
+	if assert.ErrorAs(t, err, &notFound) {
+		assert.Equal(t, http.StatusNotFound, notFound.Code())
+	}
+	assert.Equal(t, 0, f.count("dcim/sites"))
+}
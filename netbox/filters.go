@@ -0,0 +1,185 @@
+package netbox
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceFilters describes the filters of a data source listing the
+// objects of a Netbox endpoint. Every filter of the endpoint is passed to
+// Netbox as it is, including lookup expressions like `name__ic` and custom
+// field filters like `cf_owner`. Filters given several times match objects
+// matching any of the values, as far as Netbox supports this for the filter.
+type dataSourceFilters struct {
+	// params returns new go-netbox list parameters of the endpoint. The
+	// query parameters they can write are the valid filters. If it is nil,
+	// every filter is valid.
+	params func() runtime.ClientRequestWriter
+	// aliases are names of filters supported by former versions of the
+	// provider that differ from the names of the Netbox filters.
+	aliases map[string]filterAlias
+
+	once  sync.Once
+	valid map[string]bool
+}
+
+type filterAlias struct {
+	// filter is the Netbox filter the alias stands for.
+	filter string
+	// separator splits the value of the alias into several values, if set.
+	separator string
+}
+
+// paginationFilters are set by the data sources while paging through the
+// results.
+var paginationFilters = map[string]bool{"limit": true, "offset": true}
+
+// schema returns the schema of the `filter` blocks of the data source.
+// Unsupported filter names fail at plan time.
+func (f *dataSourceFilters) schema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: "Filters passed to Netbox. All filters of the endpoint are supported, including lookup expressions like `name__ic` and custom field filters like `cf_owner`. Filters given several times match objects matching any of the values.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: f.validateName,
+				},
+				"value": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+			},
+		},
+	}
+}
+
+func (f *dataSourceFilters) validateName(i interface{}, k string) ([]string, []error) {
+	name := i.(string)
+	if paginationFilters[name] {
+		return nil, []error{fmt.Errorf("%q is not a supported filter, use the `limit` and `page_size` attributes instead", name)}
+	}
+	if _, ok := f.aliases[name]; ok || strings.HasPrefix(name, "cf_") {
+		return nil, nil
+	}
+	valid := f.validFilters()
+	if valid == nil || valid[name] {
+		return nil, nil
+	}
+	return nil, []error{fmt.Errorf("%q is not a supported filter, valid filters are %s. They support the lookup expressions Netbox offers for them, e.g. `name__ic` or `name__n`. Custom fields are filtered by `cf_<name>`", name, strings.Join(baseFilterNames(valid), ", "))}
+}
+
+// validFilters returns the query parameters written by the list parameters,
+// or nil if every filter is valid.
+func (f *dataSourceFilters) validFilters() map[string]bool {
+	if f.params == nil {
+		return nil
+	}
+	f.once.Do(func() {
+		recorder := &queryParamRecorder{params: map[string]bool{}}
+		// Parameters are only written if they are set, so all are set
+		params := f.params()
+		setAllFields(reflect.ValueOf(params))
+		params.WriteToRequest(recorder, strfmt.Default)
+		for name := range paginationFilters {
+			delete(recorder.params, name)
+		}
+		f.valid = recorder.params
+	})
+	return f.valid
+}
+
+// query returns the query parameters for the `filter` blocks of the data
+// source.
+func (f *dataSourceFilters) query(d *schema.ResourceData) url.Values {
+	query := url.Values{}
+	for _, filter := range d.Get("filter").(*schema.Set).List() {
+		name := filter.(map[string]interface{})["name"].(string)
+		value := filter.(map[string]interface{})["value"].(string)
+		alias, ok := f.aliases[name]
+		if !ok {
+			query.Add(name, value)
+			continue
+		}
+		if alias.separator == "" {
+			query.Add(alias.filter, value)
+			continue
+		}
+		for _, v := range strings.Split(value, alias.separator) {
+			query.Add(alias.filter, v)
+		}
+	}
+	return query
+}
+
+// baseFilterNames returns the sorted names of the filters without lookup
+// expressions.
+func baseFilterNames(filters map[string]bool) []string {
+	var names []string
+	for name := range filters {
+		if !strings.Contains(name, "__") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// setAllFields sets every exported pointer and slice field of the struct v
+// points to to a non-empty value.
+func setAllFields(v reflect.Value) {
+	v = v.Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+		switch field.Kind() {
+		case reflect.Pointer:
+			value := reflect.New(field.Type().Elem())
+			switch value.Elem().Kind() {
+			case reflect.String:
+				value.Elem().SetString("x")
+			case reflect.Int, reflect.Int64:
+				value.Elem().SetInt(1)
+			case reflect.Float64:
+				value.Elem().SetFloat(1)
+			case reflect.Bool:
+				value.Elem().SetBool(true)
+			}
+			field.Set(value)
+		case reflect.Slice:
+			if field.Type().Elem().Kind() == reflect.String {
+				field.Set(reflect.ValueOf([]string{"x"}))
+			}
+		}
+	}
+}
+
+// queryParamRecorder is a client request recording the names of the query
+// parameters set on it.
+type queryParamRecorder struct {
+	runtime.ClientRequest
+	params map[string]bool
+}
+
+func (r *queryParamRecorder) SetQueryParam(name string, _ ...string) error {
+	r.params[name] = true
+	return nil
+}
+
+func (r *queryParamRecorder) SetTimeout(time.Duration) error {
+	return nil
+}
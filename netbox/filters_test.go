@@ -0,0 +1,70 @@
+package netbox
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceFiltersValid(t *testing.T) {
+	valid := devicesFilters.validFilters()
+	for _, name := range []string{"name", "name__ic", "name__n", "name__empty", "site_id", "tag", "q"} {
+		assert.True(t, valid[name], name)
+	}
+	assert.False(t, valid["limit"])
+	assert.False(t, valid["offset"])
+
+	_, errs := devicesFilters.validateName("cf_owner", "filter.0.name")
+	assert.Empty(t, errs)
+	_, errs = devicesFilters.validateName("tags", "filter.0.name")
+	assert.Empty(t, errs)
+	_, errs = devicesFilters.validateName("sites", "filter.0.name")
+	if assert.Len(t, errs, 1) {
+		assert.Contains(t, errs[0].Error(), `"sites" is not a supported filter, valid filters are `)
+		assert.Contains(t, errs[0].Error(), ", site, site_group, site_group_id, site_id, ")
+	}
+	_, errs = objectsFilters.validateName("anything__ic", "filter.0.name")
+	assert.Empty(t, errs)
+	_, errs = objectsFilters.validateName("limit", "filter.0.name")
+	assert.Len(t, errs, 1)
+}
+
+func TestDataSourceFiltersPlanTime(t *testing.T) {
+	for dataSourceType, r := range Provider().DataSourcesMap {
+		filterSchema, ok := r.Schema["filter"]
+		if !ok || filterSchema.Elem.(*schema.Resource).Schema["name"].ValidateFunc == nil {
+			continue
+		}
+		config := map[string]interface{}{
+			"filter": []interface{}{map[string]interface{}{"name": "no_such_filter", "value": "x"}},
+		}
+		if _, ok := r.Schema["path"]; ok {
+			config["filter"] = []interface{}{map[string]interface{}{"name": "limit", "value": "1"}}
+			config["path"] = "dcim/devices"
+		}
+		diags := r.Validate(terraform.NewResourceConfigRaw(config))
+		assert.True(t, diags.HasError(), dataSourceType)
+	}
+}
+
+func TestDataSourceFiltersQuery(t *testing.T) {
+	r := dataSourceNetboxDevices()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"filter": []interface{}{
+			map[string]interface{}{"name": "site", "value": "dc1"},
+			map[string]interface{}{"name": "site", "value": "dc2"},
+			map[string]interface{}{"name": "name__ic", "value": "sw"},
+			map[string]interface{}{"name": "cf_owner", "value": "network"},
+			map[string]interface{}{"name": "tags", "value": "a,b"},
+		},
+	})
+	query := devicesFilters.query(d)
+	assert.ElementsMatch(t, []string{"dc1", "dc2"}, query["site"])
+	assert.ElementsMatch(t, []string{"a", "b"}, query["tag"])
+	delete(query, "site")
+	delete(query, "tag")
+	assert.Equal(t, url.Values{"name__ic": {"sw"}, "cf_owner": {"network"}}, query)
+}
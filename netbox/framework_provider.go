@@ -0,0 +1,295 @@
+package netbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ProviderServerFactory returns a function creating the server of the
+// provider. The server combines the provider returned by Provider, built on
+// terraform-plugin-sdk/v2, with a provider built on terraform-plugin-framework
+// for features the SDK does not support. Both providers share the provider
+// configuration and the client, so resources and data sources can move to the
+// framework one at a time.
+func ProviderServerFactory(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	return muxProviderServerFactory(ctx, Provider())
+}
+
+func muxProviderServerFactory(ctx context.Context, sdkProvider *schema.Provider) (func() tfprotov5.ProviderServer, error) {
+	muxServer, err := tf5muxserver.NewMuxServer(ctx,
+		providerserver.NewProtocol5(newFrameworkProvider(sdkProvider)),
+		// The provider schema of the last server is served. The framework
+		// does not support the maximum number of items of blocks, so the SDK
+		// provider comes last.
+		sdkProvider.GRPCProvider,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return muxServer.ProviderServer, nil
+}
+
+// frameworkProvider is the part of the provider built on
+// terraform-plugin-framework. Its schema and configuration are the ones of
+// the SDK provider, as Terraform configures both with the same provider
+// block.
+type frameworkProvider struct {
+	sdkProvider *schema.Provider
+}
+
+var _ provider.Provider = &frameworkProvider{}
+
+func newFrameworkProvider(sdkProvider *schema.Provider) provider.Provider {
+	return &frameworkProvider{sdkProvider: sdkProvider}
+}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "netbox"
+}
+
+// Schema returns the schema of the SDK provider, as the schemas of muxed
+// providers must be equal.
+func (p *frameworkProvider) Schema(ctx context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	sdkSchema, err := p.sdkProvider.GRPCProvider().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading the provider schema", err.Error())
+		return
+	}
+	block := sdkSchema.Provider.Block
+	attributes, blocks, err := frameworkSchemaBlock(block)
+	if err != nil {
+		resp.Diagnostics.AddError("Error converting the provider schema", err.Error())
+		return
+	}
+	resp.Schema = fwschema.Schema{
+		Attributes:          attributes,
+		Blocks:              blocks,
+		Description:         frameworkDescription(block.Description, block.DescriptionKind, false),
+		MarkdownDescription: frameworkDescription(block.Description, block.DescriptionKind, true),
+		DeprecationMessage:  frameworkDeprecationMessage(block.Deprecated),
+	}
+}
+
+// Configure passes the SDK provider to the resources and data sources of the
+// framework provider, so that they use its client. The SDK provider is
+// configured after the framework provider, see frameworkProviderData.
+func (p *frameworkProvider) Configure(_ context.Context, _ provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	data := &frameworkProviderData{sdkProvider: p.sdkProvider}
+	resp.ResourceData = data
+	resp.DataSourceData = data
+}
+
+// frameworkProviderData is the provider data of the resources and data
+// sources of the framework provider.
+type frameworkProviderData struct {
+	sdkProvider *schema.Provider
+}
+
+// state returns the client of the SDK provider. Terraform configures the
+// provider before it reads or changes anything, so it is available from then
+// on.
+func (d *frameworkProviderData) state() (*providerState, error) {
+	state, ok := d.sdkProvider.Meta().(*providerState)
+	if !ok {
+		return nil, errors.New("the provider is not configured")
+	}
+	return state, nil
+}
+
+func (p *frameworkProvider) Resources(context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *frameworkProvider) DataSources(context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+// frameworkSchemaBlock returns the attributes and nested blocks of the
+// framework schema equivalent to the protocol schema block.
+func frameworkSchemaBlock(block *tfprotov5.SchemaBlock) (map[string]fwschema.Attribute, map[string]fwschema.Block, error) {
+	attributes := map[string]fwschema.Attribute{}
+	for _, a := range block.Attributes {
+		attribute, err := frameworkSchemaAttribute(a)
+		if err != nil {
+			return nil, nil, fmt.Errorf("attribute %s: %w", a.Name, err)
+		}
+		attributes[a.Name] = attribute
+	}
+
+	blocks := map[string]fwschema.Block{}
+	for _, b := range block.BlockTypes {
+		nestedAttributes, nestedBlocks, err := frameworkSchemaBlock(b.Block)
+		if err != nil {
+			return nil, nil, fmt.Errorf("block %s: %w", b.TypeName, err)
+		}
+		nested := fwschema.NestedBlockObject{
+			Attributes: nestedAttributes,
+			Blocks:     nestedBlocks,
+		}
+		description := frameworkDescription(b.Block.Description, b.Block.DescriptionKind, false)
+		markdownDescription := frameworkDescription(b.Block.Description, b.Block.DescriptionKind, true)
+		deprecationMessage := frameworkDeprecationMessage(b.Block.Deprecated)
+
+		switch b.Nesting {
+		case tfprotov5.SchemaNestedBlockNestingModeList:
+			blocks[b.TypeName] = fwschema.ListNestedBlock{
+				NestedObject:        nested,
+				Description:         description,
+				MarkdownDescription: markdownDescription,
+				DeprecationMessage:  deprecationMessage,
+			}
+		case tfprotov5.SchemaNestedBlockNestingModeSet:
+			blocks[b.TypeName] = fwschema.SetNestedBlock{
+				NestedObject:        nested,
+				Description:         description,
+				MarkdownDescription: markdownDescription,
+				DeprecationMessage:  deprecationMessage,
+			}
+		case tfprotov5.SchemaNestedBlockNestingModeSingle:
+			blocks[b.TypeName] = fwschema.SingleNestedBlock{
+				Attributes:          nestedAttributes,
+				Blocks:              nestedBlocks,
+				Description:         description,
+				MarkdownDescription: markdownDescription,
+				DeprecationMessage:  deprecationMessage,
+			}
+		default:
+			return nil, nil, fmt.Errorf("block %s: unsupported nesting mode %s", b.TypeName, b.Nesting)
+		}
+	}
+	return attributes, blocks, nil
+}
+
+func frameworkSchemaAttribute(a *tfprotov5.SchemaAttribute) (fwschema.Attribute, error) {
+	description := frameworkDescription(a.Description, a.DescriptionKind, false)
+	markdownDescription := frameworkDescription(a.Description, a.DescriptionKind, true)
+	deprecationMessage := frameworkDeprecationMessage(a.Deprecated)
+
+	switch {
+	case a.Type.Is(tftypes.String):
+		return fwschema.StringAttribute{
+			Required:            a.Required,
+			Optional:            a.Optional,
+			Sensitive:           a.Sensitive,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+	case a.Type.Is(tftypes.Bool):
+		return fwschema.BoolAttribute{
+			Required:            a.Required,
+			Optional:            a.Optional,
+			Sensitive:           a.Sensitive,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+	case a.Type.Is(tftypes.Number):
+		return fwschema.NumberAttribute{
+			Required:            a.Required,
+			Optional:            a.Optional,
+			Sensitive:           a.Sensitive,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+	case a.Type.Is(tftypes.List{}):
+		elemType, err := frameworkAttrType(a.Type.(tftypes.List).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return fwschema.ListAttribute{
+			ElementType:         elemType,
+			Required:            a.Required,
+			Optional:            a.Optional,
+			Sensitive:           a.Sensitive,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+	case a.Type.Is(tftypes.Set{}):
+		elemType, err := frameworkAttrType(a.Type.(tftypes.Set).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return fwschema.SetAttribute{
+			ElementType:         elemType,
+			Required:            a.Required,
+			Optional:            a.Optional,
+			Sensitive:           a.Sensitive,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+	case a.Type.Is(tftypes.Map{}):
+		elemType, err := frameworkAttrType(a.Type.(tftypes.Map).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return fwschema.MapAttribute{
+			ElementType:         elemType,
+			Required:            a.Required,
+			Optional:            a.Optional,
+			Sensitive:           a.Sensitive,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", a.Type)
+}
+
+// frameworkAttrType returns the framework type of values of the protocol
+// type t.
+func frameworkAttrType(t tftypes.Type) (attr.Type, error) {
+	switch {
+	case t.Is(tftypes.String):
+		return types.StringType, nil
+	case t.Is(tftypes.Bool):
+		return types.BoolType, nil
+	case t.Is(tftypes.Number):
+		return types.NumberType, nil
+	case t.Is(tftypes.List{}):
+		elemType, err := frameworkAttrType(t.(tftypes.List).ElementType)
+		return types.ListType{ElemType: elemType}, err
+	case t.Is(tftypes.Set{}):
+		elemType, err := frameworkAttrType(t.(tftypes.Set).ElementType)
+		return types.SetType{ElemType: elemType}, err
+	case t.Is(tftypes.Map{}):
+		elemType, err := frameworkAttrType(t.(tftypes.Map).ElementType)
+		return types.MapType{ElemType: elemType}, err
+	}
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+// frameworkDescription returns the description as the framework expects it,
+// either as plain text or as markdown.
+func frameworkDescription(description string, kind tfprotov5.StringKind, markdown bool) string {
+	if (kind == tfprotov5.StringKindMarkdown) != markdown {
+		return ""
+	}
+	return description
+}
+
+// frameworkDeprecationMessage returns a deprecation message for deprecated
+// parts of the schema. Terraform only learns whether they are deprecated,
+// not the message.
+func frameworkDeprecationMessage(deprecated bool) string {
+	if !deprecated {
+		return ""
+	}
+	return "Deprecated"
+}
@@ -0,0 +1,104 @@
+package netbox
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProviderServerSchema(t *testing.T) {
+	for _, serverURL := range []string{"", "https://netbox.example.com"} {
+		t.Setenv("NETBOX_SERVER_URL", serverURL)
+
+		serverFactory, err := ProviderServerFactory(context.Background())
+		assert.NoError(t, err)
+		resp, err := serverFactory().GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+		assert.NoError(t, err)
+		// The mux fails if the schemas of the providers differ
+		assert.Empty(t, resp.Diagnostics)
+		assert.Contains(t, resp.ResourceSchemas, "netbox_site")
+		for _, block := range resp.Provider.Block.BlockTypes {
+			if block.TypeName == "ignore_tags" {
+				assert.Equal(t, int64(1), block.MaxItems)
+			}
+		}
+	}
+}
+
+func TestProviderServerConfigure(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"netbox-version": "4.0.10"}`))
+	}))
+	defer ts.Close()
+
+	sdkProvider := Provider()
+	serverFactory, err := muxProviderServerFactory(context.Background(), sdkProvider)
+	assert.NoError(t, err)
+	server := serverFactory()
+	schemaResp, err := server.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	assert.NoError(t, err)
+
+	configType := schemaResp.Provider.ValueType().(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range configType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	values["server_url"] = tftypes.NewValue(tftypes.String, ts.URL)
+	values["api_token"] = tftypes.NewValue(tftypes.String, "0123456789abcdef0123456789abcdef01234567")
+	values["ignore_tags"] = tftypes.NewValue(configType.AttributeTypes["ignore_tags"], []tftypes.Value{})
+	config, err := tfprotov5.NewDynamicValue(configType, tftypes.NewValue(configType, values))
+	assert.NoError(t, err)
+
+	resp, err := server.ConfigureProvider(context.Background(), &tfprotov5.ConfigureProviderRequest{Config: &config})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Diagnostics)
+
+	// Resources of the framework provider use the client of the SDK provider
+	var configureResp provider.ConfigureResponse
+	newFrameworkProvider(sdkProvider).Configure(context.Background(), provider.ConfigureRequest{}, &configureResp)
+	assert.Equal(t, configureResp.ResourceData, configureResp.DataSourceData)
+	state, err := configureResp.ResourceData.(*frameworkProviderData).state()
+	assert.NoError(t, err)
+	assert.Same(t, sdkProvider.Meta(), state)
+
+	_, err = (&frameworkProviderData{sdkProvider: Provider()}).state()
+	assert.EqualError(t, err, "the provider is not configured")
+}
+
+func TestUnitProviderServer(t *testing.T) {
+	testFakeNetbox(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: map[string]func() (tfprotov5.ProviderServer, error){
+			"netbox": func() (tfprotov5.ProviderServer, error) {
+				serverFactory, err := ProviderServerFactory(context.Background())
+				if err != nil {
+					return nil, err
+				}
+				return serverFactory(), nil
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "netbox" {
+  ignore_tags {
+    names = ["discovered"]
+  }
+}
+
+resource "netbox_tenant" "test" {
+  name = "test"
+}`,
+				Check: resource.TestCheckResourceAttr("netbox_tenant.test", "slug", "test"),
+			},
+		},
+	})
+}
@@ -0,0 +1,215 @@
+package netbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// httpLogSubsystem is the terraform-plugin-log subsystem all HTTP traffic to
+// Netbox is logged in. Its level can be set separately via the
+// TF_LOG_PROVIDER_NETBOX_HTTP environment variable.
+const httpLogSubsystem = "netbox_http"
+
+const redactedValue = "***"
+
+// alwaysRedactedHeaders are headers whose values never appear in the logs.
+var alwaysRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// redactedBodyFields maps URL path fragments to JSON fields whose values are
+// redacted in request and response bodies of matching requests. The empty
+// fragment matches every request.
+var redactedBodyFields = map[string][]string{
+	"":              {"password"},
+	"/users/tokens": {"key"},
+}
+
+// newHTTPLogContext returns a context containing the netbox_http subsystem
+// logger based on the provider logger in ctx.
+func newHTTPLogContext(ctx context.Context) context.Context {
+	return tflog.NewSubsystem(ctx, httpLogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_NETBOX_HTTP"))
+}
+
+// requestLogContext returns a context containing the netbox_http subsystem
+// logger for a request. The logger is derived from the provider logger of the
+// request context, i.e. the one of the operation sending the request. If the
+// request context has no provider logger, logCtx is returned.
+func requestLogContext(r *http.Request, logCtx context.Context) context.Context {
+	// NewSubsystem returns the context unchanged if it has no provider logger
+	if ctx := newHTTPLogContext(r.Context()); ctx != r.Context() {
+		return ctx
+	}
+	return logCtx
+}
+
+// httpLoggingTransport is a transport that logs all requests to Netbox and
+// their responses. Secrets are redacted before anything is logged.
+type httpLoggingTransport struct {
+	original http.RoundTripper
+	// logCtx contains the netbox_http subsystem logger used for requests
+	// whose context has no provider logger.
+	logCtx context.Context
+	// redactedHeaders are the canonical names of the headers to redact.
+	redactedHeaders map[string]bool
+}
+
+func newHTTPLoggingTransport(logCtx context.Context, original http.RoundTripper, extraRedactedHeaders []string) httpLoggingTransport {
+	redactedHeaders := map[string]bool{}
+	for _, header := range append(alwaysRedactedHeaders, extraRedactedHeaders...) {
+		redactedHeaders[http.CanonicalHeaderKey(header)] = true
+	}
+	return httpLoggingTransport{
+		original:        original,
+		logCtx:          logCtx,
+		redactedHeaders: redactedHeaders,
+	}
+}
+
+// RoundTrip logs the request, sends it and logs the response.
+func (t httpLoggingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	logCtx := requestLogContext(r, t.logCtx)
+	fields := map[string]interface{}{
+		"method": r.Method,
+		"path":   r.URL.RequestURI(),
+	}
+
+	tflog.SubsystemDebug(logCtx, httpLogSubsystem, "Sending HTTP request to Netbox", fields, map[string]interface{}{
+		"headers": t.redactHeaders(r.Header),
+	})
+	if r.Body != nil && r.Body != http.NoBody {
+		getBody := r.GetBody
+		if getBody == nil {
+			requestBody, err := io.ReadAll(r.Body)
+			r.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			r.Body = io.NopCloser(bytes.NewReader(requestBody))
+			getBody = func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(requestBody)), nil
+			}
+		}
+		tflog.SubsystemTrace(logCtx, httpLogSubsystem, "HTTP request body", fields, map[string]interface{}{
+			"body": lazyBody{path: r.URL.Path, read: func() ([]byte, error) {
+				body, err := getBody()
+				if err != nil {
+					return nil, err
+				}
+				defer body.Close()
+				return io.ReadAll(body)
+			}},
+		})
+	}
+
+	start := time.Now()
+	resp, err := t.original.RoundTrip(r)
+	fields["duration_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		tflog.SubsystemDebug(logCtx, httpLogSubsystem, "HTTP request to Netbox failed", fields, map[string]interface{}{
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+
+	fields["status"] = resp.StatusCode
+	fields["request_id"] = resp.Header.Get("X-Request-Id")
+
+	tflog.SubsystemDebug(logCtx, httpLogSubsystem, "Received HTTP response from Netbox", fields, map[string]interface{}{
+		"headers": t.redactHeaders(resp.Header),
+	})
+	responseBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+	if len(responseBody) > 0 {
+		tflog.SubsystemTrace(logCtx, httpLogSubsystem, "HTTP response body", fields, map[string]interface{}{
+			"body": lazyBody{path: r.URL.Path, read: func() ([]byte, error) { return responseBody, nil }},
+		})
+	}
+
+	return resp, nil
+}
+
+// lazyBody is a log field value of a body, which is only read and redacted
+// if the message is actually logged, i.e. trace logging is enabled.
+type lazyBody struct {
+	path string
+	read func() ([]byte, error)
+}
+
+func (b lazyBody) String() string {
+	body, err := b.read()
+	if err != nil {
+		return err.Error()
+	}
+	return redactBody(b.path, body)
+}
+
+func (b lazyBody) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.String())
+}
+
+func (t httpLoggingTransport) redactHeaders(headers http.Header) map[string]string {
+	redacted := make(map[string]string, len(headers))
+	for key, values := range headers {
+		if t.redactedHeaders[http.CanonicalHeaderKey(key)] {
+			redacted[key] = redactedValue
+		} else {
+			redacted[key] = strings.Join(values, ", ")
+		}
+	}
+	return redacted
+}
+
+// redactBody returns the body with all secret JSON fields redacted. Bodies
+// that are not JSON are returned unchanged.
+func redactBody(path string, body []byte) string {
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return string(body)
+	}
+
+	keys := map[string]bool{}
+	for fragment, fields := range redactedBodyFields {
+		if strings.Contains(path, fragment) {
+			for _, field := range fields {
+				keys[field] = true
+			}
+		}
+	}
+
+	redacted, err := json.Marshal(redactJSONFields(decoded, keys))
+	if err != nil {
+		return string(body)
+	}
+	return string(redacted)
+}
+
+// redactJSONFields replaces the values of all fields with one of the given
+// keys in the decoded JSON value, at any nesting depth.
+func redactJSONFields(value interface{}, keys map[string]bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, fieldValue := range v {
+			if keys[key] && fieldValue != nil {
+				v[key] = redactedValue
+			} else {
+				v[key] = redactJSONFields(fieldValue, keys)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactJSONFields(item, keys)
+		}
+	}
+	return value
+}
@@ -0,0 +1,152 @@
+package netbox
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fbreckle/go-netbox/netbox/client/users"
+	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPLoggingRedactsSecrets(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "4f8a1c2e")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": 1, "username": "jdoe", "password": "hashed-secret"}`))
+	}))
+	defer ts.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL,
+		Headers: map[string]interface{}{
+			"X-Proxy-Secret": "proxy-secret",
+		},
+	}
+
+	client, err := config.ClientWithContext(ctx)
+	assert.NoError(t, err)
+
+	data := models.WritableUser{Username: strToPtr("jdoe"), Password: strToPtr("plain-secret")}
+	_, err = client.Users.UsersUsersCreate(users.NewUsersUsersCreateParams().WithData(&data), nil)
+	assert.NoError(t, err)
+
+	logs := output.String()
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	assert.NoError(t, err)
+
+	var response map[string]interface{}
+	for _, entry := range entries {
+		if entry["@message"] == "Received HTTP response from Netbox" {
+			response = entry
+		}
+	}
+	if assert.NotNil(t, response) {
+		assert.Equal(t, "POST", response["method"])
+		assert.Equal(t, "/api/users/users/", response["path"])
+		assert.Equal(t, float64(http.StatusCreated), response["status"])
+		assert.Equal(t, "4f8a1c2e", response["request_id"])
+		assert.Equal(t, "provider."+httpLogSubsystem, response["@module"])
+		assert.Contains(t, response, "duration_ms")
+	}
+
+	assert.NotContains(t, logs, "07b12b765127747e4afd56cb531b7bf9c61f3c30")
+	assert.NotContains(t, logs, "plain-secret")
+	assert.NotContains(t, logs, "hashed-secret")
+	assert.NotContains(t, logs, "proxy-secret")
+	assert.Contains(t, logs, "jdoe")
+}
+
+func TestHTTPLoggingBodiesOnlyWithTrace(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_NETBOX_HTTP", "DEBUG")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer ts.Close()
+
+	var output bytes.Buffer
+	transport := newHTTPLoggingTransport(newHTTPLogContext(tflogtest.RootLogger(context.Background(), &output)), http.DefaultTransport, nil)
+
+	var reads int
+	r, err := http.NewRequest(http.MethodPost, ts.URL+"/api/dcim/sites/", strings.NewReader(`{"name": "test"}`))
+	assert.NoError(t, err)
+	r.GetBody = func() (io.ReadCloser, error) {
+		reads++
+		return io.NopCloser(strings.NewReader(`{"name": "test"}`)), nil
+	}
+
+	resp, err := transport.RoundTrip(r)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, 0, reads)
+	assert.Contains(t, output.String(), "Sending HTTP request to Netbox")
+	assert.NotContains(t, output.String(), "HTTP request body")
+	assert.NotContains(t, output.String(), "HTTP response body")
+}
+
+func TestHTTPLoggingUsesRequestLogger(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer ts.Close()
+
+	var configureOutput, requestOutput bytes.Buffer
+	transport := newHTTPLoggingTransport(newHTTPLogContext(tflogtest.RootLogger(context.Background(), &configureOutput)), http.DefaultTransport, nil)
+
+	r, err := http.NewRequestWithContext(tflogtest.RootLogger(context.Background(), &requestOutput), http.MethodGet, ts.URL+"/api/dcim/sites/1/", nil)
+	assert.NoError(t, err)
+
+	resp, err := transport.RoundTrip(r)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Empty(t, configureOutput.String())
+	entries, err := tflogtest.MultilineJSONDecode(&requestOutput)
+	assert.NoError(t, err)
+	if assert.NotEmpty(t, entries) {
+		assert.Equal(t, "provider."+httpLogSubsystem, entries[0]["@module"])
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	for _, tt := range []struct {
+		path     string
+		body     string
+		expected string
+	}{
+		{
+			path:     "/api/users/tokens/",
+			body:     `{"results": [{"id": 1, "key": "0123456789abcdef", "user": {"id": 2}}]}`,
+			expected: `{"results":[{"id":1,"key":"***","user":{"id":2}}]}`,
+		},
+		{
+			path:     "/api/users/users/1/",
+			body:     `{"username": "jdoe", "password": "secret"}`,
+			expected: `{"password":"***","username":"jdoe"}`,
+		},
+		{
+			path:     "/api/extras/config-contexts/",
+			body:     `{"data": {"key": "value"}}`,
+			expected: `{"data":{"key":"value"}}`,
+		},
+		{
+			path:     "/api/dcim/sites/",
+			body:     `<html>Bad Gateway</html>`,
+			expected: `<html>Bad Gateway</html>`,
+		},
+	} {
+		assert.Equal(t, tt.expected, redactBody(tt.path, []byte(tt.body)), tt.path)
+	}
+}
@@ -0,0 +1,148 @@
+package netbox
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// ignoredTags matches the tags set by other tools than Terraform, e.g.
+// discovery jobs. Ignored tags are left out when reading resources and kept
+// when updating them.
+type ignoredTags struct {
+	names        []string
+	namePrefixes []string
+}
+
+func (i ignoredTags) isEmpty() bool {
+	return len(i.names) == 0 && len(i.namePrefixes) == 0
+}
+
+func (i ignoredTags) matches(name string) bool {
+	if slices.Contains(i.names, name) {
+		return true
+	}
+	for _, prefix := range i.namePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// preserveIgnoredTagsTransport is a transport that keeps ignored tags on
+// objects when they are updated. Netbox replaces all tags of an object with
+// the tags sent in an update, so the ignored tags the object currently has
+// are retrieved first and added to the update.
+//
+// Custom fields need no such handling, as Netbox merges the custom fields
+// sent in an update with the existing ones.
+type preserveIgnoredTagsTransport struct {
+	original http.RoundTripper
+	ignored  ignoredTags
+}
+
+// RoundTrip adds the ignored tags of the object to PUT and PATCH requests
+// setting tags.
+func (t preserveIgnoredTagsTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if (r.Method != http.MethodPut && r.Method != http.MethodPatch) || r.Body == nil || r.Body == http.NoBody {
+		return t.original.RoundTrip(r)
+	}
+
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	// Bulk updates and requests without tags are sent unchanged
+	var data map[string]interface{}
+	if err := json.Unmarshal(body, &data); err != nil || data["tags"] == nil {
+		return t.original.RoundTrip(withBody(r, body))
+	}
+	tags, ok := data["tags"].([]interface{})
+	if !ok {
+		return t.original.RoundTrip(withBody(r, body))
+	}
+
+	currentTags, err := t.currentTags(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tag := range currentTags {
+		if !t.ignored.matches(tag.Name) || containsTagName(tags, tag.Name) {
+			continue
+		}
+		tags = append(tags, map[string]interface{}{
+			"name": tag.Name,
+			"slug": tag.Slug,
+		})
+	}
+	data["tags"] = tags
+
+	body, err = json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return t.original.RoundTrip(withBody(r, body))
+}
+
+type currentTag struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// currentTags retrieves the tags the object updated by r currently has. If
+// the object cannot be retrieved, no tags are returned and the update itself
+// reports the problem.
+func (t preserveIgnoredTagsTransport) currentTags(r *http.Request) ([]currentTag, error) {
+	get, err := http.NewRequestWithContext(r.Context(), http.MethodGet, r.URL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	get.Header = r.Header.Clone()
+	get.Header.Del("Content-Type")
+	get.Header.Del("Content-Length")
+
+	resp, err := t.original.RoundTrip(get)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil
+	}
+
+	var object struct {
+		Tags []currentTag `json:"tags"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&object); err != nil {
+		return nil, nil
+	}
+	return object.Tags, nil
+}
+
+func containsTagName(tags []interface{}, name string) bool {
+	for _, tag := range tags {
+		if t, ok := tag.(map[string]interface{}); ok && t["name"] == name {
+			return true
+		}
+	}
+	return false
+}
+
+// withBody returns a copy of r sending the given body.
+func withBody(r *http.Request, body []byte) *http.Request {
+	r = r.Clone(r.Context())
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	r.ContentLength = int64(len(body))
+	return r
+}
@@ -0,0 +1,99 @@
+package netbox
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fbreckle/go-netbox/netbox/client/dcim"
+	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIgnoredTagsMatches(t *testing.T) {
+	ignored := ignoredTags{
+		names:        []string{"discovered"},
+		namePrefixes: []string{"scan-"},
+	}
+
+	assert.True(t, ignored.matches("discovered"))
+	assert.True(t, ignored.matches("scan-2024"))
+	assert.False(t, ignored.matches("discovered-by-hand"))
+	assert.False(t, ignored.matches("terraform"))
+	assert.False(t, ignoredTags{}.matches("discovered"))
+}
+
+func TestPreserveIgnoredTags(t *testing.T) {
+	var updateBody map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/dcim/devices/1/", r.URL.Path)
+		assert.Equal(t, "Token 07b12b765127747e4afd56cb531b7bf9c61f3c30", r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"id": 1, "tags": [
+				{"id": 1, "name": "terraform", "slug": "terraform"},
+				{"id": 2, "name": "discovered", "slug": "discovered"},
+				{"id": 3, "name": "scan-2024", "slug": "scan-2024"},
+				{"id": 4, "name": "removed", "slug": "removed"}
+			]}`))
+		case http.MethodPatch:
+			body, _ := io.ReadAll(r.Body)
+			assert.NoError(t, json.Unmarshal(body, &updateBody))
+			w.Write([]byte(`{"id": 1}`))
+		}
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:              "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL:             ts.URL,
+		IgnoreTagNames:        []string{"discovered"},
+		IgnoreTagNamePrefixes: []string{"scan-"},
+	}
+
+	client, err := config.Client()
+	assert.NoError(t, err)
+
+	data := models.WritableDeviceWithConfigContext{
+		Tags: []*models.NestedTag{{Name: strToPtr("terraform"), Slug: strToPtr("terraform")}},
+	}
+	params := dcim.NewDcimDevicesPartialUpdateParams().WithID(1).WithData(&data)
+	_, err = client.Dcim.DcimDevicesPartialUpdate(params, nil)
+	assert.NoError(t, err)
+
+	var names []string
+	for _, tag := range updateBody["tags"].([]interface{}) {
+		names = append(names, tag.(map[string]interface{})["name"].(string))
+	}
+	assert.ElementsMatch(t, []string{"terraform", "discovered", "scan-2024"}, names)
+}
+
+func TestPreserveIgnoredTagsWithoutTags(t *testing.T) {
+	var methods []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:       "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL:      ts.URL,
+		IgnoreTagNames: []string{"discovered"},
+	}
+
+	client, err := config.Client()
+	assert.NoError(t, err)
+
+	data := models.WritableDeviceWithConfigContext{Name: strToPtr("device1")}
+	params := dcim.NewDcimDevicesPartialUpdateParams().WithID(1).WithData(&data)
+	_, err = client.Dcim.DcimDevicesPartialUpdate(params, nil, onlyFields("name"))
+	assert.NoError(t, err)
+
+	// The object is only read if the update sets tags
+	assert.Equal(t, []string{http.MethodPatch}, methods)
+}
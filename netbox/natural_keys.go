@@ -0,0 +1,348 @@
+package netbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fbreckle/go-netbox/netbox/client"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// naturalKey describes how objects of a resource are found by a key other
+// than their ID when they are imported.
+type naturalKey struct {
+	// endpoint is the list endpoint of the objects, relative to the API root.
+	endpoint string
+	// filters converts a key into the query filters identifying the object.
+	// If it is nil, objects can only be imported by ID or by `field:value`.
+	filters func(ctx context.Context, state *providerState, key string) (url.Values, error)
+}
+
+// byField returns key filters for keys holding the value of the given field,
+// e.g. the name of an object.
+func byField(field string) func(context.Context, *providerState, string) (url.Values, error) {
+	return func(_ context.Context, _ *providerState, key string) (url.Values, error) {
+		return url.Values{field: {key}}, nil
+	}
+}
+
+// byParentAndName returns key filters for keys of the form `parent/name` or
+// `name`, where parent is the value of the given filter, e.g. the slug of the
+// site of a device.
+func byParentAndName(parentFilter string) func(context.Context, *providerState, string) (url.Values, error) {
+	return func(_ context.Context, _ *providerState, key string) (url.Values, error) {
+		parent, name, found := strings.Cut(key, "/")
+		if !found {
+			return url.Values{"name": {key}}, nil
+		}
+		return url.Values{parentFilter: {parent}, "name": {name}}, nil
+	}
+}
+
+// byPrefix returns key filters for keys of the form `vrf/prefix` or
+// `prefix`. Prefixes without VRF are looked up in the global table.
+func byPrefix(ctx context.Context, state *providerState, key string) (url.Values, error) {
+	prefix, vrf := key, ""
+	if strings.Count(key, "/") > 1 {
+		vrf, prefix, _ = strings.Cut(key, "/")
+	}
+	return withVrfFilter(ctx, state, url.Values{"prefix": {prefix}}, vrf)
+}
+
+// byAddress returns key filters for keys of the form `address@vrf` or
+// `address`, where address is the value of the given field. Addresses
+// without VRF are looked up in the global table.
+func byAddress(field string) func(context.Context, *providerState, string) (url.Values, error) {
+	return func(ctx context.Context, state *providerState, key string) (url.Values, error) {
+		address, vrf, _ := strings.Cut(key, "@")
+		return withVrfFilter(ctx, state, url.Values{field: {address}}, vrf)
+	}
+}
+
+func withVrfFilter(ctx context.Context, state *providerState, filters url.Values, vrf string) (url.Values, error) {
+	if vrf == "" {
+		filters.Set("vrf_id", "null")
+		return filters, nil
+	}
+	vrfID, err := state.findObjectID(ctx, "VRF", "/ipam/vrfs/", url.Values{"name": {vrf}})
+	if err != nil {
+		return nil, err
+	}
+	filters.Set("vrf_id", strconv.FormatInt(vrfID, 10))
+	return filters, nil
+}
+
+// naturalKeys maps resource types to the keys their objects can be imported
+// by, in addition to their IDs. Every resource can also be imported by
+// `field:value`, e.g. `slug:dc1`, where field is a filter of the list
+// endpoint.
+var naturalKeys = map[string]naturalKey{
+	"netbox_aggregate":                  {endpoint: "/ipam/aggregates/", filters: byField("prefix")},
+	"netbox_asn":                        {endpoint: "/ipam/asns/", filters: byField("asn")},
+	"netbox_available_ip_address":       {endpoint: "/ipam/ip-addresses/", filters: byAddress("address")},
+	"netbox_branch":                     {endpoint: "/plugins/branching/branches/", filters: byField("name")},
+	"netbox_cable":                      {endpoint: "/dcim/cables/", filters: byField("label")},
+	"netbox_circuit":                    {endpoint: "/circuits/circuits/", filters: byField("cid")},
+	"netbox_circuit_provider":           {endpoint: "/circuits/providers/", filters: byField("name")},
+	"netbox_circuit_termination":        {endpoint: "/circuits/circuit-terminations/"},
+	"netbox_circuit_type":               {endpoint: "/circuits/circuit-types/", filters: byField("name")},
+	"netbox_cluster":                    {endpoint: "/virtualization/clusters/", filters: byField("name")},
+	"netbox_cluster_group":              {endpoint: "/virtualization/cluster-groups/", filters: byField("name")},
+	"netbox_cluster_type":               {endpoint: "/virtualization/cluster-types/", filters: byField("name")},
+	"netbox_config_context":             {endpoint: "/extras/config-contexts/", filters: byField("name")},
+	"netbox_config_template":            {endpoint: "/extras/config-templates/", filters: byField("name")},
+	"netbox_contact":                    {endpoint: "/tenancy/contacts/", filters: byField("name")},
+	"netbox_contact_assignment":         {endpoint: "/tenancy/contact-assignments/"},
+	"netbox_contact_group":              {endpoint: "/tenancy/contact-groups/", filters: byField("name")},
+	"netbox_contact_role":               {endpoint: "/tenancy/contact-roles/", filters: byField("name")},
+	"netbox_custom_field":               {endpoint: "/extras/custom-fields/", filters: byField("name")},
+	"netbox_custom_field_choice_set":    {endpoint: "/extras/custom-field-choice-sets/", filters: byField("name")},
+	"netbox_device":                     {endpoint: "/dcim/devices/", filters: byParentAndName("site")},
+	"netbox_device_console_port":        {endpoint: "/dcim/console-ports/", filters: byParentAndName("device")},
+	"netbox_device_console_server_port": {endpoint: "/dcim/console-server-ports/", filters: byParentAndName("device")},
+	"netbox_device_front_port":          {endpoint: "/dcim/front-ports/", filters: byParentAndName("device")},
+	"netbox_device_interface":           {endpoint: "/dcim/interfaces/", filters: byParentAndName("device")},
+	"netbox_device_module_bay":          {endpoint: "/dcim/module-bays/", filters: byParentAndName("device")},
+	"netbox_device_power_outlet":        {endpoint: "/dcim/power-outlets/", filters: byParentAndName("device")},
+	"netbox_device_power_port":          {endpoint: "/dcim/power-ports/", filters: byParentAndName("device")},
+	"netbox_device_primary_ip":          {endpoint: "/dcim/devices/", filters: byParentAndName("site")},
+	"netbox_device_rear_port":           {endpoint: "/dcim/rear-ports/", filters: byParentAndName("device")},
+	"netbox_device_role":                {endpoint: "/dcim/device-roles/", filters: byField("name")},
+	"netbox_device_type":                {endpoint: "/dcim/device-types/", filters: byField("model")},
+	"netbox_event_rule":                 {endpoint: "/extras/event-rules/", filters: byField("name")},
+	"netbox_group":                      {endpoint: "/users/groups/", filters: byField("name")},
+	"netbox_interface":                  {endpoint: "/virtualization/interfaces/", filters: byParentAndName("virtual_machine")},
+	"netbox_interface_template":         {endpoint: "/dcim/interface-templates/", filters: byField("name")},
+	"netbox_inventory_item":             {endpoint: "/dcim/inventory-items/", filters: byParentAndName("device")},
+	"netbox_inventory_item_role":        {endpoint: "/dcim/inventory-item-roles/", filters: byField("name")},
+	"netbox_ip_address":                 {endpoint: "/ipam/ip-addresses/", filters: byAddress("address")},
+	"netbox_ip_range":                   {endpoint: "/ipam/ip-ranges/", filters: byAddress("start_address")},
+	"netbox_ipam_role":                  {endpoint: "/ipam/roles/", filters: byField("name")},
+	"netbox_location":                   {endpoint: "/dcim/locations/", filters: byParentAndName("site")},
+	"netbox_manufacturer":               {endpoint: "/dcim/manufacturers/", filters: byField("name")},
+	"netbox_module":                     {endpoint: "/dcim/modules/"},
+	"netbox_module_type":                {endpoint: "/dcim/module-types/", filters: byField("model")},
+	"netbox_permission":                 {endpoint: "/users/permissions/", filters: byField("name")},
+	"netbox_platform":                   {endpoint: "/dcim/platforms/", filters: byField("name")},
+	"netbox_power_feed":                 {endpoint: "/dcim/power-feeds/", filters: byField("name")},
+	"netbox_power_panel":                {endpoint: "/dcim/power-panels/", filters: byParentAndName("site")},
+	"netbox_prefix":                     {endpoint: "/ipam/prefixes/", filters: byPrefix},
+	"netbox_primary_ip":                 {endpoint: "/virtualization/virtual-machines/", filters: byField("name")},
+	"netbox_rack":                       {endpoint: "/dcim/racks/", filters: byParentAndName("site")},
+	"netbox_rack_reservation":           {endpoint: "/dcim/rack-reservations/"},
+	"netbox_rack_role":                  {endpoint: "/dcim/rack-roles/", filters: byField("name")},
+	"netbox_region":                     {endpoint: "/dcim/regions/", filters: byField("name")},
+	"netbox_rir":                        {endpoint: "/ipam/rirs/", filters: byField("name")},
+	"netbox_route_target":               {endpoint: "/ipam/route-targets/", filters: byField("name")},
+	"netbox_service":                    {endpoint: "/ipam/services/", filters: byField("name")},
+	"netbox_site":                       {endpoint: "/dcim/sites/", filters: byField("name")},
+	"netbox_site_group":                 {endpoint: "/dcim/site-groups/", filters: byField("name")},
+	"netbox_tag":                        {endpoint: "/extras/tags/", filters: byField("name")},
+	"netbox_tenant":                     {endpoint: "/tenancy/tenants/", filters: byField("name")},
+	"netbox_tenant_group":               {endpoint: "/tenancy/tenant-groups/", filters: byField("name")},
+	"netbox_token":                      {endpoint: "/users/tokens/"},
+	"netbox_user":                       {endpoint: "/users/users/", filters: byField("username")},
+	"netbox_virtual_chassis":            {endpoint: "/dcim/virtual-chassis/", filters: byField("name")},
+	"netbox_virtual_disk":               {endpoint: "/virtualization/virtual-disks/", filters: byParentAndName("virtual_machine")},
+	"netbox_virtual_machine":            {endpoint: "/virtualization/virtual-machines/", filters: byField("name")},
+	"netbox_vlan":                       {endpoint: "/ipam/vlans/", filters: byParentAndName("group")},
+	"netbox_vlan_group":                 {endpoint: "/ipam/vlan-groups/", filters: byField("name")},
+	"netbox_vpn_tunnel":                 {endpoint: "/vpn/tunnels/", filters: byField("name")},
+	"netbox_vpn_tunnel_group":           {endpoint: "/vpn/tunnel-groups/", filters: byField("name")},
+	"netbox_vpn_tunnel_termination":     {endpoint: "/vpn/tunnel-terminations/"},
+	"netbox_vrf":                        {endpoint: "/ipam/vrfs/", filters: byField("name")},
+	"netbox_webhook":                    {endpoint: "/extras/webhooks/", filters: byField("name")},
+}
+
+// addNaturalKeyImport makes every resource with an entry in naturalKeys
+// importable by its natural key. Numeric IDs are passed to the importer of
+// the resource unchanged.
+func addNaturalKeyImport(resources map[string]*schema.Resource) {
+	for resourceType, key := range naturalKeys {
+		r, ok := resources[resourceType]
+		if !ok || r.Importer == nil || r.Importer.StateContext == nil {
+			continue
+		}
+		r.Importer.StateContext = naturalKeyImport(resourceType, key, r.Importer.StateContext)
+	}
+}
+
+func naturalKeyImport(resourceType string, key naturalKey, importer schema.StateContextFunc) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		if _, err := strconv.ParseInt(d.Id(), 10, 64); err != nil {
+			id, err := m.(*providerState).findNaturalKey(ctx, resourceType, key, d.Id())
+			if err != nil {
+				return nil, err
+			}
+			d.SetId(strconv.FormatInt(id, 10))
+		}
+		return importer(ctx, d, m)
+	}
+}
+
+// explicitFieldKey matches keys of the form `field:value`. Keys whose field
+// is not a filter of the endpoint, e.g. IPv6 addresses like `beef::/48`, are
+// natural keys of their resource.
+var explicitFieldKey = regexp.MustCompile(`^([a-z_]+):(.+)$`)
+
+func (state *providerState) findNaturalKey(ctx context.Context, resourceType string, key naturalKey, id string) (int64, error) {
+	if match := explicitFieldKey.FindStringSubmatch(id); match != nil {
+		field, value := match[1], match[2]
+		// Netbox ignores unknown filters, a mistyped field would match
+		// every object
+		filters := endpointFilters(key.endpoint)
+		if filters == nil || filters[field] || strings.HasPrefix(field, "cf_") {
+			return state.findObjectID(ctx, resourceType, key.endpoint, url.Values{field: {value}})
+		}
+		if key.filters == nil {
+			return 0, fmt.Errorf("%q is not a filter of %s, valid filters are %s", field, resourceType, strings.Join(baseFilterNames(filters), ", "))
+		}
+	}
+	if key.filters == nil {
+		return 0, fmt.Errorf("%s can only be imported by ID or by `field:value`, got %q", resourceType, id)
+	}
+	filters, err := key.filters(ctx, state, id)
+	if err != nil {
+		return 0, err
+	}
+	return state.findObjectID(ctx, resourceType, key.endpoint, filters)
+}
+
+var errOperationRecorded = errors.New("operation recorded")
+
+// operationRecorder is a transport recording the operations submitted to it
+// instead of sending them.
+type operationRecorder struct {
+	operation *runtime.ClientOperation
+}
+
+func (r *operationRecorder) Submit(operation *runtime.ClientOperation) (interface{}, error) {
+	r.operation = operation
+	return nil, errOperationRecorded
+}
+
+// services returns the services of a go-netbox client submitting their
+// operations to the recorder.
+func (r *operationRecorder) services() []reflect.Value {
+	fields := reflect.ValueOf(*client.New(r, strfmt.Default))
+	var services []reflect.Value
+	for i := 0; i < fields.NumField(); i++ {
+		if service := fields.Field(i); service.Kind() == reflect.Interface && !service.IsNil() {
+			services = append(services, service)
+		}
+	}
+	return services
+}
+
+// record calls a method of a service returned by services with empty params
+// and returns the params and the operation the method submitted. The
+// operation is nil if the method is no operation.
+func (r *operationRecorder) record(method reflect.Value) (reflect.Value, *runtime.ClientOperation) {
+	t := method.Type()
+	if t.NumIn() < 2 || t.In(0).Kind() != reflect.Pointer || !t.In(0).Implements(reflect.TypeOf((*runtime.ClientRequestWriter)(nil)).Elem()) {
+		return reflect.Value{}, nil
+	}
+	r.operation = nil
+	params := reflect.New(t.In(0).Elem())
+	method.Call([]reflect.Value{params, reflect.Zero(t.In(1))})
+	return params, r.operation
+}
+
+// endpointFilterCache holds the filters of the list endpoints looked up by
+// endpointFilters.
+var endpointFilterCache = struct {
+	mu      sync.Mutex
+	filters map[string]map[string]bool
+}{filters: map[string]map[string]bool{}}
+
+// endpointFilters returns the filters of a list endpoint of the go-netbox
+// client, e.g. `/ipam/prefixes/`. It returns nil for endpoints missing in the
+// client, e.g. of plugins.
+func endpointFilters(endpoint string) map[string]bool {
+	endpointFilterCache.mu.Lock()
+	defer endpointFilterCache.mu.Unlock()
+	if filters, ok := endpointFilterCache.filters[endpoint]; ok {
+		return filters
+	}
+
+	// go-netbox names the list operation of `/ipam/prefixes/` IpamPrefixesList
+	name := swag.ToGoName(strings.ReplaceAll(strings.Trim(endpoint, "/"), "/", "_") + "_list")
+	var filters map[string]bool
+	recorder := &operationRecorder{}
+	for _, service := range recorder.services() {
+		method := service.MethodByName(name)
+		if !method.IsValid() {
+			continue
+		}
+		params, operation := recorder.record(method)
+		if operation != nil && operation.Method == http.MethodGet && operation.PathPattern == endpoint {
+			filters = (&dataSourceFilters{params: func() runtime.ClientRequestWriter {
+				return reflect.New(params.Type().Elem()).Interface().(runtime.ClientRequestWriter)
+			}}).validFilters()
+		}
+		break
+	}
+	endpointFilterCache.filters[endpoint] = filters
+	return filters
+}
+
+// maxListedMatches is the number of matches listed in the error for an
+// ambiguous key.
+const maxListedMatches = 10
+
+// findObjectID returns the ID of the only object of the list endpoint
+// matching the given filters. If no or several objects match, the error
+// names the filters and lists the matches.
+func (state *providerState) findObjectID(ctx context.Context, description, endpoint string, filters url.Values) (int64, error) {
+	query := url.Values{"limit": {strconv.Itoa(maxListedMatches)}}
+	for k, v := range filters {
+		query[k] = v
+	}
+	var result struct {
+		Count   int `json:"count"`
+		Results []struct {
+			ID      int64  `json:"id"`
+			Display string `json:"display"`
+		} `json:"results"`
+	}
+	if err := state.rawAPIRequest(ctx, http.MethodGet, endpoint, query, nil, &result); err != nil {
+		return 0, err
+	}
+
+	switch result.Count {
+	case 0:
+		return 0, fmt.Errorf("no %s found with %s", description, formatFilters(filters))
+	case 1:
+		if len(result.Results) == 1 {
+			return result.Results[0].ID, nil
+		}
+	}
+	matches := make([]string, 0, len(result.Results))
+	for _, match := range result.Results {
+		matches = append(matches, fmt.Sprintf("\n  %d: %s", match.ID, match.Display))
+	}
+	if result.Count > len(result.Results) {
+		matches = append(matches, fmt.Sprintf("\n  and %d more", result.Count-len(result.Results)))
+	}
+	return 0, fmt.Errorf("%s with %s is ambiguous, %d objects match, use the ID or a more specific key:%s", description, formatFilters(filters), result.Count, strings.Join(matches, ""))
+}
+
+// formatFilters returns the filters in a stable, human readable form.
+func formatFilters(filters url.Values) string {
+	unescaped, err := url.QueryUnescape(filters.Encode())
+	if err != nil {
+		return filters.Encode()
+	}
+	return strings.ReplaceAll(unescaped, "&", ", ")
+}
@@ -0,0 +1,142 @@
+package netbox
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNaturalKeysCoverResources(t *testing.T) {
+	for resourceType, r := range Provider().ResourcesMap {
+		switch resourceType {
+		case "netbox_available_prefix", "netbox_object":
+			// Imported by keys of their own
+			continue
+		}
+		_, ok := naturalKeys[resourceType]
+		assert.True(t, ok, "%s has no natural key", resourceType)
+		assert.NotNil(t, r.Importer, resourceType)
+	}
+}
+
+func TestNaturalKeyEndpoints(t *testing.T) {
+	for resourceType, key := range naturalKeys {
+		if resourceType == "netbox_branch" {
+			// Plugin endpoints are missing in the client
+			assert.Nil(t, endpointFilters(key.endpoint))
+			continue
+		}
+		assert.NotEmpty(t, endpointFilters(key.endpoint), "%s has no filters", resourceType)
+	}
+}
+
+func TestNaturalKeyImport(t *testing.T) {
+	var queries []url.Values
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := r.URL.Query()
+		query.Del("limit")
+		queries = append(queries, query)
+		switch {
+		case r.URL.Path == "/api/ipam/vrfs/":
+			w.Write([]byte(`{"count": 1, "results": [{"id": 7, "display": "prod"}]}`))
+		case query.Get("name") == "sw1" && !query.Has("site"):
+			w.Write([]byte(`{"count": 2, "results": [{"id": 1, "display": "sw1 (dc1)"}, {"id": 2, "display": "sw1 (dc2)"}]}`))
+		case query.Get("name") == "missing":
+			w.Write([]byte(`{"count": 0, "results": []}`))
+		default:
+			w.Write([]byte(`{"count": 1, "results": [{"id": 42, "display": "match"}]}`))
+		}
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL,
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+	state := &providerState{NetBoxAPI: client}
+	resources := Provider().ResourcesMap
+
+	importID := func(resourceType, id string) (string, error) {
+		queries = nil
+		r := resources[resourceType]
+		d := r.Data(nil)
+		d.SetId(id)
+		imported, err := r.Importer.StateContext(context.Background(), d, state)
+		if err != nil {
+			return "", err
+		}
+		return imported[0].Id(), nil
+	}
+
+	// Numeric IDs are imported without looking them up
+	id, err := importID("netbox_device", "3")
+	assert.NoError(t, err)
+	assert.Equal(t, "3", id)
+	assert.Empty(t, queries)
+
+	id, err = importID("netbox_device", "dc1/sw1")
+	assert.NoError(t, err)
+	assert.Equal(t, "42", id)
+	assert.Equal(t, []url.Values{{"site": {"dc1"}, "name": {"sw1"}}}, queries)
+
+	id, err = importID("netbox_site", "slug:dc1")
+	assert.NoError(t, err)
+	assert.Equal(t, "42", id)
+	assert.Equal(t, []url.Values{{"slug": {"dc1"}}}, queries)
+
+	id, err = importID("netbox_device_interface", "sw1.dc1/Ethernet1/1")
+	assert.NoError(t, err)
+	assert.Equal(t, "42", id)
+	assert.Equal(t, []url.Values{{"device": {"sw1.dc1"}, "name": {"Ethernet1/1"}}}, queries)
+
+	id, err = importID("netbox_prefix", "prod/10.0.0.0/24")
+	assert.NoError(t, err)
+	assert.Equal(t, "42", id)
+	assert.Equal(t, []url.Values{{"name": {"prod"}}, {"prefix": {"10.0.0.0/24"}, "vrf_id": {"7"}}}, queries)
+
+	id, err = importID("netbox_prefix", "10.0.0.0/24")
+	assert.NoError(t, err)
+	assert.Equal(t, "42", id)
+	assert.Equal(t, []url.Values{{"prefix": {"10.0.0.0/24"}, "vrf_id": {"null"}}}, queries)
+
+	id, err = importID("netbox_ip_address", "2001:db8::5/64@prod")
+	assert.NoError(t, err)
+	assert.Equal(t, "42", id)
+	assert.Equal(t, []url.Values{{"name": {"prod"}}, {"address": {"2001:db8::5/64"}, "vrf_id": {"7"}}}, queries)
+
+	// IPv6 addresses are no `field:value` keys
+	id, err = importID("netbox_prefix", "beef::/48")
+	assert.NoError(t, err)
+	assert.Equal(t, "42", id)
+	assert.Equal(t, []url.Values{{"prefix": {"beef::/48"}, "vrf_id": {"null"}}}, queries)
+
+	id, err = importID("netbox_ip_address", "fdab:cdef::1/64")
+	assert.NoError(t, err)
+	assert.Equal(t, "42", id)
+	assert.Equal(t, []url.Values{{"address": {"fdab:cdef::1/64"}, "vrf_id": {"null"}}}, queries)
+
+	id, err = importID("netbox_site", "cf_owner:team-a")
+	assert.NoError(t, err)
+	assert.Equal(t, "42", id)
+	assert.Equal(t, []url.Values{{"cf_owner": {"team-a"}}}, queries)
+
+	_, err = importID("netbox_token", "usr:abc")
+	assert.ErrorContains(t, err, "\"usr\" is not a filter of netbox_token, valid filters are ")
+	assert.Empty(t, queries)
+
+	_, err = importID("netbox_device", "sw1")
+	assert.EqualError(t, err, "netbox_device with name=sw1 is ambiguous, 2 objects match, use the ID or a more specific key:\n  1: sw1 (dc1)\n  2: sw1 (dc2)")
+
+	_, err = importID("netbox_site", "missing")
+	assert.EqualError(t, err, "no netbox_site found with name=missing")
+
+	_, err = importID("netbox_token", "abc")
+	assert.EqualError(t, err, "netbox_token can only be imported by ID or by `field:value`, got \"abc\"")
+}
@@ -0,0 +1,61 @@
+package netbox
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// defaultPageSize is the default number of objects requested per page by
+// data sources returning lists of objects.
+const defaultPageSize = 1000
+
+var pageSizeSchema = &schema.Schema{
+	Type:             schema.TypeInt,
+	Optional:         true,
+	Default:          defaultPageSize,
+	ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+	Description:      "The number of objects requested from Netbox at once. All pages are requested until every matching object is returned. Netbox returns at most `MAX_PAGE_SIZE` objects per request, 1000 by default.",
+}
+
+// getPagination returns the page size and the limit of a data source
+// returning lists of objects. A limit of 0 means no limit.
+func getPagination(d *schema.ResourceData) (pageSize, limit int64) {
+	pageSize = int64(d.Get("page_size").(int))
+	if v, ok := d.GetOk("limit"); ok {
+		limit = int64(v.(int))
+	}
+	return pageSize, limit
+}
+
+// listAll pages through the objects of a list endpoint. list is called with
+// the offset and limit of every page and returns the objects of the page and
+// the total number of objects. If keep is not nil, only the objects it
+// returns true for are returned. Paging stops early once limit objects are
+// found, unless limit is 0.
+func listAll[T any](pageSize, limit int64, keep func(T) bool, list func(offset, limit int64) ([]T, int64, error)) ([]T, error) {
+	var found []T
+	var offset int64
+	for {
+		size := pageSize
+		if keep == nil && limit > 0 && limit-int64(len(found)) < size {
+			size = limit - int64(len(found))
+		}
+		page, count, err := list(offset, size)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range page {
+			if keep != nil && !keep(object) {
+				continue
+			}
+			found = append(found, object)
+			if limit > 0 && int64(len(found)) >= limit {
+				return found, nil
+			}
+		}
+		offset += int64(len(page))
+		if len(page) == 0 || offset >= count {
+			return found, nil
+		}
+	}
+}
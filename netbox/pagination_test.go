@@ -0,0 +1,111 @@
+package netbox
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListAll(t *testing.T) {
+	objects := []int{1, 2, 3, 4, 5, 6, 7}
+	var requests [][2]int64
+	list := func(offset, limit int64) ([]int, int64, error) {
+		requests = append(requests, [2]int64{offset, limit})
+		end := offset + limit
+		if end > int64(len(objects)) {
+			end = int64(len(objects))
+		}
+		return objects[offset:end], int64(len(objects)), nil
+	}
+	even := func(i int) bool { return i%2 == 0 }
+
+	requests = nil
+	found, err := listAll(3, 0, nil, list)
+	assert.NoError(t, err)
+	assert.Equal(t, objects, found)
+	assert.Equal(t, [][2]int64{{0, 3}, {3, 3}, {6, 3}}, requests)
+
+	// Only the missing objects are requested
+	requests = nil
+	found, err = listAll(3, 4, nil, list)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4}, found)
+	assert.Equal(t, [][2]int64{{0, 3}, {3, 1}}, requests)
+
+	// The limit applies to the kept objects
+	requests = nil
+	found, err = listAll(3, 2, even, list)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 4}, found)
+	assert.Equal(t, [][2]int64{{0, 3}, {3, 3}}, requests)
+
+	requests = nil
+	found, err = listAll(3, 0, even, list)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 4, 6}, found)
+	assert.Len(t, requests, 3)
+
+	_, err = listAll(3, 0, nil, func(offset, limit int64) ([]int, int64, error) {
+		return nil, 0, errors.New("failed")
+	})
+	assert.EqualError(t, err, "failed")
+}
+
+func TestDataSourcePagination(t *testing.T) {
+	names := []string{"web1", "db1", "web2", "db2", "web3"}
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		results := []map[string]interface{}{}
+		for i := offset; i < offset+limit && i < len(names); i++ {
+			results = append(results, map[string]interface{}{"id": i + 1, "name": names[i]})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"count": len(names), "results": results})
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL,
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+	state := &providerState{NetBoxAPI: client}
+
+	r := dataSourceNetboxVirtualMachine()
+	read := func(raw map[string]interface{}) []interface{} {
+		requests = 0
+		d := schema.TestResourceDataRaw(t, r.Schema, raw)
+		assert.NoError(t, r.Read(d, state))
+		var names []interface{}
+		for _, vm := range d.Get("vms").([]interface{}) {
+			names = append(names, vm.(map[string]interface{})["name"])
+		}
+		return names
+	}
+
+	assert.Equal(t, []interface{}{"web1", "db1", "web2", "db2", "web3"}, read(map[string]interface{}{"page_size": 2}))
+	assert.Equal(t, 3, requests)
+
+	// name_regex applies to all pages
+	assert.Equal(t, []interface{}{"web1", "web2", "web3"}, read(map[string]interface{}{"page_size": 2, "name_regex": "^web"}))
+	assert.Equal(t, 3, requests)
+
+	// limit applies after name_regex
+	assert.Equal(t, []interface{}{"web1", "web2"}, read(map[string]interface{}{"page_size": 2, "name_regex": "^web", "limit": 2}))
+	assert.Equal(t, 2, requests)
+
+	// limit 0 returns all objects, like an unset limit
+	assert.Empty(t, r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{"limit": 0})))
+	assert.Equal(t, []interface{}{"web1", "db1", "web2", "db2", "web3"}, read(map[string]interface{}{"page_size": 2, "limit": 0}))
+}
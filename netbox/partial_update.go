@@ -0,0 +1,251 @@
+package netbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// changedFieldsOnly returns a client option that reduces the body of a
+// partial update to the fields whose attributes changed, so that fields not
+// managed by Terraform, e.g. set in the UI or by plugins, are left alone.
+//
+// A field belongs to the attribute of the same name, with or without an
+// `_id` or `_ids` suffix. Fields belonging to attributes of other names, or
+// to no attribute at all, are given in aliases. A field that is set in the
+// body but belongs to no attribute of the resource fails the request, so that
+// its changes are not dropped silently. Tags and custom fields are always
+// sent if the provider has default tags or custom fields, so that changed
+// defaults are applied.
+//
+// The request is sent as PATCH, also for endpoints that go-netbox only
+// offers as PUT. As the body only contains absolute values, it is retried
+// like an idempotent request.
+func changedFieldsOnly(api *providerState, d *schema.ResourceData, aliases map[string][]string) func(*runtime.ClientOperation) {
+	// fieldAttributes returns the attributes of the resource a field belongs to
+	fieldAttributes := func(field string) []string {
+		candidates, ok := aliases[field]
+		if !ok {
+			candidates = []string{field, field + "_id", field + "_ids", strings.TrimSuffix(field, "s") + "_ids"}
+		}
+		var attributes []string
+		for _, attribute := range candidates {
+			// Get returns nil only for attributes missing in the schema
+			if d.Get(attribute) != nil {
+				attributes = append(attributes, attribute)
+			}
+		}
+		return attributes
+	}
+	checkFields := withBodyCheck(func(field string, value interface{}) error {
+		switch field {
+		case lastUpdatedKey, "tags", "custom_fields":
+			return nil
+		}
+		if _, ok := aliases[field]; ok || isEmptyBodyValue(value) || len(fieldAttributes(field)) > 0 {
+			return nil
+		}
+		return fmt.Errorf("the field %q of the partial update belongs to no attribute, give it in the aliases", field)
+	})
+	selectFields := withBodyFields(func(field string) bool {
+		if field == lastUpdatedKey {
+			return false
+		}
+		attributes := fieldAttributes(field)
+		switch field {
+		case "tags":
+			if len(api.defaultTags) > 0 {
+				return true
+			}
+		case "custom_fields":
+			if len(api.defaultCustomFields) > 0 {
+				return true
+			}
+			if d.Get(customFieldsJSONKey) != nil {
+				attributes = append(attributes, customFieldsJSONKey)
+			}
+		}
+		for _, attribute := range attributes {
+			if d.HasChange(attribute) {
+				return true
+			}
+		}
+		return false
+	})
+	return func(op *runtime.ClientOperation) {
+		op.Method = http.MethodPatch
+		ctx := op.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		op.Context = withRepeatableRequest(ctx)
+		checkFields(op)
+		selectFields(op)
+	}
+}
+
+// onlyFields returns a client option that reduces the body of a request to
+// the given fields.
+func onlyFields(fields ...string) func(*runtime.ClientOperation) {
+	return withBodyFields(func(field string) bool {
+		for _, f := range fields {
+			if f == field {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// withBodyFields returns a client option that reduces the JSON body of a
+// request to the selected fields. Selected fields that are empty, i.e.
+// omitted from the JSON body or null, are sent with the empty value of their
+// type, so that they are cleared in Netbox.
+func withBodyFields(selected func(field string) bool) func(*runtime.ClientOperation) {
+	return func(op *runtime.ClientOperation) {
+		params := op.Params
+		op.Params = runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+			capture := &bodyCapture{ClientRequest: r}
+			if err := params.WriteToRequest(capture, reg); err != nil {
+				return err
+			}
+			if capture.body == nil {
+				return nil
+			}
+			body, err := selectBodyFields(capture.body, selected)
+			if err != nil {
+				return err
+			}
+			return r.SetBodyParam(body)
+		})
+	}
+}
+
+// withBodyCheck returns a client option that fails the request if check
+// returns an error for a field of its JSON body.
+func withBodyCheck(check func(field string, value interface{}) error) func(*runtime.ClientOperation) {
+	return func(op *runtime.ClientOperation) {
+		params := op.Params
+		op.Params = runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+			capture := &bodyCapture{ClientRequest: r}
+			if err := params.WriteToRequest(capture, reg); err != nil {
+				return err
+			}
+			if capture.body == nil {
+				return nil
+			}
+			fields, err := bodyFields(capture.body)
+			if err != nil {
+				return err
+			}
+			for field, value := range fields {
+				if err := check(field, value); err != nil {
+					return err
+				}
+			}
+			return r.SetBodyParam(capture.body)
+		})
+	}
+}
+
+// bodyCapture is a client request that holds back the body, so that it can
+// be changed before it is set on the actual request.
+type bodyCapture struct {
+	runtime.ClientRequest
+	body interface{}
+}
+
+func (r *bodyCapture) SetBodyParam(body interface{}) error {
+	r.body = body
+	return nil
+}
+
+// bodyFields returns the fields of the JSON body of the payload.
+func bodyFields(payload interface{}) (map[string]interface{}, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	// Numbers are kept as they are, IDs must not be turned into floats
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var fields map[string]interface{}
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func selectBodyFields(payload interface{}, selected func(field string) bool) (map[string]interface{}, error) {
+	fields, err := bodyFields(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{}
+	for field, value := range fields {
+		if selected(field) {
+			body[field] = value
+		}
+	}
+
+	t := reflect.TypeOf(payload)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return body, nil
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if field == "" || field == "-" || !selected(field) || body[field] != nil {
+			continue
+		}
+		body[field] = emptyFieldValue(field, t.Field(i).Type)
+	}
+	return body, nil
+}
+
+// emptyFieldValue returns the value clearing a field of the given type in
+// Netbox.
+func emptyFieldValue(field string, t reflect.Type) interface{} {
+	if field == "custom_fields" {
+		// Netbox merges custom fields, an empty object leaves them unchanged
+		return map[string]interface{}{}
+	}
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Struct:
+		return nil
+	case reflect.Slice:
+		return []interface{}{}
+	default:
+		return reflect.Zero(t).Interface()
+	}
+}
+
+// isEmptyBodyValue returns true if a field of a JSON body has an empty value.
+func isEmptyBodyValue(value interface{}) bool {
+	switch value := value.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	case bool:
+		return !value
+	case json.Number:
+		return value == "0"
+	case []interface{}:
+		return len(value) == 0
+	case map[string]interface{}:
+		return len(value) == 0
+	}
+	return false
+}
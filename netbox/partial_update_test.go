@@ -0,0 +1,121 @@
+package netbox
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fbreckle/go-netbox/netbox/client/dcim"
+	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectBodyFields(t *testing.T) {
+	data := models.WritableSite{
+		Name:        strToPtr("test"),
+		Slug:        strToPtr("test"),
+		Description: "test",
+	}
+	selected := map[string]bool{"name": true, "tenant": true, "time_zone": true, "asns": true, "custom_fields": true}
+
+	body, err := selectBodyFields(&data, func(field string) bool { return selected[field] })
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"name":          "test",
+		"tenant":        nil,
+		"time_zone":     nil,
+		"asns":          []interface{}{},
+		"custom_fields": map[string]interface{}{},
+	}, body)
+}
+
+func TestChangedFieldsOnly(t *testing.T) {
+	var method string
+	var body map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodGet {
+			method = r.Method
+			raw, _ := io.ReadAll(r.Body)
+			json.Unmarshal(raw, &body)
+		}
+		w.Write([]byte(`{"id": 1, "name": "test", "slug": "test", "status": {"value": "active"}, "time_zone": "Europe/Berlin"}`))
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL,
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+	state := &providerState{NetBoxAPI: client, tags: newTagCache()}
+
+	r := Provider().ResourcesMap["netbox_site"]
+	oldState := &terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"id":        "1",
+			"name":      "test",
+			"slug":      "test",
+			"status":    "active",
+			"tenant_id": "3",
+		},
+	}
+	diff, err := r.Diff(context.Background(), oldState, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":     "test",
+		"slug":     "test",
+		"status":   "active",
+		"timezone": "Europe/Berlin",
+	}), state)
+	assert.NoError(t, err)
+	d, err := schema.InternalMap(r.Schema).Data(oldState, diff)
+	assert.NoError(t, err)
+
+	diags := r.UpdateContext(context.Background(), d, state)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, http.MethodPatch, method)
+	assert.Equal(t, map[string]interface{}{
+		"time_zone": "Europe/Berlin",
+		"tenant":    nil,
+	}, body)
+}
+
+func TestChangedFieldsOnlyUnmappedField(t *testing.T) {
+	var requests int
+	var body map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		raw, _ := io.ReadAll(r.Body)
+		json.Unmarshal(raw, &body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1, "name": "test", "slug": "test"}`))
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL,
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+	state := &providerState{NetBoxAPI: client, tags: newTagCache()}
+	d := schema.TestResourceDataRaw(t, resourceNetboxPlatform().Schema, map[string]interface{}{"name": "test", "slug": "test"})
+
+	// The platform resource has no attribute for the NAPALM driver
+	data := models.WritablePlatform{Name: strToPtr("test"), Slug: strToPtr("test"), NapalmDriver: "ios"}
+	params := dcim.NewDcimPlatformsPartialUpdateParams().WithID(1).WithData(&data)
+	_, err = client.Dcim.DcimPlatformsPartialUpdate(params, nil, changedFieldsOnly(state, d, nil))
+	assert.ErrorContains(t, err, `"napalm_driver"`)
+	assert.Equal(t, 0, requests)
+
+	_, err = client.Dcim.DcimPlatformsPartialUpdate(params, nil, changedFieldsOnly(state, d, map[string][]string{"napalm_driver": nil}))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, map[string]interface{}{"name": "test", "slug": "test"}, body)
+}
@@ -4,14 +4,59 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
+	"sync"
 
+	netboxclient "github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/status"
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"golang.org/x/exp/slices"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// providerState is passed as meta to all resources and data sources.
+type providerState struct {
+	*netboxclient.NetBoxAPI
+
+	// netboxVersion is the version of the Netbox server. It is nil if the
+	// version was not determined, e.g. because `skip_version_check` is set.
+	netboxVersion *version.Version
+
+	// tags caches the tags resolved while the provider runs.
+	tags *tagCache
+
+	// defaultTags are added to the tags of every resource supporting tags.
+	defaultTags []string
+	// defaultCustomFields are added to the custom fields of every resource
+	// supporting custom fields, as long as the custom field is assigned to
+	// its object type in Netbox.
+	defaultCustomFields map[string]interface{}
+	// defaultCustomFieldObjectTypes maps the names of the default custom
+	// fields to the object types they are assigned to.
+	defaultCustomFieldObjectTypes map[string][]string
+
+	// ignoredTags and ignoredCustomFields are managed outside of Terraform.
+	// They are left out when reading resources unless they are also set on
+	// the resource.
+	ignoredTags         ignoredTags
+	ignoredCustomFields []string
+
+	// readOnly is set if the provider must not modify anything in Netbox.
+	readOnly bool
+
+	// validateReferencesAtPlan is set if references to other objects are
+	// looked up in Netbox at plan time.
+	validateReferencesAtPlan bool
+	// conflictMode decides what happens if an object was changed in Netbox
+	// since it was last read, see `conflict_mode`.
+	conflictMode string
+
+	// knownReferences holds the references found in Netbox at plan time.
+	knownReferences sync.Map
+}
+
 // This makes the description contain the default value, particularly useful for the docs
 // From https://github.com/hashicorp/terraform-plugin-docs/issues/65#issuecomment-1152842370
 func init() {
@@ -99,6 +144,7 @@ func Provider() *schema.Provider {
 			"netbox_device_primary_ip":          resourceNetboxDevicePrimaryIP(),
 			"netbox_device_role":                resourceNetboxDeviceRole(),
 			"netbox_tag":                        resourceNetboxTag(),
+			"netbox_branch":                     resourceNetboxBranch(),
 			"netbox_cluster_group":              resourceNetboxClusterGroup(),
 			"netbox_site":                       resourceNetboxSite(),
 			"netbox_vlan":                       resourceNetboxVlan(),
@@ -139,6 +185,7 @@ func Provider() *schema.Provider {
 			"netbox_inventory_item_role":        resourceNetboxInventoryItemRole(),
 			"netbox_inventory_item":             resourceNetboxInventoryItem(),
 			"netbox_webhook":                    resourceNetboxWebhook(),
+			"netbox_object":                     resourceNetboxObject(),
 			"netbox_custom_field_choice_set":    resourceNetboxCustomFieldChoiceSet(),
 			"netbox_virtual_chassis":            resourceNetboxVirtualChassis(),
 			"netbox_virtual_disk":               resourceNetboxVirtualDisks(),
@@ -168,12 +215,14 @@ func Provider() *schema.Provider {
 			"netbox_prefix":            dataSourceNetboxPrefix(),
 			"netbox_prefixes":          dataSourceNetboxPrefixes(),
 			"netbox_devices":           dataSourceNetboxDevices(),
+			"netbox_objects":           dataSourceNetboxObjects(),
 			"netbox_device_role":       dataSourceNetboxDeviceRole(),
 			"netbox_device_type":       dataSourceNetboxDeviceType(),
 			"netbox_site":              dataSourceNetboxSite(),
 			"netbox_location":          dataSourceNetboxLocation(),
 			"netbox_locations":         dataSourceNetboxLocations(),
 			"netbox_tag":               dataSourceNetboxTag(),
+			"netbox_branch":            dataSourceNetboxBranch(),
 			"netbox_tags":              dataSourceNetboxTags(),
 			"netbox_virtual_machines":  dataSourceNetboxVirtualMachine(),
 			"netbox_interfaces":        dataSourceNetboxInterfaces(),
@@ -200,9 +249,21 @@ func Provider() *schema.Provider {
 			},
 			"api_token": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("NETBOX_API_TOKEN", nil),
-				Description: "Netbox API authentication token. Can be set via the `NETBOX_API_TOKEN` environment variable.",
+				Description: "Netbox API authentication token. Can be set via the `NETBOX_API_TOKEN` environment variable." + apiTokenPrecedenceDescription,
+			},
+			"api_token_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NETBOX_API_TOKEN_FILE", nil),
+				Description: "Path to a file containing the Netbox API authentication token. Leading and trailing whitespace is ignored. The file is read again whenever it changes, so the token can be rotated while Terraform is running. Can be set via the `NETBOX_API_TOKEN_FILE` environment variable." + apiTokenPrecedenceDescription,
+			},
+			"api_token_command": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NETBOX_API_TOKEN_COMMAND", nil),
+				Description: "Command that prints the Netbox API authentication token, e.g. a password manager CLI. The command is run once through the system shell (`sh -c`, or `cmd /C` on Windows) when the provider is configured. Leading and trailing whitespace of its output is ignored. Can be set via the `NETBOX_API_TOKEN_COMMAND` environment variable." + apiTokenPrecedenceDescription,
 			},
 			"allow_insecure_https": {
 				Type:        schema.TypeBool,
@@ -210,12 +271,134 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("NETBOX_ALLOW_INSECURE_HTTPS", false),
 				Description: "Flag to set whether to allow https with invalid certificates. Can be set via the `NETBOX_ALLOW_INSECURE_HTTPS` environment variable. Defaults to `false`.",
 			},
+			"ca_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("NETBOX_CA_CERT_FILE", nil),
+				ConflictsWith: []string{"ca_cert_pem"},
+				Description:   "Path to a file containing PEM encoded CA certificates that are trusted in addition to the system CAs when verifying the certificate of Netbox. Can be set via the `NETBOX_CA_CERT_FILE` environment variable.",
+			},
+			"ca_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("NETBOX_CA_CERT_PEM", nil),
+				ConflictsWith: []string{"ca_cert_file"},
+				Description:   "PEM encoded CA certificates that are trusted in addition to the system CAs when verifying the certificate of Netbox. Can be set via the `NETBOX_CA_CERT_PEM` environment variable.",
+			},
+			"client_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("NETBOX_CLIENT_CERT_FILE", nil),
+				ConflictsWith: []string{"client_cert_pem"},
+				Description:   "Path to a file containing a PEM encoded client certificate for mutual TLS authentication. Requires a client key. Can be set via the `NETBOX_CLIENT_CERT_FILE` environment variable.",
+			},
+			"client_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("NETBOX_CLIENT_CERT_PEM", nil),
+				ConflictsWith: []string{"client_cert_file"},
+				Description:   "PEM encoded client certificate for mutual TLS authentication. Requires a client key. Can be set via the `NETBOX_CLIENT_CERT_PEM` environment variable.",
+			},
+			"client_key_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("NETBOX_CLIENT_KEY_FILE", nil),
+				ConflictsWith: []string{"client_key_pem"},
+				Description:   "Path to a file containing the PEM encoded private key of the client certificate. Can be set via the `NETBOX_CLIENT_KEY_FILE` environment variable.",
+			},
+			"client_key_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.EnvDefaultFunc("NETBOX_CLIENT_KEY_PEM", nil),
+				ConflictsWith: []string{"client_key_file"},
+				Description:   "PEM encoded private key of the client certificate. Can be set via the `NETBOX_CLIENT_KEY_PEM` environment variable.",
+			},
 			"headers": {
 				Type:        schema.TypeMap,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("NETBOX_HEADERS", map[string]interface{}{}),
 				Description: "Set these header on all requests to Netbox. Can be set via the `NETBOX_HEADERS` environment variable.",
 			},
+			"default_tags": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				Set:         schema.HashString,
+				Description: "Tags added to every resource supporting tags. Default tags are not shown in the `tags` attribute of resources unless they are set there explicitly. Changes to the default tags are applied to a resource the next time it is updated.",
+			},
+			"default_custom_fields": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Custom fields added to every resource supporting custom fields whose object type the custom field is assigned to in Netbox. A value set in the `custom_fields` attribute of a resource takes precedence. Default custom fields are not shown in the `custom_fields` attribute of resources unless they are set there explicitly. Changes to the default custom fields are applied to a resource the next time it is updated.",
+			},
+			"read_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NETBOX_READ_ONLY", false),
+				Description: "If true, the provider refuses to create, update or delete anything, so that only data sources can be used. Only read requests are sent to Netbox, so a token with write permissions can be used safely. Can be set via the `NETBOX_READ_ONLY` environment variable. Defaults to `false`.",
+			},
+			"validate_references_at_plan": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NETBOX_VALIDATE_REFERENCES_AT_PLAN", false),
+				Description: "If true, IDs of referenced objects like `site_id` or `device_id` that are known at plan time are looked up in Netbox during the plan, so that references to objects that do not exist or have the wrong type fail before anything is changed. This needs one additional request per referenced object. Can be set via the `NETBOX_VALIDATE_REFERENCES_AT_PLAN` environment variable. Defaults to `false`.",
+			},
+			"conflict_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("NETBOX_CONFLICT_MODE", conflictModeError),
+				ValidateFunc: validation.StringInSlice(conflictModeOptions, false),
+				Description:  "What to do if an object was changed in Netbox since it was last read and the changes overlap with the planned changes. With `error`, the update fails and names the attributes changed in Netbox. With `warn`, the changes made in Netbox are overwritten with a warning. With `ignore`, objects are not read again before they are updated. Can be set via the `NETBOX_CONFLICT_MODE` environment variable. Defaults to `error`.",
+			},
+			"branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NETBOX_BRANCH", nil),
+				Description: "Schema ID or name of a branch of the [Netbox Branching plugin](https://github.com/netboxlabs/netbox-branching). If set, all changes are made in this branch instead of the main schema. The branch has to exist and be ready. Can be set via the `NETBOX_BRANCH` environment variable.",
+			},
+			"ignore_tags": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"names": {
+							Type: schema.TypeSet,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Optional:    true,
+							Set:         schema.HashString,
+							Description: "Names of the tags to ignore.",
+						},
+						"name_prefixes": {
+							Type: schema.TypeSet,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Optional:    true,
+							Set:         schema.HashString,
+							Description: "Prefixes of the names of the tags to ignore.",
+						},
+					},
+				},
+				Description: "Tags managed outside of Terraform, e.g. by discovery jobs. Ignored tags are not shown in the `tags` attribute of resources unless they are set there explicitly, and they are kept when a resource is updated. To keep them, the provider reads the object before every update that sets its tags, i.e. sends an additional GET request.",
+			},
+			"ignore_custom_fields": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				Set:         schema.HashString,
+				Description: "Names of custom fields managed outside of Terraform, e.g. by discovery jobs. Ignored custom fields are not shown in the `custom_fields` attribute of resources unless they are set there explicitly, and they are kept when a resource is updated.",
+			},
 			"strip_trailing_slashes_from_url": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -232,23 +415,151 @@ func Provider() *schema.Provider {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("NETBOX_REQUEST_TIMEOUT", 10),
-				Description: "Netbox API HTTP request timeout in seconds. Can be set via the `NETBOX_REQUEST_TIMEOUT` environment variable.",
+				Description: "Netbox API HTTP request timeout in seconds. When requests are retried, the timeout applies to each attempt. Requests of resources that create, update or delete objects are bounded by the `timeouts` of the resource instead. Can be set via the `NETBOX_REQUEST_TIMEOUT` environment variable.",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("NETBOX_MAX_RETRIES", 3),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of times a request to Netbox is retried after a transient failure (HTTP 429, 502, 503, 504 or a connection error). Only idempotent requests are retried, except when Netbox refused the request (HTTP 429) or it never reached the server. Set to `0` to disable retries. Can be set via the `NETBOX_MAX_RETRIES` environment variable. Defaults to `3`.",
+			},
+			"retry_min_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("NETBOX_RETRY_MIN_WAIT", 1),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Minimum time in seconds to wait before retrying a failed request. The wait time doubles with every attempt up to `retry_max_wait`. A `Retry-After` header sent by Netbox takes precedence, but is capped to `retry_max_wait`. Can be set via the `NETBOX_RETRY_MIN_WAIT` environment variable. Defaults to `1`.",
+			},
+			"retry_max_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("NETBOX_RETRY_MAX_WAIT", 30),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum time in seconds to wait before retrying a failed request, also if Netbox asks for a longer wait by a `Retry-After` header. Can be set via the `NETBOX_RETRY_MAX_WAIT` environment variable. Defaults to `30`.",
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("NETBOX_MAX_CONCURRENT_REQUESTS", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of requests the provider sends to Netbox at the same time. Further requests are queued until a request finishes. Set to `0` for no limit. Can be set via the `NETBOX_MAX_CONCURRENT_REQUESTS` environment variable. Defaults to `0`.",
+			},
+			"requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("NETBOX_REQUESTS_PER_SECOND", 0.0),
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  "Maximum number of requests per second the provider sends to Netbox. Further requests are queued until they may be sent. Set to `0` for no limit. Can be set via the `NETBOX_REQUESTS_PER_SECOND` environment variable. Defaults to `0`.",
 			},
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
+
+	addResourceTimeouts(provider.ResourcesMap)
+	addCapabilityChecks(provider.ResourcesMap)
+	addReferenceChecks(provider.ResourcesMap)
+	addConflictChecks(provider.ResourcesMap)
+	// Checks added later run first. In read-only mode, nothing else is
+	// checked or read.
+	addReadOnlyChecks(provider.ResourcesMap)
+	addNaturalKeyImport(provider.ResourcesMap)
+	addValidationErrorDiagnostics(provider.ResourcesMap)
+
 	return provider
 }
 
+const apiTokenPrecedenceDescription = " Exactly one of `api_token`, `api_token_file` or `api_token_command` must be given. One given in the provider configuration takes precedence over the environment variables of the others."
+
+// apiTokenEnvVars maps the attributes the API token can be given by to their
+// environment variables.
+var apiTokenEnvVars = map[string]string{
+	"api_token":         "NETBOX_API_TOKEN",
+	"api_token_file":    "NETBOX_API_TOKEN_FILE",
+	"api_token_command": "NETBOX_API_TOKEN_COMMAND",
+}
+
+// configuredAPITokenAttribute returns the attribute the API token is given by,
+// or an empty string if it is not given. An attribute set in the provider
+// configuration takes precedence over the environment variables of the
+// others, e.g. `api_token_file` over `NETBOX_API_TOKEN`.
+func configuredAPITokenAttribute(data *schema.ResourceData) (string, error) {
+	var configured, fromEnv []string
+	rawConfig := data.GetRawConfig()
+	for _, attribute := range []string{"api_token", "api_token_file", "api_token_command"} {
+		if data.Get(attribute).(string) == "" {
+			continue
+		}
+		if rawConfig.IsKnown() && !rawConfig.IsNull() && !rawConfig.GetAttr(attribute).IsNull() {
+			configured = append(configured, attribute)
+		} else {
+			fromEnv = append(fromEnv, attribute)
+		}
+	}
+	switch {
+	case len(configured) > 1:
+		return "", fmt.Errorf("only one of `api_token`, `api_token_file` and `api_token_command` can be set, got `%s`", strings.Join(configured, "`, `"))
+	case len(configured) == 1:
+		return configured[0], nil
+	case len(fromEnv) > 1:
+		envVars := make([]string, len(fromEnv))
+		for i, attribute := range fromEnv {
+			envVars[i] = apiTokenEnvVars[attribute]
+		}
+		return "", fmt.Errorf("only one of NETBOX_API_TOKEN, NETBOX_API_TOKEN_FILE and NETBOX_API_TOKEN_COMMAND can be set, got %s", strings.Join(envVars, ", "))
+	case len(fromEnv) == 1:
+		return fromEnv[0], nil
+	}
+	return "", nil
+}
+
 func providerConfigure(ctx context.Context, data *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	return configureProvider(ctx, data, nil)
+}
+
+// configureProvider configures the provider like providerConfigure, wrapping
+// the HTTP transport of the client with wrapTransport if it is set.
+func configureProvider(ctx context.Context, data *schema.ResourceData, wrapTransport func(http.RoundTripper) http.RoundTripper) (interface{}, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	config := Config{
-		APIToken:                    data.Get("api_token").(string),
+		wrapTransport:               wrapTransport,
 		AllowInsecureHTTPS:          data.Get("allow_insecure_https").(bool),
 		Headers:                     data.Get("headers").(map[string]interface{}),
 		RequestTimeout:              data.Get("request_timeout").(int),
 		StripTrailingSlashesFromURL: data.Get("strip_trailing_slashes_from_url").(bool),
+		MaxRetries:                  data.Get("max_retries").(int),
+		RetryMinWait:                data.Get("retry_min_wait").(int),
+		RetryMaxWait:                data.Get("retry_max_wait").(int),
+		MaxConcurrentRequests:       data.Get("max_concurrent_requests").(int),
+		RequestsPerSecond:           data.Get("requests_per_second").(float64),
+		CACertFile:                  data.Get("ca_cert_file").(string),
+		CACertPEM:                   data.Get("ca_cert_pem").(string),
+		ClientCertFile:              data.Get("client_cert_file").(string),
+		ClientCertPEM:               data.Get("client_cert_pem").(string),
+		ClientKeyFile:               data.Get("client_key_file").(string),
+		ClientKeyPEM:                data.Get("client_key_pem").(string),
+		IgnoreTagNames:              toStringList(data.Get("ignore_tags.0.names")),
+		IgnoreTagNamePrefixes:       toStringList(data.Get("ignore_tags.0.name_prefixes")),
+		ReadOnly:                    data.Get("read_only").(bool),
+		Branch:                      data.Get("branch").(string),
+	}
+
+	apiTokenAttribute, err := configuredAPITokenAttribute(data)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	switch apiTokenAttribute {
+	case "api_token":
+		config.APIToken = data.Get("api_token").(string)
+	case "api_token_file":
+		config.APITokenFile = data.Get("api_token_file").(string)
+	case "api_token_command":
+		config.APITokenCommand = data.Get("api_token_command").(string)
+	}
+
+	if config.RetryMinWait > config.RetryMaxWait {
+		return nil, diag.Errorf("`retry_min_wait` (%d) must not be greater than `retry_max_wait` (%d)", config.RetryMinWait, config.RetryMaxWait)
 	}
 
 	serverURL := data.Get("server_url").(string)
@@ -277,7 +588,7 @@ func providerConfigure(ctx context.Context, data *schema.ResourceData) (interfac
 
 	config.ServerURL = serverURL
 
-	netboxClient, clientError := config.Client()
+	netboxClient, clientError := config.ClientWithContext(ctx)
 	if clientError != nil {
 		return nil, diag.FromErr(clientError)
 	}
@@ -286,6 +597,27 @@ func providerConfigure(ctx context.Context, data *schema.ResourceData) (interfac
 	// so we can determine compatibility of the provider with the used Netbox
 	skipVersionCheck := data.Get("skip_version_check").(bool)
 
+	state := &providerState{
+		NetBoxAPI:           netboxClient,
+		tags:                newTagCache(),
+		defaultTags:         toStringList(data.Get("default_tags")),
+		defaultCustomFields: data.Get("default_custom_fields").(map[string]interface{}),
+		ignoredTags:         config.ignoredTags(),
+		ignoredCustomFields: toStringList(data.Get("ignore_custom_fields")),
+		readOnly:            config.ReadOnly,
+
+		validateReferencesAtPlan: data.Get("validate_references_at_plan").(bool),
+		conflictMode:             data.Get("conflict_mode").(string),
+	}
+
+	if len(state.defaultCustomFields) > 0 {
+		objectTypes, err := getCustomFieldObjectTypes(state, state.defaultCustomFields)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		state.defaultCustomFieldObjectTypes = objectTypes
+	}
+
 	if !skipVersionCheck {
 		req := status.NewStatusListParams()
 		res, err := netboxClient.Status.StatusList(req, nil)
@@ -295,17 +627,26 @@ func providerConfigure(ctx context.Context, data *schema.ResourceData) (interfac
 
 		netboxVersion := res.GetPayload().(map[string]interface{})["netbox-version"].(string)
 
-		supportedVersions := []string{"4.0.0", "4.0.1", "4.0.2", "4.0.3", "4.0.5", "4.0.6", "4.0.7", "4.0.8", "4.0.9", "4.0.10"}
-
-		if !slices.Contains(supportedVersions, netboxVersion) {
-			// Currently, there is no way to test these warnings. There is an issue to track this: https://github.com/hashicorp/terraform-plugin-sdk/issues/864
+		parsedVersion, err := parseNetboxVersion(netboxVersion)
+		if err != nil {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Warning,
-				Summary:  "Possibly unsupported Netbox version",
-				Detail:   fmt.Sprintf("Your Netbox version is v%v. The provider was successfully tested against the following versions:\n\n  %v\n\nUnexpected errors may occur.", netboxVersion, strings.Join(supportedVersions, ", ")),
+				Summary:  "Unable to parse Netbox version",
+				Detail:   fmt.Sprintf("Your Netbox reports version %q, which is not a valid version: %v\n\nChecks for features requiring a specific Netbox version are skipped.", netboxVersion, err),
 			})
+		} else {
+			state.netboxVersion = parsedVersion
+
+			if !supportedNetboxVersions.Check(parsedVersion) {
+				// Currently, there is no way to test these warnings. There is an issue to track this: https://github.com/hashicorp/terraform-plugin-sdk/issues/864
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  "Possibly unsupported Netbox version",
+					Detail:   fmt.Sprintf("Your Netbox version is v%v. The provider was successfully tested against the following versions:\n\n  %v\n\nUnexpected errors may occur.", netboxVersion, supportedNetboxVersions),
+				})
+			}
 		}
 	}
 
-	return netboxClient, diags
+	return state, diags
 }
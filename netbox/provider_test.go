@@ -20,22 +20,33 @@ var testPrefix = "test"
 
 func init() {
 	testAccProvider = Provider()
+	testAccProvider.ConfigureContextFunc = testAccProviderConfigure
 	testAccProviders = map[string]*schema.Provider{
 		"netbox": testAccProvider,
 	}
 }
 
+// testAccGetTestName returns a unique name for objects created by a test.
+// Names are deterministic when recording or replaying cassettes.
 func testAccGetTestName(testSlug string) string {
-	randomString := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	randomString := testAccRandString(testSlug, 10, acctest.CharSetAlphaNum)
 	return strings.Join([]string{testPrefix, testSlug, randomString}, "-")
 }
 
 func testAccGetTestToken() string {
-	randomToken := acctest.RandStringFromCharSet(40, "0123456789")
+	randomToken := testAccRandString("token", 40, "0123456789")
 	return randomToken
 }
 
+func testAccRandString(key string, length int, charset string) string {
+	if cassetteMode() != "" {
+		return cassetteRandomString(key, length, charset)
+	}
+	return acctest.RandStringFromCharSet(length, charset)
+}
+
 func testAccPreCheck(t *testing.T) {
+	testAccCassette(t)
 	if v := os.Getenv("NETBOX_SERVER_URL"); v == "" {
 		t.Fatal("NETBOX_SERVER_URL must be set for acceptance tests.")
 	}
@@ -64,7 +75,7 @@ func providerInvalidConfigure() schema.ConfigureContextFunc {
 			return nil, diag.FromErr(clientError)
 		}
 
-		return netboxClient, diags
+		return &providerState{NetBoxAPI: netboxClient}, diags
 	}
 }
 
@@ -0,0 +1,69 @@
+package netbox
+
+import (
+	"math"
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// limitTransport is a transport that limits the number of requests in flight
+// and the rate at which requests are sent to Netbox. Requests exceeding the
+// limits are queued until they may be sent or their context is done.
+type limitTransport struct {
+	original http.RoundTripper
+	// slots is a semaphore holding one element per request in flight. It is
+	// nil if the number of concurrent requests is not limited.
+	slots chan struct{}
+	// limiter is nil if the request rate is not limited.
+	limiter *rate.Limiter
+}
+
+// newLimitTransport returns a transport enforcing the given limits. A limit
+// of zero means no limit.
+func newLimitTransport(original http.RoundTripper, maxConcurrentRequests int, requestsPerSecond float64) *limitTransport {
+	t := &limitTransport{
+		original: original,
+	}
+	if maxConcurrentRequests > 0 {
+		t.slots = make(chan struct{}, maxConcurrentRequests)
+	}
+	if requestsPerSecond > 0 {
+		burst := int(math.Max(1, math.Floor(requestsPerSecond)))
+		t.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+	}
+	return t
+}
+
+// RoundTrip waits for a free slot and for the rate limiter before sending the
+// request. The slot is held until the response body is closed.
+func (t *limitTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	release := func() {}
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		case <-r.Context().Done():
+			return nil, r.Context().Err()
+		}
+		var once sync.Once
+		release = func() {
+			once.Do(func() { <-t.slots })
+		}
+	}
+
+	if t.limiter != nil {
+		if err := t.limiter.Wait(r.Context()); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	resp, err := t.original.RoundTrip(r)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp.Body = onCloseBody{ReadCloser: resp.Body, onClose: release}
+	return resp, nil
+}
@@ -0,0 +1,83 @@
+package netbox
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fbreckle/go-netbox/netbox/client/status"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:              "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL:             ts.URL,
+		MaxConcurrentRequests: 2,
+	}
+
+	client, err := config.Client()
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.Status.StatusList(status.NewStatusListParams(), nil)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxInFlight))
+}
+
+func TestRequestsPerSecond(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:          "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL:         ts.URL,
+		RequestsPerSecond: 20,
+	}
+
+	client, err := config.Client()
+	assert.NoError(t, err)
+
+	// The first 20 requests are allowed as a burst, the next 5 have to wait
+	// for the limiter
+	start := time.Now()
+	for i := 0; i < 25; i++ {
+		_, err := client.Status.StatusList(status.NewStatusListParams(), nil)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(25), atomic.LoadInt32(&calls))
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+}
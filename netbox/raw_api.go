@@ -0,0 +1,87 @@
+package netbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+)
+
+// rawAPIError is returned for error responses of rawAPIRequest.
+type rawAPIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Body       string
+}
+
+func (e *rawAPIError) Error() string {
+	return fmt.Sprintf("[%s %s][%d] %s", e.Method, e.Path, e.StatusCode, strings.TrimSpace(e.Body))
+}
+
+// Code returns the HTTP status code of the response.
+func (e *rawAPIError) Code() int {
+	return e.StatusCode
+}
+
+// GetPayload returns the decoded JSON body of the response, or nil if the
+// body is not JSON.
+func (e *rawAPIError) GetPayload() interface{} {
+	var payload interface{}
+	if err := json.Unmarshal([]byte(e.Body), &payload); err != nil {
+		return nil
+	}
+	return payload
+}
+
+// isRawAPINotFound returns true if err is a 404 response to rawAPIRequest.
+func isRawAPINotFound(err error) bool {
+	apiErr, ok := err.(*rawAPIError)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// rawAPIRequest sends a request to a Netbox API endpoint without the typed
+// go-netbox client, e.g. to endpoints of plugins or to endpoints only known
+// at runtime. The request goes through the same transport as all other
+// requests. The path is relative to the API root, e.g.
+// `/plugins/branching/branches/`. If body is not nil, it is sent as JSON. If
+// result is not nil, the JSON response is decoded into it.
+func (state *providerState) rawAPIRequest(ctx context.Context, method, path string, query url.Values, body interface{}, result interface{}) error {
+	_, err := state.Transport.Submit(&runtime.ClientOperation{
+		ID:                 strings.ToLower(method) + " " + path,
+		Method:             method,
+		PathPattern:        path,
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Context:            ctx,
+		Params: runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, _ strfmt.Registry) error {
+			for key, values := range query {
+				if err := r.SetQueryParam(key, values...); err != nil {
+					return err
+				}
+			}
+			if body != nil {
+				return r.SetBodyParam(body)
+			}
+			return nil
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+			if response.Code() < 200 || response.Code() > 299 {
+				responseBody, _ := io.ReadAll(response.Body())
+				return nil, &rawAPIError{Method: method, Path: path, StatusCode: response.Code(), Body: string(responseBody)}
+			}
+			if result != nil && response.Code() != http.StatusNoContent {
+				return nil, consumer.Consume(response.Body(), result)
+			}
+			return nil, nil
+		}),
+	})
+	return err
+}
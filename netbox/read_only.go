@@ -0,0 +1,61 @@
+package netbox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// readOnlyError returns an error if the provider is in read-only mode. The
+// action describes what was refused, e.g. "create netbox_site".
+func readOnlyError(m interface{}, action string) error {
+	if state, ok := m.(*providerState); ok && state.readOnly {
+		return fmt.Errorf("cannot %s: the provider is in read-only mode (`read_only` is set)", action)
+	}
+	return nil
+}
+
+// addReadOnlyChecks wraps the create, update and delete functions of all
+// resources so that they fail before any request is sent to Netbox if the
+// provider is in read-only mode.
+func addReadOnlyChecks(resources map[string]*schema.Resource) {
+	for resourceType, r := range resources {
+		r.CreateContext = readOnlyCheck(r.CreateContext, "create "+resourceType)
+		r.UpdateContext = readOnlyCheck(r.UpdateContext, "update "+resourceType)
+		r.DeleteContext = readOnlyCheck(r.DeleteContext, "delete "+resourceType)
+	}
+}
+
+func readOnlyCheck(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics, action string) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if err := readOnlyError(m, action); err != nil {
+			return diag.FromErr(err)
+		}
+		return f(ctx, d, m)
+	}
+}
+
+// readOnlyTransport is a transport that refuses to send requests that could
+// modify data in Netbox. It is a safety net for the checks added by
+// addReadOnlyChecks.
+type readOnlyTransport struct {
+	original http.RoundTripper
+}
+
+// RoundTrip sends safe requests and fails all others.
+func (t readOnlyTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return t.original.RoundTrip(r)
+	}
+	if r.Body != nil {
+		r.Body.Close()
+	}
+	return nil, fmt.Errorf("refusing to send %s request to %s: the provider is in read-only mode (`read_only` is set)", r.Method, r.URL.Path)
+}
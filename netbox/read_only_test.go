@@ -0,0 +1,103 @@
+package netbox
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fbreckle/go-netbox/netbox/client/extras"
+	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadOnlyResources(t *testing.T) {
+	resources := Provider().ResourcesMap
+	state := &providerState{readOnly: true}
+
+	r := resources["netbox_tag"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "test"})
+	diags := r.CreateContext(context.Background(), d, state)
+	if assert.True(t, diags.HasError()) {
+		assert.Equal(t, "cannot create netbox_tag: the provider is in read-only mode (`read_only` is set)", diags[0].Summary)
+	}
+	diags = r.UpdateContext(context.Background(), d, state)
+	if assert.True(t, diags.HasError()) {
+		assert.Equal(t, "cannot update netbox_tag: the provider is in read-only mode (`read_only` is set)", diags[0].Summary)
+	}
+	diags = r.DeleteContext(context.Background(), d, state)
+	if assert.True(t, diags.HasError()) {
+		assert.Equal(t, "cannot delete netbox_tag: the provider is in read-only mode (`read_only` is set)", diags[0].Summary)
+	}
+
+	r = resources["netbox_config_template"]
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "test"})
+	diags = r.CreateContext(context.Background(), d, state)
+	if assert.True(t, diags.HasError()) {
+		assert.Equal(t, "cannot create netbox_config_template: the provider is in read-only mode (`read_only` is set)", diags[0].Summary)
+	}
+}
+
+func TestReadOnlyCheckRunsFirst(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1, "name": "changed", "slug": "test"}`))
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL,
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+	state := &providerState{NetBoxAPI: client, readOnly: true, conflictMode: conflictModeError}
+
+	// The conflict check would read the tag before updating it
+	r := Provider().ResourcesMap["netbox_tag"]
+	d := r.Data(&terraform.InstanceState{ID: "1", Attributes: map[string]string{
+		"name":         "test",
+		"slug":         "test",
+		lastUpdatedKey: "2024-01-01T12:00:00Z",
+	}})
+	diags := r.UpdateContext(context.Background(), d, state)
+	if assert.True(t, diags.HasError()) {
+		assert.Equal(t, "cannot update netbox_tag: the provider is in read-only mode (`read_only` is set)", diags[0].Summary)
+	}
+	assert.Equal(t, 0, requests)
+}
+
+func TestReadOnlyTransport(t *testing.T) {
+	var methods []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"count": 0, "results": []}`))
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL,
+		ReadOnly:  true,
+	}
+
+	client, err := config.Client()
+	assert.NoError(t, err)
+
+	_, err = client.Extras.ExtrasTagsList(extras.NewExtrasTagsListParams(), nil)
+	assert.NoError(t, err)
+
+	data := models.Tag{Name: strToPtr("test"), Slug: strToPtr("test")}
+	_, err = client.Extras.ExtrasTagsCreate(extras.NewExtrasTagsCreateParams().WithData(&data), nil)
+	assert.ErrorContains(t, err, "refusing to send POST request to /api/extras/tags/: the provider is in read-only mode")
+
+	_, err = client.Extras.ExtrasTagsDelete(extras.NewExtrasTagsDeleteParams().WithID(1), nil)
+	assert.ErrorContains(t, err, "refusing to send DELETE request")
+
+	assert.Equal(t, []string{http.MethodGet}, methods)
+}
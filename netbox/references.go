@@ -0,0 +1,229 @@
+package netbox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// objectTypeEndpoints maps Netbox object types to their API endpoints,
+// relative to the API root.
+var objectTypeEndpoints = map[string]string{
+	"dcim.consoleport":              "/dcim/console-ports/",
+	"dcim.consoleserverport":        "/dcim/console-server-ports/",
+	"dcim.device":                   "/dcim/devices/",
+	"dcim.devicerole":               "/dcim/device-roles/",
+	"dcim.devicetype":               "/dcim/device-types/",
+	"dcim.frontport":                "/dcim/front-ports/",
+	"dcim.interface":                "/dcim/interfaces/",
+	"dcim.inventoryitem":            "/dcim/inventory-items/",
+	"dcim.inventoryitemrole":        "/dcim/inventory-item-roles/",
+	"dcim.location":                 "/dcim/locations/",
+	"dcim.manufacturer":             "/dcim/manufacturers/",
+	"dcim.module":                   "/dcim/modules/",
+	"dcim.platform":                 "/dcim/platforms/",
+	"dcim.poweroutlet":              "/dcim/power-outlets/",
+	"dcim.powerport":                "/dcim/power-ports/",
+	"dcim.rack":                     "/dcim/racks/",
+	"dcim.rearport":                 "/dcim/rear-ports/",
+	"dcim.site":                     "/dcim/sites/",
+	"dcim.virtualchassis":           "/dcim/virtual-chassis/",
+	"extras.configtemplate":         "/extras/config-templates/",
+	"ipam.ipaddress":                "/ipam/ip-addresses/",
+	"ipam.role":                     "/ipam/roles/",
+	"ipam.vlan":                     "/ipam/vlans/",
+	"ipam.vrf":                      "/ipam/vrfs/",
+	"tenancy.tenant":                "/tenancy/tenants/",
+	"virtualization.cluster":        "/virtualization/clusters/",
+	"virtualization.virtualmachine": "/virtualization/virtual-machines/",
+	"virtualization.vminterface":    "/virtualization/interfaces/",
+}
+
+// reference describes an attribute holding the ID of another Netbox object.
+type reference struct {
+	// objectType is the object type of the referenced object.
+	objectType string
+	// objectTypeAttribute is the attribute holding the object type of the
+	// referenced object if the reference is polymorphic. If it is set,
+	// objectType is ignored.
+	objectTypeAttribute string
+}
+
+// componentReferences are the references shared by all device components.
+var componentReferences = map[string]reference{
+	"device_id": {objectType: "dcim.device"},
+	"module_id": {objectType: "dcim.module"},
+}
+
+// referenceAttributes maps resource types to their attributes holding IDs of
+// other Netbox objects. They are checked at plan time if
+// `validate_references_at_plan` is set.
+var referenceAttributes = map[string]map[string]reference{
+	"netbox_device": {
+		"cluster_id":         {objectType: "virtualization.cluster"},
+		"config_template_id": {objectType: "extras.configtemplate"},
+		"device_type_id":     {objectType: "dcim.devicetype"},
+		"location_id":        {objectType: "dcim.location"},
+		"platform_id":        {objectType: "dcim.platform"},
+		"rack_id":            {objectType: "dcim.rack"},
+		"role_id":            {objectType: "dcim.devicerole"},
+		"site_id":            {objectType: "dcim.site"},
+		"tenant_id":          {objectType: "tenancy.tenant"},
+		"virtual_chassis_id": {objectType: "dcim.virtualchassis"},
+	},
+	"netbox_virtual_machine": {
+		"cluster_id":  {objectType: "virtualization.cluster"},
+		"device_id":   {objectType: "dcim.device"},
+		"platform_id": {objectType: "dcim.platform"},
+		"role_id":     {objectType: "dcim.devicerole"},
+		"site_id":     {objectType: "dcim.site"},
+		"tenant_id":   {objectType: "tenancy.tenant"},
+	},
+	"netbox_prefix": {
+		"role_id":   {objectType: "ipam.role"},
+		"site_id":   {objectType: "dcim.site"},
+		"tenant_id": {objectType: "tenancy.tenant"},
+		"vlan_id":   {objectType: "ipam.vlan"},
+		"vrf_id":    {objectType: "ipam.vrf"},
+	},
+	"netbox_ip_address": {
+		"device_interface_id":          {objectType: "dcim.interface"},
+		"interface_id":                 {objectTypeAttribute: "object_type"},
+		"nat_inside_address_id":        {objectType: "ipam.ipaddress"},
+		"tenant_id":                    {objectType: "tenancy.tenant"},
+		"virtual_machine_interface_id": {objectType: "virtualization.vminterface"},
+		"vrf_id":                       {objectType: "ipam.vrf"},
+	},
+	"netbox_device_interface": {
+		"device_id":                  {objectType: "dcim.device"},
+		"lag_device_interface_id":    {objectType: "dcim.interface"},
+		"parent_device_interface_id": {objectType: "dcim.interface"},
+	},
+	"netbox_interface": {
+		"virtual_machine_id": {objectType: "virtualization.virtualmachine"},
+	},
+	"netbox_device_console_port":        componentReferences,
+	"netbox_device_console_server_port": componentReferences,
+	"netbox_device_front_port": {
+		"device_id":    {objectType: "dcim.device"},
+		"module_id":    {objectType: "dcim.module"},
+		"rear_port_id": {objectType: "dcim.rearport"},
+	},
+	"netbox_device_rear_port":  componentReferences,
+	"netbox_device_power_port": componentReferences,
+	"netbox_device_power_outlet": {
+		"device_id":     {objectType: "dcim.device"},
+		"module_id":     {objectType: "dcim.module"},
+		"power_port_id": {objectType: "dcim.powerport"},
+	},
+	"netbox_device_module_bay": {
+		"device_id": {objectType: "dcim.device"},
+	},
+	"netbox_inventory_item": {
+		"component_id":    {objectTypeAttribute: "component_type"},
+		"device_id":       {objectType: "dcim.device"},
+		"manufacturer_id": {objectType: "dcim.manufacturer"},
+		"parent_id":       {objectType: "dcim.inventoryitem"},
+		"role_id":         {objectType: "dcim.inventoryitemrole"},
+	},
+	"netbox_virtual_disk": {
+		"virtual_machine_id": {objectType: "virtualization.virtualmachine"},
+	},
+	"netbox_service": {
+		"virtual_machine_id": {objectType: "virtualization.virtualmachine"},
+	},
+}
+
+// addReferenceChecks adds a plan time check to every resource with entries
+// in referenceAttributes. If `validate_references_at_plan` is set, the check
+// looks up every changed reference in Netbox, so that references to objects
+// that do not exist fail before anything is changed.
+func addReferenceChecks(resources map[string]*schema.Resource) {
+	for resourceType, attributes := range referenceAttributes {
+		r, ok := resources[resourceType]
+		if !ok {
+			continue
+		}
+		appendCustomizeDiff(r, referenceCheck(attributes))
+	}
+}
+
+// appendCustomizeDiff adds f to the CustomizeDiff functions of r. f runs
+// before any CustomizeDiff function already set.
+func appendCustomizeDiff(r *schema.Resource, f schema.CustomizeDiffFunc) {
+	if r.CustomizeDiff == nil {
+		r.CustomizeDiff = f
+	} else {
+		r.CustomizeDiff = customdiff.All(f, r.CustomizeDiff)
+	}
+}
+
+func referenceCheck(attributes map[string]reference) schema.CustomizeDiffFunc {
+	// Check attributes in a stable order so the first error is deterministic
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		state, ok := m.(*providerState)
+		if !ok || !state.validateReferencesAtPlan {
+			return nil
+		}
+		for _, name := range names {
+			if !d.HasChange(name) || !d.NewValueKnown(name) {
+				continue
+			}
+			id, _ := d.Get(name).(int)
+			if id == 0 {
+				continue
+			}
+			ref := attributes[name]
+			objectType := ref.objectType
+			if ref.objectTypeAttribute != "" {
+				if !d.NewValueKnown(ref.objectTypeAttribute) {
+					continue
+				}
+				objectType = d.Get(ref.objectTypeAttribute).(string)
+				if objectType == "" {
+					continue
+				}
+			}
+			if err := state.checkReference(ctx, objectType, int64(id)); err != nil {
+				return fmt.Errorf("`%s`: %w", name, err)
+			}
+		}
+		return nil
+	}
+}
+
+// checkReference returns an error if there is no object of the given object
+// type and ID in Netbox. Objects that were found are remembered, so every
+// object is looked up at most once.
+func (state *providerState) checkReference(ctx context.Context, objectType string, id int64) error {
+	endpoint, ok := objectTypeEndpoints[objectType]
+	if !ok {
+		return fmt.Errorf("cannot validate references to objects of type %q", objectType)
+	}
+	key := fmt.Sprintf("%s:%d", objectType, id)
+	if _, ok := state.knownReferences.Load(key); ok {
+		return nil
+	}
+
+	path := fmt.Sprintf("%s%d/", endpoint, id)
+	err := state.rawAPIRequest(ctx, http.MethodGet, path, url.Values{"brief": []string{"1"}}, nil, nil)
+	if isRawAPINotFound(err) {
+		return fmt.Errorf("there is no %s with ID %d in Netbox", objectType, id)
+	}
+	if err != nil {
+		return fmt.Errorf("could not look up %s with ID %d: %w", objectType, id, err)
+	}
+	state.knownReferences.Store(key, struct{}{})
+	return nil
+}
@@ -0,0 +1,114 @@
+package netbox
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReferenceAttributesExist(t *testing.T) {
+	resources := Provider().ResourcesMap
+	for resourceType, attributes := range referenceAttributes {
+		r, ok := resources[resourceType]
+		if !assert.True(t, ok, resourceType) {
+			continue
+		}
+		for name, ref := range attributes {
+			assert.Contains(t, r.Schema, name, resourceType)
+			if ref.objectTypeAttribute != "" {
+				assert.Contains(t, r.Schema, ref.objectTypeAttribute, resourceType)
+			} else {
+				assert.Contains(t, objectTypeEndpoints, ref.objectType, resourceType)
+			}
+		}
+	}
+}
+
+func TestReferenceCheck(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/api/dcim/sites/1/", "/api/dcim/interfaces/3/":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id": 1}`))
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"detail": "Not found."}`))
+		}
+	}))
+	defer ts.Close()
+
+	configure := func(validate bool) *providerState {
+		p := Provider()
+		diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+			"server_url":                  ts.URL,
+			"api_token":                   "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+			"skip_version_check":          true,
+			"validate_references_at_plan": validate,
+		}))
+		for _, d := range diags {
+			assert.NotEqual(t, diag.Error, d.Severity, d.Summary)
+		}
+		return p.Meta().(*providerState)
+	}
+	state := configure(true)
+
+	diff := func(r string, config map[string]interface{}, m interface{}) error {
+		_, err := Provider().ResourcesMap[r].Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), m)
+		return err
+	}
+
+	// Found objects are only looked up once
+	for i := 0; i < 2; i++ {
+		err := diff("netbox_prefix", map[string]interface{}{
+			"prefix":  "10.0.0.0/24",
+			"status":  "active",
+			"site_id": 1,
+		}, state)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, requests["/api/dcim/sites/1/"])
+
+	err := diff("netbox_prefix", map[string]interface{}{
+		"prefix":  "10.0.0.0/24",
+		"status":  "active",
+		"site_id": 2,
+	}, state)
+	assert.ErrorContains(t, err, "`site_id`: there is no dcim.site with ID 2 in Netbox")
+
+	// Polymorphic references are looked up by their object type
+	err = diff("netbox_ip_address", map[string]interface{}{
+		"ip_address":   "10.0.0.1/24",
+		"status":       "active",
+		"interface_id": 3,
+		"object_type":  "dcim.interface",
+	}, state)
+	assert.NoError(t, err)
+	err = diff("netbox_ip_address", map[string]interface{}{
+		"ip_address":   "10.0.0.1/24",
+		"status":       "active",
+		"interface_id": 3,
+		"object_type":  "virtualization.vminterface",
+	}, state)
+	assert.ErrorContains(t, err, "`interface_id`: there is no virtualization.vminterface with ID 3 in Netbox")
+
+	// Without `validate_references_at_plan` nothing is looked up
+	err = diff("netbox_prefix", map[string]interface{}{
+		"prefix":  "10.0.0.0/24",
+		"status":  "active",
+		"site_id": 4,
+	}, configure(false))
+	assert.NoError(t, err)
+	assert.Zero(t, requests["/api/dcim/sites/4/"])
+}
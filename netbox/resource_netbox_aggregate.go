@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxAggregate() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxAggregateCreate,
-		Read:   resourceNetboxAggregateRead,
-		Update: resourceNetboxAggregateUpdate,
-		Delete: resourceNetboxAggregateDelete,
+		CreateContext: resourceNetboxAggregateCreate,
+		ReadContext:   resourceNetboxAggregateRead,
+		UpdateContext: resourceNetboxAggregateUpdate,
+		DeleteContext: resourceNetboxAggregateDelete,
 
 		Description: `:meta:subcategory:IP Address Management (IPAM):From the [official documentation](https://docs.netbox.dev/en/stable/features/ipam/#aggregates):
 
@@ -39,15 +40,16 @@ func resourceNetboxAggregate() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
-			tagsKey: tagsSchema,
+			tagsKey:        tagsSchema,
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 	}
 }
-func resourceNetboxAggregateCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxAggregateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	data := models.WritableAggregate{}
 
 	prefix := d.Get("prefix").(string)
@@ -64,22 +66,26 @@ func resourceNetboxAggregateCreate(d *schema.ResourceData, m interface{}) error
 		data.Rir = int64ToPtr(int64(rirID.(int)))
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.Tags = tags
 
-	params := ipam.NewIpamAggregatesCreateParams().WithData(&data)
+	params := ipam.NewIpamAggregatesCreateParams().WithContext(ctx).WithData(&data)
 	res, err := api.Ipam.IpamAggregatesCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxAggregateRead(d, m)
+	return resourceNetboxAggregateRead(ctx, d, m)
 }
 
-func resourceNetboxAggregateRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxAggregateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamAggregatesReadParams().WithID(id)
+	params := ipam.NewIpamAggregatesReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Ipam.IpamAggregatesRead(params, nil)
 	if err != nil {
@@ -91,7 +97,7 @@ func resourceNetboxAggregateRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("description", res.GetPayload().Description)
@@ -111,13 +117,14 @@ func resourceNetboxAggregateRead(d *schema.ResourceData, m interface{}) error {
 		d.Set("rir_id", nil)
 	}
 
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, res.GetPayload().Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxAggregateUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxAggregateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableAggregate{}
 	prefix := d.Get("prefix").(string)
@@ -134,20 +141,24 @@ func resourceNetboxAggregateUpdate(d *schema.ResourceData, m interface{}) error
 		data.Rir = int64ToPtr(int64(rirID.(int)))
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.Tags = tags
 
-	params := ipam.NewIpamAggregatesUpdateParams().WithID(id).WithData(&data)
-	_, err := api.Ipam.IpamAggregatesUpdate(params, nil)
+	params := ipam.NewIpamAggregatesPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
+	_, err = api.Ipam.IpamAggregatesPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
-	return resourceNetboxAggregateRead(d, m)
+	return resourceNetboxAggregateRead(ctx, d, m)
 }
 
-func resourceNetboxAggregateDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxAggregateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamAggregatesDeleteParams().WithID(id)
+	params := ipam.NewIpamAggregatesDeleteParams().WithContext(ctx).WithID(id)
 	_, err := api.Ipam.IpamAggregatesDelete(params, nil)
 	if err != nil {
 		if errresp, ok := err.(*ipam.IpamAggregatesDeleteDefault); ok {
@@ -156,7 +167,7 @@ func resourceNetboxAggregateDelete(d *schema.ResourceData, m interface{}) error
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("")
 	return nil
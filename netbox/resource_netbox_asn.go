@@ -1,20 +1,21 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceNetboxAsn() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxAsnCreate,
-		Read:   resourceNetboxAsnRead,
-		Update: resourceNetboxAsnUpdate,
-		Delete: resourceNetboxAsnDelete,
+		CreateContext: resourceNetboxAsnCreate,
+		ReadContext:   resourceNetboxAsnRead,
+		UpdateContext: resourceNetboxAsnUpdate,
+		DeleteContext: resourceNetboxAsnDelete,
 
 		Description: `:meta:subcategory:IP Address Management (IPAM):From the [official documentation](https://docs.netbox.dev/en/stable/features/ipam/#asn):
 > ASN is short for Autonomous System Number. This identifier is used in the BGP protocol to identify which "autonomous system" a particular prefix is originating and transiting through.
@@ -30,7 +31,8 @@ func resourceNetboxAsn() *schema.Resource {
 				Type:     schema.TypeInt,
 				Required: true,
 			},
-			tagsKey: tagsSchema,
+			tagsKey:        tagsSchema,
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -38,8 +40,8 @@ func resourceNetboxAsn() *schema.Resource {
 	}
 }
 
-func resourceNetboxAsnCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxAsnCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableASN{}
 
@@ -49,24 +51,28 @@ func resourceNetboxAsnCreate(d *schema.ResourceData, m interface{}) error {
 	rir := int64(d.Get("rir_id").(int))
 	data.Rir = &rir
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.Tags = tags
 
-	params := ipam.NewIpamAsnsCreateParams().WithData(&data)
+	params := ipam.NewIpamAsnsCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Ipam.IpamAsnsCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxAsnRead(d, m)
+	return resourceNetboxAsnRead(ctx, d, m)
 }
 
-func resourceNetboxAsnRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxAsnRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamAsnsReadParams().WithID(id)
+	params := ipam.NewIpamAsnsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Ipam.IpamAsnsRead(params, nil)
 
@@ -79,20 +85,21 @@ func resourceNetboxAsnRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	asn := res.GetPayload()
 	d.Set("asn", asn.Asn)
 	d.Set("rir_id", asn.Rir.ID)
 
-	d.Set(tagsKey, getTagListFromNestedTagList(asn.Tags))
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, asn.Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxAsnUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxAsnUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableASN{}
@@ -103,23 +110,27 @@ func resourceNetboxAsnUpdate(d *schema.ResourceData, m interface{}) error {
 	rir := int64(d.Get("rir_id").(int))
 	data.Rir = &rir
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.Tags = tags
 
-	params := ipam.NewIpamAsnsUpdateParams().WithID(id).WithData(&data)
+	params := ipam.NewIpamAsnsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Ipam.IpamAsnsUpdate(params, nil)
+	_, err = api.Ipam.IpamAsnsPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxAsnRead(d, m)
+	return resourceNetboxAsnRead(ctx, d, m)
 }
 
-func resourceNetboxAsnDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxAsnDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamAsnsDeleteParams().WithID(id)
+	params := ipam.NewIpamAsnsDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Ipam.IpamAsnsDelete(params, nil)
 	if err != nil {
@@ -129,7 +140,7 @@ func resourceNetboxAsnDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
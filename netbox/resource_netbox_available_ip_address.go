@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxAvailableIPAddress() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxAvailableIPAddressCreate,
-		Read:   resourceNetboxAvailableIPAddressRead,
-		Update: resourceNetboxAvailableIPAddressUpdate,
-		Delete: resourceNetboxAvailableIPAddressDelete,
+		CreateContext: resourceNetboxAvailableIPAddressCreate,
+		ReadContext:   resourceNetboxAvailableIPAddressRead,
+		UpdateContext: resourceNetboxAvailableIPAddressUpdate,
+		DeleteContext: resourceNetboxAvailableIPAddressDelete,
 
 		Description: `:meta:subcategory:IP Address Management (IPAM):Per [the docs](https://netbox.readthedocs.io/en/stable/models/ipam/ipaddress/):
 
@@ -98,6 +99,7 @@ This resource will retrieve the next available IP address from a given prefix or
 				ValidateFunc: validation.StringInSlice(resourceNetboxIPAddressRoleOptions, false),
 				Description:  buildValidValueDescription(resourceNetboxIPAddressRoleOptions),
 			},
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -105,8 +107,8 @@ This resource will retrieve the next available IP address from a given prefix or
 	}
 }
 
-func resourceNetboxAvailableIPAddressCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxAvailableIPAddressCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	prefixID := int64(d.Get("prefix_id").(int))
 	vrfID := int64(int64(d.Get("vrf_id").(int)))
 	rangeID := int64(d.Get("ip_range_id").(int))
@@ -117,26 +119,26 @@ func resourceNetboxAvailableIPAddressCreate(d *schema.ResourceData, m interface{
 		Vrf: &nestedvrf,
 	}
 	if prefixID != 0 {
-		params := ipam.NewIpamPrefixesAvailableIpsCreateParams().WithID(prefixID).WithData([]*models.AvailableIP{&data})
+		params := ipam.NewIpamPrefixesAvailableIpsCreateParams().WithContext(ctx).WithID(prefixID).WithData([]*models.AvailableIP{&data})
 		res, _ := api.Ipam.IpamPrefixesAvailableIpsCreate(params, nil)
 		// Since we generated the ip_address, set that now
 		d.SetId(strconv.FormatInt(res.Payload[0].ID, 10))
 		d.Set("ip_address", *res.Payload[0].Address)
 	}
 	if rangeID != 0 {
-		params := ipam.NewIpamIPRangesAvailableIpsCreateParams().WithID(rangeID).WithData([]*models.AvailableIP{&data})
+		params := ipam.NewIpamIPRangesAvailableIpsCreateParams().WithContext(ctx).WithID(rangeID).WithData([]*models.AvailableIP{&data})
 		res, _ := api.Ipam.IpamIPRangesAvailableIpsCreate(params, nil)
 		// Since we generated the ip_address, set that now
 		d.SetId(strconv.FormatInt(res.Payload[0].ID, 10))
 		d.Set("ip_address", *res.Payload[0].Address)
 	}
-	return resourceNetboxAvailableIPAddressUpdate(d, m)
+	return resourceNetboxAvailableIPAddressUpdate(ctx, d, m)
 }
 
-func resourceNetboxAvailableIPAddressRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxAvailableIPAddressRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamIPAddressesReadParams().WithID(id)
+	params := ipam.NewIpamIPAddressesReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Ipam.IpamIPAddressesRead(params, nil)
 	if err != nil {
@@ -148,7 +150,7 @@ func resourceNetboxAvailableIPAddressRead(d *schema.ResourceData, m interface{})
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	ipAddress := res.GetPayload()
@@ -191,12 +193,14 @@ func resourceNetboxAvailableIPAddressRead(d *schema.ResourceData, m interface{})
 	d.Set("ip_address", ipAddress.Address)
 	d.Set("description", ipAddress.Description)
 	d.Set("status", ipAddress.Status.Value)
-	d.Set(tagsKey, getTagListFromNestedTagList(ipAddress.Tags))
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, ipAddress.Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
+
 	return nil
 }
 
-func resourceNetboxAvailableIPAddressUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxAvailableIPAddressUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableIPAddress{}
@@ -237,22 +241,26 @@ func resourceNetboxAvailableIPAddressUpdate(d *schema.ResourceData, m interface{
 		data.AssignedObjectID = nil
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.Tags = tags
 
-	params := ipam.NewIpamIPAddressesUpdateParams().WithID(id).WithData(&data)
+	params := ipam.NewIpamIPAddressesPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Ipam.IpamIPAddressesUpdate(params, nil)
+	_, err = api.Ipam.IpamIPAddressesPartialUpdate(params, nil, changedFieldsOnly(api, d, ipAddressFieldAliases))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
-	return resourceNetboxAvailableIPAddressRead(d, m)
+	return resourceNetboxAvailableIPAddressRead(ctx, d, m)
 }
 
-func resourceNetboxAvailableIPAddressDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxAvailableIPAddressDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamIPAddressesDeleteParams().WithID(id)
+	params := ipam.NewIpamIPAddressesDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Ipam.IpamIPAddressesDelete(params, nil)
 	if err != nil {
@@ -262,7 +270,7 @@ func resourceNetboxAvailableIPAddressDelete(d *schema.ResourceData, m interface{
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -17,6 +17,7 @@ func TestAccNetboxAvailableIPAddress_basic(t *testing.T) {
 	testIP := "1.1.2.1/24"
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
@@ -47,6 +48,7 @@ func TestAccNetboxAvailableIPAddress_basic_range(t *testing.T) {
 	testIP := "1.1.5.1/24"
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
@@ -73,6 +75,7 @@ func TestAccNetboxAvailableIPAddress_multipleIpsParallel(t *testing.T) {
 	testPrefix := "1.1.3.0/24"
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
@@ -112,6 +115,7 @@ func TestAccNetboxAvailableIPAddress_multipleIpsParallel_range(t *testing.T) {
 	testIP := []string{"1.1.6.1/24", "1.1.6.2/24", "1.1.6.3/24"}
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
@@ -150,6 +154,7 @@ func TestAccNetboxAvailableIPAddress_multipleIpsSerial(t *testing.T) {
 	testIP := []string{"1.1.4.1/24", "1.1.4.2/24", "1.1.4.3/24"}
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
@@ -191,6 +196,7 @@ func TestAccNetboxAvailableIPAddress_multipleIpsSerial_range(t *testing.T) {
 	testIP := []string{"1.1.7.1/24", "1.1.7.2/24", "1.1.7.3/24"}
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
@@ -232,6 +238,7 @@ func TestAccNetboxAvailableIPAddress_deviceByObjectType(t *testing.T) {
 	testName := testAccGetTestName(testSlug)
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNetboxIPAddressFullDeviceDependencies(testName) + fmt.Sprintf(`
@@ -263,6 +270,7 @@ func TestAccNetboxAvailableIPAddress_deviceByFieldName(t *testing.T) {
 	testName := testAccGetTestName(testSlug)
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNetboxIPAddressFullDeviceDependencies(testName) + fmt.Sprintf(`
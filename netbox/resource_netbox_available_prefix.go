@@ -6,19 +6,19 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxAvailablePrefix() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxAvailablePrefixCreate,
-		Read:   resourceNetboxPrefixRead,
-		Update: resourceNetboxPrefixUpdate,
-		Delete: resourceNetboxPrefixDelete,
+		CreateContext: resourceNetboxAvailablePrefixCreate,
+		ReadContext:   resourceNetboxPrefixRead,
+		UpdateContext: resourceNetboxPrefixUpdate,
+		DeleteContext: resourceNetboxPrefixDelete,
 
 		Description: `:meta:subcategory:IP Address Management (IPAM):`,
 
@@ -76,7 +76,10 @@ func resourceNetboxAvailablePrefix() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
-			tagsKey: tagsSchema,
+			customFieldsKey:     customFieldsSchema,
+			customFieldsJSONKey: customFieldsJSONSchema,
+			tagsKey:             tagsSchema,
+			lastUpdatedKey:      lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: func(c context.Context, rd *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -114,24 +117,26 @@ func resourceNetboxAvailablePrefixParseImport(importStr string) (int, string, in
 	return parentID, parts[1], prefixLength, nil
 }
 
-func resourceNetboxAvailablePrefixCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxAvailablePrefixCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	parentPrefixID := int64(d.Get("parent_prefix_id").(int))
 	prefixLength := int64(d.Get("prefix_length").(int))
 	data := models.PrefixLength{
 		PrefixLength: &prefixLength,
 	}
-	params := ipam.NewIpamPrefixesAvailablePrefixesCreateParams().WithID(parentPrefixID).WithData(&data)
+	params := ipam.NewIpamPrefixesAvailablePrefixesCreateParams().WithContext(ctx).WithID(parentPrefixID).WithData(&data)
 
 	res, err := api.Ipam.IpamPrefixesAvailablePrefixesCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	payload := res.GetPayload()
 	d.SetId(strconv.FormatInt(payload.ID, 10))
 	d.Set("prefix", payload.Prefix)
 
-	return resourceNetboxPrefixUpdate(d, m)
+	// The allocation takes the prefix length only, the other attributes,
+	// including custom fields, are sent by the update
+	return resourceNetboxPrefixUpdate(ctx, d, m)
 }
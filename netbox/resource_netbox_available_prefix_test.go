@@ -3,6 +3,7 @@ package netbox
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"testing"
 
 	"github.com/fbreckle/go-netbox/netbox/client"
@@ -41,6 +42,7 @@ func TestAccNetboxAvailablePrefix_basic(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNetboxAvailablePrefixFullDependencies(testName, testParentPrefix) + fmt.Sprintf(`
@@ -100,6 +102,92 @@ resource "netbox_available_prefix" "test" {
 	})
 }
 
+func TestUnitNetboxAvailablePrefix_allocation(t *testing.T) {
+	testFakeNetbox(t)
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testUnitProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetboxAvailablePrefixFullDependencies("test", "10.0.0.0/24") + `
+resource "netbox_prefix" "used" {
+  prefix = "10.0.0.0/26"
+  status = "active"
+}
+
+resource "netbox_available_prefix" "test" {
+  parent_prefix_id = netbox_prefix.parent.id
+  prefix_length    = 25
+  status           = "active"
+  depends_on       = [netbox_prefix.used]
+}
+
+resource "netbox_available_prefix" "small" {
+  parent_prefix_id = netbox_prefix.parent.id
+  prefix_length    = 26
+  status           = "reserved"
+  depends_on       = [netbox_available_prefix.test]
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_available_prefix.test", "prefix", "10.0.0.128/25"),
+					resource.TestCheckResourceAttr("netbox_available_prefix.small", "prefix", "10.0.0.64/26"),
+					resource.TestCheckResourceAttr("netbox_available_prefix.small", "status", "reserved"),
+				),
+			},
+			{
+				Config: testAccNetboxAvailablePrefixFullDependencies("test", "10.0.0.0/24") + `
+resource "netbox_available_prefix" "test" {
+  parent_prefix_id = netbox_prefix.parent.id
+  prefix_length    = 23
+  status           = "active"
+}`,
+				ExpectError: regexp.MustCompile("Insufficient resources"),
+			},
+		},
+	})
+}
+
+func TestUnitNetboxAvailablePrefix_customFields(t *testing.T) {
+	f := testFakeNetbox(t)
+	resourceName := "netbox_available_prefix.test"
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testUnitProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetboxAvailablePrefixFullDependencies("test", "10.0.0.0/24") + `
+resource "netbox_available_prefix" "test" {
+  parent_prefix_id = netbox_prefix.parent.id
+  prefix_length    = 25
+  status           = "active"
+  custom_fields = {
+    owner = "team-a"
+  }
+  custom_fields_json = jsonencode({
+    rating = 5
+  })
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "custom_fields.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "custom_fields.owner", "team-a"),
+					resource.TestCheckResourceAttr(resourceName, "custom_fields_json", `{"rating":5}`),
+					func(s *terraform.State) error {
+						id, err := strconv.ParseInt(s.RootModule().Resources[resourceName].Primary.ID, 10, 64)
+						if err != nil {
+							return err
+						}
+						f.mu.Lock()
+						defer f.mu.Unlock()
+						customFields, _ := f.objects["ipam/prefixes"][id]["custom_fields"].(map[string]interface{})
+						if fmt.Sprint(customFields["owner"]) != "team-a" || fmt.Sprint(customFields["rating"]) != "5" {
+							return fmt.Errorf("expected the custom fields owner and rating in Netbox, got %v", customFields)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func TestAccNetboxAvailablePrefix_multiplePrefixesSerial(t *testing.T) {
 	testParentPrefix := "1.1.0.0/24"
 	testPrefixLength := 25
@@ -111,6 +199,7 @@ func TestAccNetboxAvailablePrefix_multiplePrefixesSerial(t *testing.T) {
 	testName := testAccGetTestName(testSlug)
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNetboxAvailablePrefixFullDependencies(testName, testParentPrefix) + fmt.Sprintf(`
@@ -0,0 +1,259 @@
+package netbox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// branchPollInterval is the interval in which the status of a branch is
+// checked while waiting for the branching plugin.
+var branchPollInterval = 2 * time.Second
+
+func resourceNetboxBranch() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetboxBranchCreate,
+		ReadContext:   resourceNetboxBranchRead,
+		UpdateContext: resourceNetboxBranchUpdate,
+		DeleteContext: resourceNetboxBranchDelete,
+
+		Description: `:meta:subcategory:Branching:From the [official documentation](https://docs.netboxlabs.com/netbox-extensions/branching/):
+
+> Branching enables users to make changes to NetBox in isolated branches, review them and merge them into the main schema.
+
+This resource requires the [Netbox Branching plugin](https://github.com/netboxlabs/netbox-branching). To make changes in a branch, set the ` + "`branch`" + ` attribute of a provider to the schema ID of the branch.`,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 100),
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"sync_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Any value. Whenever it changes, the branch is synchronized with the changes made to the main schema since the branch was created or last synchronized.",
+			},
+			"merge": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If true, the changes of the branch are merged into the main schema. A merged branch cannot be changed anymore.",
+			},
+			"schema_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the database schema of the branch. Set the `branch` attribute of a provider to this value to make changes in the branch.",
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_sync": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+	}
+}
+
+func resourceNetboxBranchCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	data := branch{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+	}
+
+	var res branch
+	err := api.rawAPIRequest(ctx, http.MethodPost, branchingAPIPath+"branches/", nil, &data, &res)
+	if err != nil {
+		return netboxErrorDiagnostics(err)
+	}
+
+	d.SetId(strconv.FormatInt(res.ID, 10))
+
+	// New branches are provisioned in the background
+	_, err = waitForBranch(ctx, api, res.ID, d.Timeout(schema.TimeoutCreate), func(b *branch) bool {
+		return b.status() == "ready"
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("merge").(bool) {
+		if err := mergeBranch(ctx, api, res.ID, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceNetboxBranchRead(ctx, d, m)
+}
+
+func resourceNetboxBranchRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+	id, _ := strconv.ParseInt(d.Id(), 10, 64)
+
+	b, err := getBranch(ctx, api, id)
+	if err != nil {
+		if isRawAPINotFound(err) {
+			// If the ID is updated to blank, this tells Terraform the resource no longer exists (maybe it was destroyed out of band). Just like the destroy callback, the Read function should gracefully handle this case. https://www.terraform.io/docs/extend/writing-custom-providers.html
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", b.Name)
+	d.Set("description", b.Description)
+	d.Set("schema_id", b.SchemaID)
+	d.Set("status", b.status())
+	if b.LastSync != nil {
+		d.Set("last_sync", *b.LastSync)
+	} else {
+		d.Set("last_sync", nil)
+	}
+
+	return nil
+}
+
+func resourceNetboxBranchUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+	id, _ := strconv.ParseInt(d.Id(), 10, 64)
+
+	if d.HasChange("merge") && !d.Get("merge").(bool) {
+		return diag.Errorf("branch %s is already merged, merging cannot be undone by setting `merge` to false", d.Get("name").(string))
+	}
+
+	if d.HasChanges("name", "description") {
+		data := branch{
+			Name:        d.Get("name").(string),
+			Description: d.Get("description").(string),
+		}
+		err := api.rawAPIRequest(ctx, http.MethodPatch, fmt.Sprintf("%sbranches/%d/", branchingAPIPath, id), nil, &data, nil)
+		if err != nil {
+			return netboxErrorDiagnostics(err)
+		}
+	}
+
+	if d.HasChange("sync_trigger") {
+		if err := syncBranch(ctx, api, id, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("merge") {
+		if err := mergeBranch(ctx, api, id, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceNetboxBranchRead(ctx, d, m)
+}
+
+func resourceNetboxBranchDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+	id, _ := strconv.ParseInt(d.Id(), 10, 64)
+
+	err := api.rawAPIRequest(ctx, http.MethodDelete, fmt.Sprintf("%sbranches/%d/", branchingAPIPath, id), nil, nil, nil)
+	if err != nil {
+		if isRawAPINotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func getBranch(ctx context.Context, api *providerState, id int64) (*branch, error) {
+	var b branch
+	err := api.rawAPIRequest(ctx, http.MethodGet, fmt.Sprintf("%sbranches/%d/", branchingAPIPath, id), nil, nil, &b)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// syncBranch synchronizes the branch with the main schema and waits until
+// the synchronization is finished.
+func syncBranch(ctx context.Context, api *providerState, id int64, timeout time.Duration) error {
+	before, err := getBranch(ctx, api, id)
+	if err != nil {
+		return err
+	}
+
+	err = api.rawAPIRequest(ctx, http.MethodPost, fmt.Sprintf("%sbranches/%d/sync/", branchingAPIPath, id), nil, map[string]interface{}{"commit": true}, nil)
+	if err != nil {
+		return err
+	}
+
+	// The synchronization is done by a background job, which is finished
+	// once the time of the last synchronization changes
+	_, err = waitForBranch(ctx, api, id, timeout, func(b *branch) bool {
+		return b.status() == "ready" && b.LastSync != nil && (before.LastSync == nil || *b.LastSync != *before.LastSync)
+	})
+	return err
+}
+
+// mergeBranch merges the branch into the main schema and waits until the
+// merge is finished.
+func mergeBranch(ctx context.Context, api *providerState, id int64, timeout time.Duration) error {
+	err := api.rawAPIRequest(ctx, http.MethodPost, fmt.Sprintf("%sbranches/%d/merge/", branchingAPIPath, id), nil, map[string]interface{}{"commit": true}, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = waitForBranch(ctx, api, id, timeout, func(b *branch) bool {
+		return b.status() == "merged"
+	})
+	return err
+}
+
+// waitForBranch polls the branch until done returns true for it. Waiting
+// fails if the branching plugin reports a failure.
+func waitForBranch(ctx context.Context, api *providerState, id int64, timeout time.Duration, done func(*branch) bool) (*branch, error) {
+	stateConf := &retry.StateChangeConf{
+		Pending:      []string{"pending"},
+		Target:       []string{"done"},
+		Timeout:      timeout,
+		PollInterval: branchPollInterval,
+		Refresh: func() (interface{}, string, error) {
+			b, err := getBranch(ctx, api, id)
+			if err != nil {
+				return nil, "", err
+			}
+			if b.status() == "failed" {
+				return nil, "", fmt.Errorf("branch %s failed, check the jobs of the branch in Netbox for details", b.Name)
+			}
+			if done(b) {
+				return b, "done", nil
+			}
+			return b, "pending", nil
+		},
+	}
+
+	b, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return b.(*branch), nil
+}
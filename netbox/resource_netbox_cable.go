@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxCable() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxCableCreate,
-		Read:   resourceNetboxCableRead,
-		Update: resourceNetboxCableUpdate,
-		Delete: resourceNetboxCableDelete,
+		CreateContext: resourceNetboxCableCreate,
+		ReadContext:   resourceNetboxCableRead,
+		UpdateContext: resourceNetboxCableUpdate,
+		DeleteContext: resourceNetboxCableDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/cable/):
 
@@ -80,8 +81,10 @@ func resourceNetboxCable() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
-			tagsKey:         tagsSchema,
-			customFieldsKey: customFieldsSchema,
+			tagsKey:             tagsSchema,
+			customFieldsKey:     customFieldsSchema,
+			customFieldsJSONKey: customFieldsJSONSchema,
+			lastUpdatedKey:      lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -89,8 +92,8 @@ func resourceNetboxCable() *schema.Resource {
 	}
 }
 
-func resourceNetboxCableCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCableCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableCable{
 		Status:      d.Get("status").(string),
@@ -110,29 +113,30 @@ func resourceNetboxCableCreate(d *schema.ResourceData, m interface{}) error {
 	bTerminations := d.Get("b_termination").(*schema.Set)
 	data.BTerminations = getGenericObjectsFromSchemaSet(bTerminations)
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
 
-	params := dcim.NewDcimCablesCreateParams().WithData(&data)
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.cable")
+
+	params := dcim.NewDcimCablesCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimCablesCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxCableRead(d, m)
+	return resourceNetboxCableRead(ctx, d, m)
 }
 
-func resourceNetboxCableRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCableRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimCablesReadParams().WithID(id)
+	params := dcim.NewDcimCablesReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimCablesRead(params, nil)
 
@@ -143,7 +147,7 @@ func resourceNetboxCableRead(d *schema.ResourceData, m interface{}) error {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	cable := res.GetPayload()
@@ -178,17 +182,23 @@ func resourceNetboxCableRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("description", cable.Description)
 	d.Set("comments", cable.Comments)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
+	cf := getResourceCustomFields(api, d, res.GetPayload().CustomFields)
 	if cf != nil {
 		d.Set(customFieldsKey, cf)
 	}
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	cfJSON, err := getResourceCustomFieldsJSON(d, res.GetPayload().CustomFields)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set(customFieldsJSONKey, cfJSON)
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, res.GetPayload().Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxCableUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCableUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -210,32 +220,37 @@ func resourceNetboxCableUpdate(d *schema.ResourceData, m interface{}) error {
 	bTerminations := d.Get("b_termination").(*schema.Set)
 	data.BTerminations = getGenericObjectsFromSchemaSet(bTerminations)
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
+
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.cable")
 
-	params := dcim.NewDcimCablesPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimCablesPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimCablesPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimCablesPartialUpdate(params, nil, changedFieldsOnly(api, d, map[string][]string{
+		"a_terminations": {"a_termination"},
+		"b_terminations": {"b_termination"},
+		"color":          {"color_hex"},
+	}))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxCableRead(d, m)
+	return resourceNetboxCableRead(ctx, d, m)
 }
 
-func resourceNetboxCableDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCableDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimCablesDeleteParams().WithID(id)
+	params := dcim.NewDcimCablesDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimCablesDelete(params, nil)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
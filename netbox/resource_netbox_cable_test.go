@@ -204,7 +204,7 @@ resource "netbox_cable" "test" {
 
 func testAccCheckCableDestroy(s *terraform.State) error {
 	// retrieve the connection established in Provider configuration
-	conn := testAccProvider.Meta().(*client.NetBoxAPI)
+	conn := testAccProvider.Meta().(*providerState)
 
 	// loop through the resources in state, verifying each cable
 	// is destroyed
@@ -1,11 +1,12 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/circuits"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -14,10 +15,10 @@ var resourceNetboxCircuitStatusOptions = []string{"planned", "provisioning", "ac
 
 func resourceNetboxCircuit() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxCircuitCreate,
-		Read:   resourceNetboxCircuitRead,
-		Update: resourceNetboxCircuitUpdate,
-		Delete: resourceNetboxCircuitDelete,
+		CreateContext: resourceNetboxCircuitCreate,
+		ReadContext:   resourceNetboxCircuitRead,
+		UpdateContext: resourceNetboxCircuitUpdate,
+		DeleteContext: resourceNetboxCircuitDelete,
 
 		Description: `:meta:subcategory:Circuits:From the [official documentation](https://docs.netbox.dev/en/stable/features/circuits/#circuits_1):
 
@@ -48,6 +49,7 @@ func resourceNetboxCircuit() *schema.Resource {
 				ValidateFunc: validation.StringInSlice(resourceNetboxCircuitStatusOptions, false),
 				Description:  buildValidValueDescription(resourceNetboxCircuitStatusOptions),
 			},
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -55,8 +57,8 @@ func resourceNetboxCircuit() *schema.Resource {
 	}
 }
 
-func resourceNetboxCircuitCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableCircuit{}
 
@@ -82,22 +84,22 @@ func resourceNetboxCircuitCreate(d *schema.ResourceData, m interface{}) error {
 
 	data.Tags = []*models.NestedTag{}
 
-	params := circuits.NewCircuitsCircuitsCreateParams().WithData(&data)
+	params := circuits.NewCircuitsCircuitsCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Circuits.CircuitsCircuitsCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxCircuitRead(d, m)
+	return resourceNetboxCircuitRead(ctx, d, m)
 }
 
-func resourceNetboxCircuitRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := circuits.NewCircuitsCircuitsReadParams().WithID(id)
+	params := circuits.NewCircuitsCircuitsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Circuits.CircuitsCircuitsRead(params, nil)
 
@@ -110,7 +112,7 @@ func resourceNetboxCircuitRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("cid", res.GetPayload().Cid)
@@ -133,12 +135,13 @@ func resourceNetboxCircuitRead(d *schema.ResourceData, m interface{}) error {
 	} else {
 		d.Set("tenant_id", nil)
 	}
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxCircuitUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableCircuit{}
@@ -165,21 +168,21 @@ func resourceNetboxCircuitUpdate(d *schema.ResourceData, m interface{}) error {
 
 	data.Tags = []*models.NestedTag{}
 
-	params := circuits.NewCircuitsCircuitsPartialUpdateParams().WithID(id).WithData(&data)
+	params := circuits.NewCircuitsCircuitsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Circuits.CircuitsCircuitsPartialUpdate(params, nil)
+	_, err := api.Circuits.CircuitsCircuitsPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxCircuitRead(d, m)
+	return resourceNetboxCircuitRead(ctx, d, m)
 }
 
-func resourceNetboxCircuitDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := circuits.NewCircuitsCircuitsDeleteParams().WithID(id)
+	params := circuits.NewCircuitsCircuitsDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Circuits.CircuitsCircuitsDelete(params, nil)
 	if err != nil {
@@ -189,7 +192,7 @@ func resourceNetboxCircuitDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -1,11 +1,12 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/circuits"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -14,10 +15,10 @@ var resourceNetboxCircuitTerminationTermSideOptions = []string{"A", "Z"}
 
 func resourceNetboxCircuitTermination() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxCircuitTerminationCreate,
-		Read:   resourceNetboxCircuitTerminationRead,
-		Update: resourceNetboxCircuitTerminationUpdate,
-		Delete: resourceNetboxCircuitTerminationDelete,
+		CreateContext: resourceNetboxCircuitTerminationCreate,
+		ReadContext:   resourceNetboxCircuitTerminationRead,
+		UpdateContext: resourceNetboxCircuitTerminationUpdate,
+		DeleteContext: resourceNetboxCircuitTerminationDelete,
 
 		Description: `:meta:subcategory:Circuits:From the [official documentation](https://docs.netbox.dev/en/stable/features/circuits/#circuit-terminations):
 
@@ -48,8 +49,10 @@ func resourceNetboxCircuitTermination() *schema.Resource {
 				ValidateFunc: validation.StringInSlice(resourceNetboxCircuitTerminationTermSideOptions, false),
 				Description:  buildValidValueDescription(resourceNetboxCircuitTerminationTermSideOptions),
 			},
-			tagsKey:         tagsSchema,
-			customFieldsKey: customFieldsSchema,
+			tagsKey:             tagsSchema,
+			customFieldsKey:     customFieldsSchema,
+			customFieldsJSONKey: customFieldsJSONSchema,
+			lastUpdatedKey:      lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -57,8 +60,8 @@ func resourceNetboxCircuitTermination() *schema.Resource {
 	}
 }
 
-func resourceNetboxCircuitTerminationCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitTerminationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableCircuitTermination{}
 
@@ -85,29 +88,30 @@ func resourceNetboxCircuitTerminationCreate(d *schema.ResourceData, m interface{
 		data.UpstreamSpeed = int64ToPtr(int64(upstreamspeedValue.(int)))
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
 
-	params := circuits.NewCircuitsCircuitTerminationsCreateParams().WithData(&data)
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "circuits.circuittermination")
+
+	params := circuits.NewCircuitsCircuitTerminationsCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Circuits.CircuitsCircuitTerminationsCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxCircuitTerminationRead(d, m)
+	return resourceNetboxCircuitTerminationRead(ctx, d, m)
 }
 
-func resourceNetboxCircuitTerminationRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitTerminationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := circuits.NewCircuitsCircuitTerminationsReadParams().WithID(id)
+	params := circuits.NewCircuitsCircuitTerminationsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Circuits.CircuitsCircuitTerminationsRead(params, nil)
 
@@ -120,7 +124,7 @@ func resourceNetboxCircuitTerminationRead(d *schema.ResourceData, m interface{})
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	term := res.GetPayload()
@@ -151,18 +155,24 @@ func resourceNetboxCircuitTerminationRead(d *schema.ResourceData, m interface{})
 		d.Set("upstream_speed", nil)
 	}
 
-	d.Set(tagsKey, getTagListFromNestedTagList(term.Tags))
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, term.Tags))
 
-	cf := getCustomFields(term.CustomFields)
+	cf := getResourceCustomFields(api, d, term.CustomFields)
 	if cf != nil {
 		d.Set(customFieldsKey, cf)
 	}
+	cfJSON, err := getResourceCustomFieldsJSON(d, term.CustomFields)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set(customFieldsJSONKey, cfJSON)
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxCircuitTerminationUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitTerminationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableCircuitTermination{}
@@ -190,28 +200,29 @@ func resourceNetboxCircuitTerminationUpdate(d *schema.ResourceData, m interface{
 		data.UpstreamSpeed = int64ToPtr(int64(upstreamspeedValue.(int)))
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	cf, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = cf
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
+
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "circuits.circuittermination")
 
-	params := circuits.NewCircuitsCircuitTerminationsPartialUpdateParams().WithID(id).WithData(&data)
+	params := circuits.NewCircuitsCircuitTerminationsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Circuits.CircuitsCircuitTerminationsPartialUpdate(params, nil)
+	_, err = api.Circuits.CircuitsCircuitTerminationsPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxCircuitTerminationRead(d, m)
+	return resourceNetboxCircuitTerminationRead(ctx, d, m)
 }
 
-func resourceNetboxCircuitTerminationDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitTerminationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := circuits.NewCircuitsCircuitTerminationsDeleteParams().WithID(id)
+	params := circuits.NewCircuitsCircuitTerminationsDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Circuits.CircuitsCircuitTerminationsDelete(params, nil)
 	if err != nil {
@@ -221,7 +232,7 @@ func resourceNetboxCircuitTerminationDelete(d *schema.ResourceData, m interface{
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
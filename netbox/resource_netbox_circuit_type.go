@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/circuits"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxCircuitType() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxCircuitTypeCreate,
-		Read:   resourceNetboxCircuitTypeRead,
-		Update: resourceNetboxCircuitTypeUpdate,
-		Delete: resourceNetboxCircuitTypeDelete,
+		CreateContext: resourceNetboxCircuitTypeCreate,
+		ReadContext:   resourceNetboxCircuitTypeRead,
+		UpdateContext: resourceNetboxCircuitTypeUpdate,
+		DeleteContext: resourceNetboxCircuitTypeDelete,
 
 		Description: `:meta:subcategory:Circuits:From the [official documentation](https://docs.netbox.dev/en/stable/features/circuits/#circuit-types):
 
@@ -32,6 +33,7 @@ func resourceNetboxCircuitType() *schema.Resource {
 				Computed:     true,
 				ValidateFunc: validation.StringLenBetween(1, 100),
 			},
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -39,8 +41,8 @@ func resourceNetboxCircuitType() *schema.Resource {
 	}
 }
 
-func resourceNetboxCircuitTypeCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitTypeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.CircuitType{}
 
@@ -57,22 +59,22 @@ func resourceNetboxCircuitTypeCreate(d *schema.ResourceData, m interface{}) erro
 
 	data.Tags = []*models.NestedTag{}
 
-	params := circuits.NewCircuitsCircuitTypesCreateParams().WithData(&data)
+	params := circuits.NewCircuitsCircuitTypesCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Circuits.CircuitsCircuitTypesCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxCircuitTypeRead(d, m)
+	return resourceNetboxCircuitTypeRead(ctx, d, m)
 }
 
-func resourceNetboxCircuitTypeRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitTypeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := circuits.NewCircuitsCircuitTypesReadParams().WithID(id)
+	params := circuits.NewCircuitsCircuitTypesReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Circuits.CircuitsCircuitTypesRead(params, nil)
 
@@ -85,17 +87,18 @@ func resourceNetboxCircuitTypeRead(d *schema.ResourceData, m interface{}) error
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
 	d.Set("slug", res.GetPayload().Slug)
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxCircuitTypeUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitTypeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.CircuitType{}
@@ -113,21 +116,21 @@ func resourceNetboxCircuitTypeUpdate(d *schema.ResourceData, m interface{}) erro
 
 	data.Tags = []*models.NestedTag{}
 
-	params := circuits.NewCircuitsCircuitTypesPartialUpdateParams().WithID(id).WithData(&data)
+	params := circuits.NewCircuitsCircuitTypesPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Circuits.CircuitsCircuitTypesPartialUpdate(params, nil)
+	_, err := api.Circuits.CircuitsCircuitTypesPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxCircuitTypeRead(d, m)
+	return resourceNetboxCircuitTypeRead(ctx, d, m)
 }
 
-func resourceNetboxCircuitTypeDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitTypeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := circuits.NewCircuitsCircuitTypesDeleteParams().WithID(id)
+	params := circuits.NewCircuitsCircuitTypesDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Circuits.CircuitsCircuitTypesDelete(params, nil)
 	if err != nil {
@@ -137,7 +140,7 @@ func resourceNetboxCircuitTypeDelete(d *schema.ResourceData, m interface{}) erro
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
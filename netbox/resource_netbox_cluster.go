@@ -1,20 +1,21 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/virtualization"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceNetboxCluster() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxClusterCreate,
-		Read:   resourceNetboxClusterRead,
-		Update: resourceNetboxClusterUpdate,
-		Delete: resourceNetboxClusterDelete,
+		CreateContext: resourceNetboxClusterCreate,
+		ReadContext:   resourceNetboxClusterRead,
+		UpdateContext: resourceNetboxClusterUpdate,
+		DeleteContext: resourceNetboxClusterDelete,
 
 		Description: `:meta:subcategory:Virtualization:From the [official documentation](https://docs.netbox.dev/en/stable/features/virtualization/#clusters):
 
@@ -51,7 +52,8 @@ func resourceNetboxCluster() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
-			tagsKey: tagsSchema,
+			tagsKey:        tagsSchema,
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -59,8 +61,8 @@ func resourceNetboxCluster() *schema.Resource {
 	}
 }
 
-func resourceNetboxClusterCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxClusterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableCluster{}
 
@@ -88,26 +90,29 @@ func resourceNetboxClusterCreate(d *schema.ResourceData, m interface{}) error {
 		data.Tenant = &tenantID
 	}
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	data.Tags = tags
 
-	params := virtualization.NewVirtualizationClustersCreateParams().WithData(&data)
+	params := virtualization.NewVirtualizationClustersCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Virtualization.VirtualizationClustersCreate(params, nil)
 	if err != nil {
 		//return errors.New(getTextFromError(err))
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxClusterRead(d, m)
+	return resourceNetboxClusterRead(ctx, d, m)
 }
 
-func resourceNetboxClusterRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxClusterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := virtualization.NewVirtualizationClustersReadParams().WithID(id)
+	params := virtualization.NewVirtualizationClustersReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Virtualization.VirtualizationClustersRead(params, nil)
 	if err != nil {
@@ -119,7 +124,7 @@ func resourceNetboxClusterRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
@@ -146,12 +151,14 @@ func resourceNetboxClusterRead(d *schema.ResourceData, m interface{}) error {
 		d.Set("tenant_id", nil)
 	}
 
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, res.GetPayload().Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
+
 	return nil
 }
 
-func resourceNetboxClusterUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxClusterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableCluster{}
@@ -180,24 +187,30 @@ func resourceNetboxClusterUpdate(d *schema.ResourceData, m interface{}) error {
 		data.Tenant = &tenantID
 	}
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	data.Tags = tags
 
-	params := virtualization.NewVirtualizationClustersPartialUpdateParams().WithID(id).WithData(&data)
+	params := virtualization.NewVirtualizationClustersPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Virtualization.VirtualizationClustersPartialUpdate(params, nil)
+	_, err = api.Virtualization.VirtualizationClustersPartialUpdate(params, nil, changedFieldsOnly(api, d, map[string][]string{
+		"group": {"cluster_group_id"},
+		"type":  {"cluster_type_id"},
+	}))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxClusterRead(d, m)
+	return resourceNetboxClusterRead(ctx, d, m)
 }
 
-func resourceNetboxClusterDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxClusterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := virtualization.NewVirtualizationClustersDeleteParams().WithID(id)
+	params := virtualization.NewVirtualizationClustersDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Virtualization.VirtualizationClustersDelete(params, nil)
 	if err != nil {
@@ -207,7 +220,7 @@ func resourceNetboxClusterDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/virtualization"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxClusterGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxClusterGroupCreate,
-		Read:   resourceNetboxClusterGroupRead,
-		Update: resourceNetboxClusterGroupUpdate,
-		Delete: resourceNetboxClusterGroupDelete,
+		CreateContext: resourceNetboxClusterGroupCreate,
+		ReadContext:   resourceNetboxClusterGroupRead,
+		UpdateContext: resourceNetboxClusterGroupUpdate,
+		DeleteContext: resourceNetboxClusterGroupDelete,
 
 		Description: `:meta:subcategory:Virtualization:From the [official documentation](https://docs.netbox.dev/en/stable/features/virtualization/#cluster-groups):
 
@@ -36,6 +37,7 @@ func resourceNetboxClusterGroup() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -43,8 +45,8 @@ func resourceNetboxClusterGroup() *schema.Resource {
 	}
 }
 
-func resourceNetboxClusterGroupCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxClusterGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.ClusterGroup{}
 
@@ -67,22 +69,22 @@ func resourceNetboxClusterGroupCreate(d *schema.ResourceData, m interface{}) err
 
 	data.Tags = []*models.NestedTag{}
 
-	params := virtualization.NewVirtualizationClusterGroupsCreateParams().WithData(&data)
+	params := virtualization.NewVirtualizationClusterGroupsCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Virtualization.VirtualizationClusterGroupsCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxClusterGroupRead(d, m)
+	return resourceNetboxClusterGroupRead(ctx, d, m)
 }
 
-func resourceNetboxClusterGroupRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxClusterGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := virtualization.NewVirtualizationClusterGroupsReadParams().WithID(id)
+	params := virtualization.NewVirtualizationClusterGroupsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Virtualization.VirtualizationClusterGroupsRead(params, nil)
 	if err != nil {
@@ -94,17 +96,19 @@ func resourceNetboxClusterGroupRead(d *schema.ResourceData, m interface{}) error
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
 	d.Set("slug", res.GetPayload().Slug)
 	d.Set("description", res.GetPayload().Description)
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
+
 	return nil
 }
 
-func resourceNetboxClusterGroupUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxClusterGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.ClusterGroup{}
@@ -133,21 +137,21 @@ func resourceNetboxClusterGroupUpdate(d *schema.ResourceData, m interface{}) err
 
 	data.Tags = []*models.NestedTag{}
 
-	params := virtualization.NewVirtualizationClusterGroupsPartialUpdateParams().WithID(id).WithData(&data)
+	params := virtualization.NewVirtualizationClusterGroupsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Virtualization.VirtualizationClusterGroupsPartialUpdate(params, nil)
+	_, err := api.Virtualization.VirtualizationClusterGroupsPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxClusterGroupRead(d, m)
+	return resourceNetboxClusterGroupRead(ctx, d, m)
 }
 
-func resourceNetboxClusterGroupDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxClusterGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := virtualization.NewVirtualizationClusterGroupsDeleteParams().WithID(id)
+	params := virtualization.NewVirtualizationClusterGroupsDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Virtualization.VirtualizationClusterGroupsDelete(params, nil)
 	if err != nil {
@@ -157,7 +161,7 @@ func resourceNetboxClusterGroupDelete(d *schema.ResourceData, m interface{}) err
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/virtualization"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxClusterType() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxClusterTypeCreate,
-		Read:   resourceNetboxClusterTypeRead,
-		Update: resourceNetboxClusterTypeUpdate,
-		Delete: resourceNetboxClusterTypeDelete,
+		CreateContext: resourceNetboxClusterTypeCreate,
+		ReadContext:   resourceNetboxClusterTypeRead,
+		UpdateContext: resourceNetboxClusterTypeUpdate,
+		DeleteContext: resourceNetboxClusterTypeDelete,
 
 		Description: `:meta:subcategory:Virtualization:From the [official documentation](https://docs.netbox.dev/en/stable/features/virtualization/#cluster-types):
 
@@ -32,6 +33,7 @@ func resourceNetboxClusterType() *schema.Resource {
 				Computed:     true,
 				ValidateFunc: validation.StringLenBetween(1, 100),
 			},
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -39,8 +41,8 @@ func resourceNetboxClusterType() *schema.Resource {
 	}
 }
 
-func resourceNetboxClusterTypeCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxClusterTypeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 	slugValue, slugOk := d.GetOk("slug")
@@ -53,7 +55,7 @@ func resourceNetboxClusterTypeCreate(d *schema.ResourceData, m interface{}) erro
 		slug = slugValue.(string)
 	}
 
-	params := virtualization.NewVirtualizationClusterTypesCreateParams().WithData(
+	params := virtualization.NewVirtualizationClusterTypesCreateParams().WithContext(ctx).WithData(
 		&models.ClusterType{
 			Name: &name,
 			Slug: &slug,
@@ -64,18 +66,18 @@ func resourceNetboxClusterTypeCreate(d *schema.ResourceData, m interface{}) erro
 	res, err := api.Virtualization.VirtualizationClusterTypesCreate(params, nil)
 	if err != nil {
 		//return errors.New(getTextFromError(err))
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxClusterTypeRead(d, m)
+	return resourceNetboxClusterTypeRead(ctx, d, m)
 }
 
-func resourceNetboxClusterTypeRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxClusterTypeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := virtualization.NewVirtualizationClusterTypesReadParams().WithID(id)
+	params := virtualization.NewVirtualizationClusterTypesReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Virtualization.VirtualizationClusterTypesRead(params, nil)
 	if err != nil {
@@ -87,16 +89,18 @@ func resourceNetboxClusterTypeRead(d *schema.ResourceData, m interface{}) error
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
 	d.Set("slug", res.GetPayload().Slug)
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
+
 	return nil
 }
 
-func resourceNetboxClusterTypeUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxClusterTypeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.ClusterType{}
@@ -116,21 +120,21 @@ func resourceNetboxClusterTypeUpdate(d *schema.ResourceData, m interface{}) erro
 	data.Name = &name
 	data.Tags = []*models.NestedTag{}
 
-	params := virtualization.NewVirtualizationClusterTypesPartialUpdateParams().WithID(id).WithData(&data)
+	params := virtualization.NewVirtualizationClusterTypesPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Virtualization.VirtualizationClusterTypesPartialUpdate(params, nil)
+	_, err := api.Virtualization.VirtualizationClusterTypesPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxClusterTypeRead(d, m)
+	return resourceNetboxClusterTypeRead(ctx, d, m)
 }
 
-func resourceNetboxClusterTypeDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxClusterTypeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := virtualization.NewVirtualizationClusterTypesDeleteParams().WithID(id)
+	params := virtualization.NewVirtualizationClusterTypesDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Virtualization.VirtualizationClusterTypesDelete(params, nil)
 	if err != nil {
@@ -140,7 +144,7 @@ func resourceNetboxClusterTypeDelete(d *schema.ResourceData, m interface{}) erro
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -1,22 +1,23 @@
 package netbox
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/extras"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxConfigContext() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxConfigContextCreate,
-		Read:   resourceNetboxConfigContextRead,
-		Update: resourceNetboxConfigContextUpdate,
-		Delete: resourceNetboxConfigContextDelete,
+		CreateContext: resourceNetboxConfigContextCreate,
+		ReadContext:   resourceNetboxConfigContextRead,
+		UpdateContext: resourceNetboxConfigContextUpdate,
+		DeleteContext: resourceNetboxConfigContextDelete,
 
 		Description: `:meta:subcategory:Extras:From the [official documentation](https://docs.netbox.dev/en/stable/models/extras/configcontext/):
 
@@ -145,6 +146,7 @@ func resourceNetboxConfigContext() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -152,8 +154,8 @@ func resourceNetboxConfigContext() *schema.Resource {
 	}
 }
 
-func resourceNetboxConfigContextCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxConfigContextCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	data := models.WritableConfigContext{}
 	data.Name = strToPtr(d.Get("name").(string))
 
@@ -181,23 +183,23 @@ func resourceNetboxConfigContextCreate(d *schema.ResourceData, m interface{}) er
 	data.Tags = toStringList(d.Get("tags"))
 	data.Weight = int64ToPtr(int64(d.Get("weight").(int)))
 
-	params := extras.NewExtrasConfigContextsCreateParams().WithData(&data)
+	params := extras.NewExtrasConfigContextsCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Extras.ExtrasConfigContextsCreate(params, nil)
 	if err != nil {
 		//return errors.New(getTextFromError(err))
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxConfigContextRead(d, m)
+	return resourceNetboxConfigContextRead(ctx, d, m)
 }
 
-func resourceNetboxConfigContextRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxConfigContextRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := extras.NewExtrasConfigContextsReadParams().WithID(id)
+	params := extras.NewExtrasConfigContextsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Extras.ExtrasConfigContextsRead(params, nil)
 
@@ -208,7 +210,7 @@ func resourceNetboxConfigContextRead(d *schema.ResourceData, m interface{}) erro
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
@@ -313,12 +315,13 @@ func resourceNetboxConfigContextRead(d *schema.ResourceData, m interface{}) erro
 		tenantsSlice[i] = int64(v.ID)
 	}
 	d.Set("tenants", tenantsSlice)
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxConfigContextUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxConfigContextUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -351,25 +354,25 @@ func resourceNetboxConfigContextUpdate(d *schema.ResourceData, m interface{}) er
 	data.Tags = toStringList(d.Get("tags"))
 	data.Weight = int64ToPtr(int64(d.Get("weight").(int)))
 
-	params := extras.NewExtrasConfigContextsPartialUpdateParams().WithID(id).WithData(&data)
+	params := extras.NewExtrasConfigContextsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Extras.ExtrasConfigContextsPartialUpdate(params, nil)
+	_, err := api.Extras.ExtrasConfigContextsPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxConfigContextRead(d, m)
+	return resourceNetboxConfigContextRead(ctx, d, m)
 }
 
-func resourceNetboxConfigContextDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxConfigContextDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := extras.NewExtrasConfigContextsDeleteParams().WithID(id)
+	params := extras.NewExtrasConfigContextsDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Extras.ExtrasConfigContextsDelete(params, nil)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
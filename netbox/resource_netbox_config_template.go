@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/extras"
 	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -45,7 +44,8 @@ func resourceNetboxConfigTemplate() *schema.Resource {
 				Default:      "{}",
 				ValidateFunc: validation.StringIsJSON,
 			},
-			tagsKey: tagsSchema,
+			tagsKey:        tagsSchema,
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -54,7 +54,7 @@ func resourceNetboxConfigTemplate() *schema.Resource {
 }
 
 func resourceNetboxConfigTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	var diags diag.Diagnostics
 
@@ -62,7 +62,10 @@ func resourceNetboxConfigTemplateCreate(ctx context.Context, d *schema.ResourceD
 	description := d.Get("description").(string)
 	templateCode := d.Get("template_code").(string)
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	data := models.WritableConfigTemplate{
 		Name:         &name,
@@ -84,11 +87,11 @@ func resourceNetboxConfigTemplateCreate(ctx context.Context, d *schema.ResourceD
 		data.EnvironmentParams = environmentParams
 	}
 
-	params := extras.NewExtrasConfigTemplatesCreateParams().WithData(&data)
+	params := extras.NewExtrasConfigTemplatesCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Extras.ExtrasConfigTemplatesCreate(params, nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
@@ -97,12 +100,12 @@ func resourceNetboxConfigTemplateCreate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceNetboxConfigTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
 	var diags diag.Diagnostics
 
-	params := extras.NewExtrasConfigTemplatesReadParams().WithID(id)
+	params := extras.NewExtrasConfigTemplatesReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Extras.ExtrasConfigTemplatesRead(params, nil)
 	if err != nil {
@@ -133,12 +136,13 @@ func resourceNetboxConfigTemplateRead(ctx context.Context, d *schema.ResourceDat
 	} else {
 		d.Set("environment_params", "{}")
 	}
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return diags
 }
 
 func resourceNetboxConfigTemplateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	var diags diag.Diagnostics
 
@@ -148,7 +152,10 @@ func resourceNetboxConfigTemplateUpdate(ctx context.Context, d *schema.ResourceD
 	description := d.Get("description").(string)
 	templateCode := d.Get("template_code").(string)
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	data := models.WritableConfigTemplate{
 		Name:         &name,
@@ -170,20 +177,20 @@ func resourceNetboxConfigTemplateUpdate(ctx context.Context, d *schema.ResourceD
 		data.EnvironmentParams = environmentParams
 	}
 
-	params := extras.NewExtrasConfigTemplatesUpdateParams().WithID(id).WithData(&data)
-	_, err := api.Extras.ExtrasConfigTemplatesUpdate(params, nil)
+	params := extras.NewExtrasConfigTemplatesPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
+	_, err = api.Extras.ExtrasConfigTemplatesPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return diag.FromErr(err)
+		return netboxErrorDiagnostics(err)
 	}
 
 	return diags
 }
 
 func resourceNetboxConfigTemplateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := extras.NewExtrasConfigTemplatesDeleteParams().WithID(id)
+	params := extras.NewExtrasConfigTemplatesDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Extras.ExtrasConfigTemplatesDelete(params, nil)
 	if err != nil {
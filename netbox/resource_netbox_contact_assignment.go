@@ -1,11 +1,12 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/tenancy"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -14,10 +15,10 @@ var resourceNetboxContactAssignmentPriorityOptions = []string{"primary", "second
 
 func resourceNetboxContactAssignment() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxContactAssignmentCreate,
-		Read:   resourceNetboxContactAssignmentRead,
-		Update: resourceNetboxContactAssignmentUpdate,
-		Delete: resourceNetboxContactAssignmentDelete,
+		CreateContext: resourceNetboxContactAssignmentCreate,
+		ReadContext:   resourceNetboxContactAssignmentRead,
+		UpdateContext: resourceNetboxContactAssignmentUpdate,
+		DeleteContext: resourceNetboxContactAssignmentDelete,
 
 		Description: `:meta:subcategory:Tenancy:From the [official documentation](https://docs.netbox.dev/en/stable/features/contacts#contactassignments_1):
 
@@ -46,6 +47,7 @@ func resourceNetboxContactAssignment() *schema.Resource {
 				ValidateFunc: validation.StringInSlice(resourceNetboxContactAssignmentPriorityOptions, false),
 				Description:  buildValidValueDescription(resourceNetboxContactAssignmentPriorityOptions),
 			},
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -53,8 +55,8 @@ func resourceNetboxContactAssignment() *schema.Resource {
 	}
 }
 
-func resourceNetboxContactAssignmentCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxContactAssignmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	contentType := d.Get("content_type").(string)
 	objectID := int64(d.Get("object_id").(int))
@@ -70,22 +72,22 @@ func resourceNetboxContactAssignmentCreate(d *schema.ResourceData, m interface{}
 	data.Role = &roleID
 	data.Priority = priority
 
-	params := tenancy.NewTenancyContactAssignmentsCreateParams().WithData(data)
+	params := tenancy.NewTenancyContactAssignmentsCreateParams().WithContext(ctx).WithData(data)
 
 	res, err := api.Tenancy.TenancyContactAssignmentsCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxContactAssignmentRead(d, m)
+	return resourceNetboxContactAssignmentRead(ctx, d, m)
 }
 
-func resourceNetboxContactAssignmentRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxContactAssignmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := tenancy.NewTenancyContactAssignmentsReadParams().WithID(id)
+	params := tenancy.NewTenancyContactAssignmentsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Tenancy.TenancyContactAssignmentsRead(params, nil)
 	if err != nil {
@@ -97,7 +99,7 @@ func resourceNetboxContactAssignmentRead(d *schema.ResourceData, m interface{})
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("content_type", res.GetPayload().ObjectType)
@@ -114,12 +116,13 @@ func resourceNetboxContactAssignmentRead(d *schema.ResourceData, m interface{})
 	if res.GetPayload().Priority != nil {
 		d.Set("priority", res.GetPayload().Priority.Value)
 	}
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxContactAssignmentUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxContactAssignmentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableContactAssignment{}
@@ -142,21 +145,21 @@ func resourceNetboxContactAssignmentUpdate(d *schema.ResourceData, m interface{}
 	}
 	data.Priority = priority
 
-	params := tenancy.NewTenancyContactAssignmentsPartialUpdateParams().WithID(id).WithData(&data)
+	params := tenancy.NewTenancyContactAssignmentsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Tenancy.TenancyContactAssignmentsPartialUpdate(params, nil)
+	_, err := api.Tenancy.TenancyContactAssignmentsPartialUpdate(params, nil, changedFieldsOnly(api, d, map[string][]string{"object_type": {"content_type"}}))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxContactAssignmentRead(d, m)
+	return resourceNetboxContactAssignmentRead(ctx, d, m)
 }
 
-func resourceNetboxContactAssignmentDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxContactAssignmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := tenancy.NewTenancyContactAssignmentsDeleteParams().WithID(id)
+	params := tenancy.NewTenancyContactAssignmentsDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Tenancy.TenancyContactAssignmentsDelete(params, nil)
 	if err != nil {
@@ -166,7 +169,7 @@ func resourceNetboxContactAssignmentDelete(d *schema.ResourceData, m interface{}
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/tenancy"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxContactRole() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxContactRoleCreate,
-		Read:   resourceNetboxContactRoleRead,
-		Update: resourceNetboxContactRoleUpdate,
-		Delete: resourceNetboxContactRoleDelete,
+		CreateContext: resourceNetboxContactRoleCreate,
+		ReadContext:   resourceNetboxContactRoleRead,
+		UpdateContext: resourceNetboxContactRoleUpdate,
+		DeleteContext: resourceNetboxContactRoleDelete,
 
 		Description: `:meta:subcategory:Tenancy:From the [official documentation](https://docs.netbox.dev/en/stable/features/contacts/#contactroles):
 
@@ -32,6 +33,7 @@ func resourceNetboxContactRole() *schema.Resource {
 				Computed:     true,
 				ValidateFunc: validation.StringLenBetween(1, 100),
 			},
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -39,8 +41,8 @@ func resourceNetboxContactRole() *schema.Resource {
 	}
 }
 
-func resourceNetboxContactRoleCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxContactRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 
@@ -57,22 +59,22 @@ func resourceNetboxContactRoleCreate(d *schema.ResourceData, m interface{}) erro
 	data.Name = &name
 	data.Tags = []*models.NestedTag{}
 
-	params := tenancy.NewTenancyContactRolesCreateParams().WithData(data)
+	params := tenancy.NewTenancyContactRolesCreateParams().WithContext(ctx).WithData(data)
 
 	res, err := api.Tenancy.TenancyContactRolesCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxContactRoleRead(d, m)
+	return resourceNetboxContactRoleRead(ctx, d, m)
 }
 
-func resourceNetboxContactRoleRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxContactRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := tenancy.NewTenancyContactRolesReadParams().WithID(id)
+	params := tenancy.NewTenancyContactRolesReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Tenancy.TenancyContactRolesRead(params, nil)
 
@@ -85,18 +87,19 @@ func resourceNetboxContactRoleRead(d *schema.ResourceData, m interface{}) error
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	contactrole := res.GetPayload()
 	d.Set("name", contactrole.Name)
 	d.Set("slug", contactrole.Slug)
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxContactRoleUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxContactRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.ContactRole{}
@@ -113,21 +116,21 @@ func resourceNetboxContactRoleUpdate(d *schema.ResourceData, m interface{}) erro
 	data.Name = &name
 	data.Tags = []*models.NestedTag{}
 
-	params := tenancy.NewTenancyContactRolesPartialUpdateParams().WithID(id).WithData(&data)
+	params := tenancy.NewTenancyContactRolesPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Tenancy.TenancyContactRolesPartialUpdate(params, nil)
+	_, err := api.Tenancy.TenancyContactRolesPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxContactRoleRead(d, m)
+	return resourceNetboxContactRoleRead(ctx, d, m)
 }
 
-func resourceNetboxContactRoleDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxContactRoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := tenancy.NewTenancyContactRolesDeleteParams().WithID(id)
+	params := tenancy.NewTenancyContactRolesDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Tenancy.TenancyContactRolesDelete(params, nil)
 	if err != nil {
@@ -137,7 +140,7 @@ func resourceNetboxContactRoleDelete(d *schema.ResourceData, m interface{}) erro
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/extras"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceCustomField() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxCustomFieldCreate,
-		Read:   resourceNetboxCustomFieldRead,
-		Update: resourceNetboxCustomFieldUpdate,
-		Delete: resourceNetboxCustomFieldDelete,
+		CreateContext: resourceNetboxCustomFieldCreate,
+		ReadContext:   resourceNetboxCustomFieldRead,
+		UpdateContext: resourceNetboxCustomFieldUpdate,
+		DeleteContext: resourceNetboxCustomFieldDelete,
 
 		Description: `:meta:subcategory:Extras:From the [official documentation](https://docs.netbox.dev/en/stable/customization/custom-fields/#custom-fields):
 
@@ -93,6 +94,7 @@ func resourceCustomField() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -100,8 +102,8 @@ func resourceCustomField() *schema.Resource {
 	}
 }
 
-func resourceNetboxCustomFieldUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCustomFieldUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -140,19 +142,19 @@ func resourceNetboxCustomFieldUpdate(d *schema.ResourceData, m interface{}) erro
 		data.ValidationMinimum = int64ToPtr(int64(vmin.(int)))
 	}
 
-	params := extras.NewExtrasCustomFieldsUpdateParams().WithID(id).WithData(data)
-	res, err := api.Extras.ExtrasCustomFieldsUpdate(params, nil)
+	params := extras.NewExtrasCustomFieldsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(data)
+	res, err := api.Extras.ExtrasCustomFieldsPartialUpdate(params, nil, changedFieldsOnly(api, d, map[string][]string{"object_types": {"content_types"}}))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxCustomFieldRead(d, m)
+	return resourceNetboxCustomFieldRead(ctx, d, m)
 }
 
-func resourceNetboxCustomFieldCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCustomFieldCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := &models.WritableCustomField{
 		Name:            strToPtr(d.Get("name").(string)),
@@ -189,35 +191,35 @@ func resourceNetboxCustomFieldCreate(d *schema.ResourceData, m interface{}) erro
 		data.ValidationMinimum = int64ToPtr(int64(vmin.(int)))
 	}
 
-	params := extras.NewExtrasCustomFieldsCreateParams().WithData(data)
+	params := extras.NewExtrasCustomFieldsCreateParams().WithContext(ctx).WithData(data)
 
 	res, err := api.Extras.ExtrasCustomFieldsCreate(params, nil)
 	if err != nil {
 		//return errors.New(getTextFromError(err))
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxCustomFieldRead(d, m)
+	return resourceNetboxCustomFieldRead(ctx, d, m)
 }
 
-func resourceNetboxCustomFieldRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCustomFieldRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := extras.NewExtrasCustomFieldsReadParams().WithID(id)
+	params := extras.NewExtrasCustomFieldsReadParams().WithContext(ctx).WithID(id)
 	res, err := api.Extras.ExtrasCustomFieldsRead(params, nil)
 	if err != nil {
 		errapi, ok := err.(*extras.ExtrasCustomFieldsReadDefault)
 		if !ok {
-			return err
+			return diag.FromErr(err)
 		}
 		errorcode := errapi.Code()
 		if errorcode == 404 {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	customField := res.GetPayload()
@@ -244,14 +246,15 @@ func resourceNetboxCustomFieldRead(d *schema.ResourceData, m interface{}) error
 	d.Set("validation_maximum", customField.ValidationMaximum)
 	d.Set("validation_minimum", customField.ValidationMinimum)
 	d.Set("validation_regex", customField.ValidationRegex)
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxCustomFieldDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCustomFieldDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := extras.NewExtrasCustomFieldsDeleteParams().WithID(id)
+	params := extras.NewExtrasCustomFieldsDeleteParams().WithContext(ctx).WithID(id)
 	_, err := api.Extras.ExtrasCustomFieldsDelete(params, nil)
 	if err != nil {
 		if errresp, ok := err.(*extras.ExtrasCustomFieldsDeleteDefault); ok {
@@ -260,7 +263,7 @@ func resourceNetboxCustomFieldDelete(d *schema.ResourceData, m interface{}) erro
 				d.SetId("")
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -1,12 +1,12 @@
 package netbox
 
 import (
-	"errors"
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/extras"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -15,10 +15,10 @@ var resourceNetboxCustomFieldChoiceSetBaseChoicesOptions = []string{"IATA", "ISO
 
 func resourceNetboxCustomFieldChoiceSet() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxCustomFieldChoiceSetCreate,
-		Read:   resourceNetboxCustomFieldChoiceSetRead,
-		Update: resourceNetboxCustomFieldChoiceSetUpdate,
-		Delete: resourceNetboxCustomFieldChoiceSetDelete,
+		CreateContext: resourceNetboxCustomFieldChoiceSetCreate,
+		ReadContext:   resourceNetboxCustomFieldChoiceSetRead,
+		UpdateContext: resourceNetboxCustomFieldChoiceSetUpdate,
+		DeleteContext: resourceNetboxCustomFieldChoiceSetDelete,
 
 		Description: `:meta:subcategory:Extras:From the [official documentation](https://docs.netbox.dev/en/stable/models/extras/customfieldchoiceset/):
 
@@ -77,6 +77,7 @@ A choice set must define a base choice set and/or a set of arbitrary extra choic
 				Default:     false,
 			},
 			customFieldsKey: customFieldsSchema,
+			lastUpdatedKey:  lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -84,8 +85,8 @@ A choice set must define a base choice set and/or a set of arbitrary extra choic
 	}
 }
 
-func resourceNetboxCustomFieldChoiceSetCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCustomFieldChoiceSetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 
@@ -102,29 +103,29 @@ func resourceNetboxCustomFieldChoiceSetCreate(d *schema.ResourceData, m interfac
 		for _, innerList := range extraChoices.([]interface{}) {
 			tmp := innerList.([]interface{})
 			if len(tmp) != 2 {
-				return errors.New("length of inner lists must be exactly two for custom field choice sets")
+				return diag.Errorf("length of inner lists must be exactly two for custom field choice sets")
 			}
 			extraChoiceListList = append(extraChoiceListList, []string{tmp[0].(string), tmp[1].(string)})
 		}
 		data.ExtraChoices = extraChoiceListList
 	}
 
-	params := extras.NewExtrasCustomFieldChoiceSetsCreateParams().WithData(&data)
+	params := extras.NewExtrasCustomFieldChoiceSetsCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Extras.ExtrasCustomFieldChoiceSetsCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxCustomFieldChoiceSetRead(d, m)
+	return resourceNetboxCustomFieldChoiceSetRead(ctx, d, m)
 }
 
-func resourceNetboxCustomFieldChoiceSetRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCustomFieldChoiceSetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := extras.NewExtrasCustomFieldChoiceSetsReadParams().WithID(id)
+	params := extras.NewExtrasCustomFieldChoiceSetsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Extras.ExtrasCustomFieldChoiceSetsRead(params, nil)
 
@@ -137,7 +138,7 @@ func resourceNetboxCustomFieldChoiceSetRead(d *schema.ResourceData, m interface{
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	choiceSet := res.GetPayload()
@@ -149,12 +150,13 @@ func resourceNetboxCustomFieldChoiceSetRead(d *schema.ResourceData, m interface{
 	} else {
 		d.Set("description", nil)
 	}
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxCustomFieldChoiceSetUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCustomFieldChoiceSetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -173,28 +175,28 @@ func resourceNetboxCustomFieldChoiceSetUpdate(d *schema.ResourceData, m interfac
 		for _, innerList := range extraChoices.([]interface{}) {
 			tmp := innerList.([]interface{})
 			if len(tmp) != 2 {
-				return errors.New("length of inner lists must be exactly two for custom field choice sets")
+				return diag.Errorf("length of inner lists must be exactly two for custom field choice sets")
 			}
 			extraChoiceListList = append(extraChoiceListList, []string{tmp[0].(string), tmp[1].(string)})
 		}
 		data.ExtraChoices = extraChoiceListList
 	}
 
-	params := extras.NewExtrasCustomFieldChoiceSetsPartialUpdateParams().WithID(id).WithData(&data)
+	params := extras.NewExtrasCustomFieldChoiceSetsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Extras.ExtrasCustomFieldChoiceSetsPartialUpdate(params, nil)
+	_, err := api.Extras.ExtrasCustomFieldChoiceSetsPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxCustomFieldChoiceSetRead(d, m)
+	return resourceNetboxCustomFieldChoiceSetRead(ctx, d, m)
 }
 
-func resourceNetboxCustomFieldChoiceSetDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCustomFieldChoiceSetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := extras.NewExtrasCustomFieldChoiceSetsDeleteParams().WithID(id)
+	params := extras.NewExtrasCustomFieldChoiceSetsDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Extras.ExtrasCustomFieldChoiceSetsDelete(params, nil)
 	if err != nil {
@@ -204,7 +206,7 @@ func resourceNetboxCustomFieldChoiceSetDelete(d *schema.ResourceData, m interfac
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -134,7 +133,9 @@ func resourceNetboxDevice() *schema.Resource {
 				Optional:    true,
 				Description: "This is best managed through the use of `jsonencode` and a map of settings.",
 			},
-			customFieldsKey: customFieldsSchema,
+			customFieldsKey:     customFieldsSchema,
+			customFieldsJSONKey: customFieldsJSONSchema,
+			lastUpdatedKey:      lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -143,7 +144,7 @@ func resourceNetboxDevice() *schema.Resource {
 }
 
 func resourceNetboxDeviceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 
@@ -235,18 +236,19 @@ func resourceNetboxDeviceCreate(ctx context.Context, d *schema.ResourceData, m i
 		}
 	}
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
-	}
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.device")
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.Tags = tags
 
-	params := dcim.NewDcimDevicesCreateParams().WithData(&data)
+	params := dcim.NewDcimDevicesCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimDevicesCreate(params, nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
@@ -254,9 +256,9 @@ func resourceNetboxDeviceCreate(ctx context.Context, d *schema.ResourceData, m i
 	if vcMaster, ok := d.GetOk("virtual_chassis_master"); ok {
 		var err error
 		if vcMaster.(bool) {
-			err = virtualChassisUpdateMaster(api, *data.VirtualChassis, &(res.GetPayload().ID))
+			err = virtualChassisUpdateMaster(ctx, api, *data.VirtualChassis, &(res.GetPayload().ID))
 		} else {
-			err = virtualChassisUpdateMaster(api, *data.VirtualChassis, nil)
+			err = virtualChassisUpdateMaster(ctx, api, *data.VirtualChassis, nil)
 		}
 		if err != nil {
 			return diag.FromErr(err)
@@ -267,13 +269,13 @@ func resourceNetboxDeviceCreate(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceNetboxDeviceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	var diags diag.Diagnostics
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
-	params := dcim.NewDcimDevicesReadParams().WithID(id)
+	params := dcim.NewDcimDevicesReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimDevicesRead(params, nil)
 	if err != nil {
@@ -350,10 +352,15 @@ func resourceNetboxDeviceRead(ctx context.Context, d *schema.ResourceData, m int
 		d.Set("config_template_id", nil)
 	}
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
+	cf := getResourceCustomFields(api, d, res.GetPayload().CustomFields)
 	if cf != nil {
 		d.Set(customFieldsKey, cf)
 	}
+	cfJSON, err := getResourceCustomFieldsJSON(d, res.GetPayload().CustomFields)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set(customFieldsJSONKey, cfJSON)
 
 	d.Set("asset_tag", device.AssetTag)
 
@@ -400,12 +407,14 @@ func resourceNetboxDeviceRead(ctx context.Context, d *schema.ResourceData, m int
 		d.Set("local_context_data", nil)
 	}
 
-	d.Set(tagsKey, getTagListFromNestedTagList(device.Tags))
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, device.Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
+
 	return diags
 }
 
 func resourceNetboxDeviceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableDeviceWithConfigContext{}
@@ -481,12 +490,13 @@ func resourceNetboxDeviceUpdate(ctx context.Context, d *schema.ResourceData, m i
 		}
 	}
 
-	cf, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = cf
-	}
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.device")
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.Tags = tags
 
 	if d.HasChanges("asset_tag") {
 		if assetTagValue, ok := d.GetOk("asset_tag"); ok {
@@ -528,24 +538,29 @@ func resourceNetboxDeviceUpdate(ctx context.Context, d *schema.ResourceData, m i
 		data.Serial = serial
 	}
 
-	params := dcim.NewDcimDevicesUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimDevicesPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimDevicesUpdate(params, nil)
+	_, err = api.Dcim.DcimDevicesPartialUpdate(params, nil, changedFieldsOnly(api, d, map[string][]string{
+		"face":        {"rack_face"},
+		"position":    {"rack_position"},
+		"vc_position": {"virtual_chassis_position"},
+		"vc_priority": {"virtual_chassis_priority"},
+	}))
 	if err != nil {
-		return diag.FromErr(err)
+		return netboxErrorDiagnostics(err)
 	}
 
 	if d.HasChange("virtual_chassis_master") && data.VirtualChassis != nil {
 		var err error
 		if vcMaster, ok := d.GetOk("virtual_chassis_master"); ok {
 			if vcMaster.(bool) {
-				err = virtualChassisUpdateMaster(api, *data.VirtualChassis, &id)
+				err = virtualChassisUpdateMaster(ctx, api, *data.VirtualChassis, &id)
 			} else {
-				err = virtualChassisUpdateMaster(api, *data.VirtualChassis, nil)
+				err = virtualChassisUpdateMaster(ctx, api, *data.VirtualChassis, nil)
 			}
 		} else {
 			// It was set before, but no longer set, remove it as master
-			err = virtualChassisUpdateMaster(api, *data.VirtualChassis, nil)
+			err = virtualChassisUpdateMaster(ctx, api, *data.VirtualChassis, nil)
 		}
 		if err != nil {
 			return diag.FromErr(err)
@@ -556,7 +571,7 @@ func resourceNetboxDeviceUpdate(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceNetboxDeviceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	var diags diag.Diagnostics
 
@@ -567,14 +582,14 @@ func resourceNetboxDeviceDelete(ctx context.Context, d *schema.ResourceData, m i
 	if virtualChassisIDValue, ok := d.GetOk("virtual_chassis_id"); ok {
 		if d.Get("virtual_chassis_master").(bool) {
 			virtualChassisID := int64(virtualChassisIDValue.(int))
-			err := virtualChassisUpdateMaster(api, virtualChassisID, nil)
+			err := virtualChassisUpdateMaster(ctx, api, virtualChassisID, nil)
 			if err != nil {
 				return diag.FromErr(err)
 			}
 		}
 	}
 
-	params := dcim.NewDcimDevicesDeleteParams().WithID(id)
+	params := dcim.NewDcimDevicesDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimDevicesDelete(params, nil)
 	if err != nil {
@@ -1,20 +1,21 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceNetboxDeviceConsolePort() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxDeviceConsolePortCreate,
-		Read:   resourceNetboxDeviceConsolePortRead,
-		Update: resourceNetboxDeviceConsolePortUpdate,
-		Delete: resourceNetboxDeviceConsolePortDelete,
+		CreateContext: resourceNetboxDeviceConsolePortCreate,
+		ReadContext:   resourceNetboxDeviceConsolePortRead,
+		UpdateContext: resourceNetboxDeviceConsolePortUpdate,
+		DeleteContext: resourceNetboxDeviceConsolePortDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/consoleport/):
 
@@ -56,8 +57,10 @@ func resourceNetboxDeviceConsolePort() *schema.Resource {
 				Default:  false,
 				Optional: true,
 			},
-			tagsKey:         tagsSchema,
-			customFieldsKey: customFieldsSchema,
+			tagsKey:             tagsSchema,
+			customFieldsKey:     customFieldsSchema,
+			customFieldsJSONKey: customFieldsJSONSchema,
+			lastUpdatedKey:      lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -65,8 +68,8 @@ func resourceNetboxDeviceConsolePort() *schema.Resource {
 	}
 }
 
-func resourceNetboxDeviceConsolePortCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceConsolePortCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableConsolePort{
 		Device:        int64ToPtr(int64(d.Get("device_id").(int))),
@@ -79,29 +82,30 @@ func resourceNetboxDeviceConsolePortCreate(d *schema.ResourceData, m interface{}
 		MarkConnected: d.Get("mark_connected").(bool),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
 
-	params := dcim.NewDcimConsolePortsCreateParams().WithData(&data)
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.consoleport")
+
+	params := dcim.NewDcimConsolePortsCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimConsolePortsCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxDeviceConsolePortRead(d, m)
+	return resourceNetboxDeviceConsolePortRead(ctx, d, m)
 }
 
-func resourceNetboxDeviceConsolePortRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceConsolePortRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimConsolePortsReadParams().WithID(id)
+	params := dcim.NewDcimConsolePortsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimConsolePortsRead(params, nil)
 
@@ -111,7 +115,7 @@ func resourceNetboxDeviceConsolePortRead(d *schema.ResourceData, m interface{})
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	consolePort := res.GetPayload()
@@ -147,17 +151,23 @@ func resourceNetboxDeviceConsolePortRead(d *schema.ResourceData, m interface{})
 	d.Set("description", consolePort.Description)
 	d.Set("mark_connected", consolePort.MarkConnected)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
+	cf := getResourceCustomFields(api, d, res.GetPayload().CustomFields)
 	if cf != nil {
 		d.Set(customFieldsKey, cf)
 	}
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	cfJSON, err := getResourceCustomFieldsJSON(d, res.GetPayload().CustomFields)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set(customFieldsJSONKey, cfJSON)
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, res.GetPayload().Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxDeviceConsolePortUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceConsolePortUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -172,32 +182,33 @@ func resourceNetboxDeviceConsolePortUpdate(d *schema.ResourceData, m interface{}
 		MarkConnected: d.Get("mark_connected").(bool),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
+
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.consoleport")
 
-	params := dcim.NewDcimConsolePortsPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimConsolePortsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimConsolePortsPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimConsolePortsPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxDeviceConsolePortRead(d, m)
+	return resourceNetboxDeviceConsolePortRead(ctx, d, m)
 }
 
-func resourceNetboxDeviceConsolePortDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceConsolePortDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimConsolePortsDeleteParams().WithID(id)
+	params := dcim.NewDcimConsolePortsDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimConsolePortsDelete(params, nil)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -1,20 +1,21 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceNetboxDeviceConsoleServerPort() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxDeviceConsoleServerPortCreate,
-		Read:   resourceNetboxDeviceConsoleServerPortRead,
-		Update: resourceNetboxDeviceConsoleServerPortUpdate,
-		Delete: resourceNetboxDeviceConsoleServerPortDelete,
+		CreateContext: resourceNetboxDeviceConsoleServerPortCreate,
+		ReadContext:   resourceNetboxDeviceConsoleServerPortRead,
+		UpdateContext: resourceNetboxDeviceConsoleServerPortUpdate,
+		DeleteContext: resourceNetboxDeviceConsoleServerPortDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/consoleserverport/):
 
@@ -56,8 +57,10 @@ func resourceNetboxDeviceConsoleServerPort() *schema.Resource {
 				Default:  false,
 				Optional: true,
 			},
-			tagsKey:         tagsSchema,
-			customFieldsKey: customFieldsSchema,
+			tagsKey:             tagsSchema,
+			customFieldsKey:     customFieldsSchema,
+			customFieldsJSONKey: customFieldsJSONSchema,
+			lastUpdatedKey:      lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -65,8 +68,8 @@ func resourceNetboxDeviceConsoleServerPort() *schema.Resource {
 	}
 }
 
-func resourceNetboxDeviceConsoleServerPortCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceConsoleServerPortCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableConsoleServerPort{
 		Device:        int64ToPtr(int64(d.Get("device_id").(int))),
@@ -79,29 +82,30 @@ func resourceNetboxDeviceConsoleServerPortCreate(d *schema.ResourceData, m inter
 		MarkConnected: d.Get("mark_connected").(bool),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
 
-	params := dcim.NewDcimConsoleServerPortsCreateParams().WithData(&data)
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.consoleserverport")
+
+	params := dcim.NewDcimConsoleServerPortsCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimConsoleServerPortsCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxDeviceConsoleServerPortRead(d, m)
+	return resourceNetboxDeviceConsoleServerPortRead(ctx, d, m)
 }
 
-func resourceNetboxDeviceConsoleServerPortRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceConsoleServerPortRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimConsoleServerPortsReadParams().WithID(id)
+	params := dcim.NewDcimConsoleServerPortsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimConsoleServerPortsRead(params, nil)
 
@@ -111,7 +115,7 @@ func resourceNetboxDeviceConsoleServerPortRead(d *schema.ResourceData, m interfa
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	consoleServerPort := res.GetPayload()
@@ -147,17 +151,23 @@ func resourceNetboxDeviceConsoleServerPortRead(d *schema.ResourceData, m interfa
 	d.Set("description", consoleServerPort.Description)
 	d.Set("mark_connected", consoleServerPort.MarkConnected)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
+	cf := getResourceCustomFields(api, d, res.GetPayload().CustomFields)
 	if cf != nil {
 		d.Set(customFieldsKey, cf)
 	}
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	cfJSON, err := getResourceCustomFieldsJSON(d, res.GetPayload().CustomFields)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set(customFieldsJSONKey, cfJSON)
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, res.GetPayload().Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxDeviceConsoleServerPortUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceConsoleServerPortUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -172,32 +182,33 @@ func resourceNetboxDeviceConsoleServerPortUpdate(d *schema.ResourceData, m inter
 		MarkConnected: d.Get("mark_connected").(bool),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
+
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.consoleserverport")
 
-	params := dcim.NewDcimConsoleServerPortsPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimConsoleServerPortsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimConsoleServerPortsPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimConsoleServerPortsPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxDeviceConsoleServerPortRead(d, m)
+	return resourceNetboxDeviceConsoleServerPortRead(ctx, d, m)
 }
 
-func resourceNetboxDeviceConsoleServerPortDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceConsoleServerPortDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimConsoleServerPortsDeleteParams().WithID(id)
+	params := dcim.NewDcimConsoleServerPortsDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimConsoleServerPortsDelete(params, nil)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
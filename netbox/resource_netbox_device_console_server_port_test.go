@@ -135,7 +135,7 @@ resource "netbox_device_console_server_port" "test" {
 
 func testAccCheckDeviceConsoleServerPortDestroy(s *terraform.State) error {
 	// retrieve the connection established in Provider configuration
-	conn := testAccProvider.Meta().(*client.NetBoxAPI)
+	conn := testAccProvider.Meta().(*providerState)
 
 	// loop through the resources in state, verifying each console server port
 	// is destroyed
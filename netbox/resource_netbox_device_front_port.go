@@ -1,20 +1,21 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceNetboxDeviceFrontPort() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxDeviceFrontPortCreate,
-		Read:   resourceNetboxDeviceFrontPortRead,
-		Update: resourceNetboxDeviceFrontPortUpdate,
-		Delete: resourceNetboxDeviceFrontPortDelete,
+		CreateContext: resourceNetboxDeviceFrontPortCreate,
+		ReadContext:   resourceNetboxDeviceFrontPortRead,
+		UpdateContext: resourceNetboxDeviceFrontPortUpdate,
+		DeleteContext: resourceNetboxDeviceFrontPortDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/frontport/):
 
@@ -63,8 +64,10 @@ func resourceNetboxDeviceFrontPort() *schema.Resource {
 				Default:  false,
 				Optional: true,
 			},
-			tagsKey:         tagsSchema,
-			customFieldsKey: customFieldsSchema,
+			tagsKey:             tagsSchema,
+			customFieldsKey:     customFieldsSchema,
+			customFieldsJSONKey: customFieldsJSONSchema,
+			lastUpdatedKey:      lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -72,8 +75,8 @@ func resourceNetboxDeviceFrontPort() *schema.Resource {
 	}
 }
 
-func resourceNetboxDeviceFrontPortCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceFrontPortCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableFrontPort{
 		Device:           int64ToPtr(int64(d.Get("device_id").(int))),
@@ -88,29 +91,30 @@ func resourceNetboxDeviceFrontPortCreate(d *schema.ResourceData, m interface{})
 		MarkConnected:    d.Get("mark_connected").(bool),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
 
-	params := dcim.NewDcimFrontPortsCreateParams().WithData(&data)
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.frontport")
+
+	params := dcim.NewDcimFrontPortsCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimFrontPortsCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxDeviceFrontPortRead(d, m)
+	return resourceNetboxDeviceFrontPortRead(ctx, d, m)
 }
 
-func resourceNetboxDeviceFrontPortRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceFrontPortRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimFrontPortsReadParams().WithID(id)
+	params := dcim.NewDcimFrontPortsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimFrontPortsRead(params, nil)
 
@@ -121,7 +125,7 @@ func resourceNetboxDeviceFrontPortRead(d *schema.ResourceData, m interface{}) er
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	frontPort := res.GetPayload()
@@ -159,17 +163,23 @@ func resourceNetboxDeviceFrontPortRead(d *schema.ResourceData, m interface{}) er
 	d.Set("description", frontPort.Description)
 	d.Set("mark_connected", frontPort.MarkConnected)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
+	cf := getResourceCustomFields(api, d, res.GetPayload().CustomFields)
 	if cf != nil {
 		d.Set(customFieldsKey, cf)
 	}
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	cfJSON, err := getResourceCustomFieldsJSON(d, res.GetPayload().CustomFields)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set(customFieldsJSONKey, cfJSON)
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, res.GetPayload().Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxDeviceFrontPortUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceFrontPortUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -186,32 +196,33 @@ func resourceNetboxDeviceFrontPortUpdate(d *schema.ResourceData, m interface{})
 		MarkConnected:    d.Get("mark_connected").(bool),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
+
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.frontport")
 
-	params := dcim.NewDcimFrontPortsPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimFrontPortsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimFrontPortsPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimFrontPortsPartialUpdate(params, nil, changedFieldsOnly(api, d, map[string][]string{"color": {"color_hex"}}))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxDeviceFrontPortRead(d, m)
+	return resourceNetboxDeviceFrontPortRead(ctx, d, m)
 }
 
-func resourceNetboxDeviceFrontPortDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceFrontPortDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimFrontPortsDeleteParams().WithID(id)
+	params := dcim.NewDcimFrontPortsDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimFrontPortsDelete(params, nil)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
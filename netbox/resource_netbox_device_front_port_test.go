@@ -152,7 +152,7 @@ resource "netbox_device_front_port" "test" {
 
 func testAccCheckDeviceFrontPortDestroy(s *terraform.State) error {
 	// retrieve the connection established in Provider configuration
-	conn := testAccProvider.Meta().(*client.NetBoxAPI)
+	conn := testAccProvider.Meta().(*providerState)
 
 	// loop through the resources in state, verifying each front port
 	// is destroyed
@@ -5,7 +5,6 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -101,6 +100,7 @@ func resourceNetboxDeviceInterface() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -109,7 +109,7 @@ func resourceNetboxDeviceInterface() *schema.Resource {
 }
 
 func resourceNetboxDeviceInterfaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	var diags diag.Diagnostics
 
@@ -120,9 +120,9 @@ func resourceNetboxDeviceInterfaceCreate(ctx context.Context, d *schema.Resource
 	enabled := d.Get("enabled").(bool)
 	mgmtonly := d.Get("mgmtonly").(bool)
 	mode := d.Get("mode").(string)
-	tags, diagnostics := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-	if diagnostics != nil {
-		diags = append(diags, diagnostics...)
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
 	taggedVlans := toInt64List(d.Get("tagged_vlans"))
 	deviceID := int64(d.Get("device_id").(int))
@@ -160,11 +160,11 @@ func resourceNetboxDeviceInterfaceCreate(ctx context.Context, d *schema.Resource
 		data.UntaggedVlan = int64ToPtr(int64(untaggedVlan))
 	}
 
-	params := dcim.NewDcimInterfacesCreateParams().WithData(&data)
+	params := dcim.NewDcimInterfacesCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimInterfacesCreate(params, nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
@@ -173,12 +173,12 @@ func resourceNetboxDeviceInterfaceCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceNetboxDeviceInterfaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
 	var diags diag.Diagnostics
 
-	params := dcim.NewDcimInterfacesReadParams().WithID(id)
+	params := dcim.NewDcimInterfacesReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimInterfacesRead(params, nil)
 	if err != nil {
@@ -204,7 +204,7 @@ func resourceNetboxDeviceInterfaceRead(ctx context.Context, d *schema.ResourceDa
 	d.Set("mac_address", iface.MacAddress)
 	d.Set("mtu", iface.Mtu)
 	d.Set("speed", iface.Speed)
-	d.Set(tagsKey, getTagListFromNestedTagList(iface.Tags))
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, iface.Tags))
 	d.Set("tagged_vlans", getIDsFromNestedVLANDevice(iface.TaggedVlans))
 	d.Set("device_id", iface.Device.ID)
 
@@ -220,12 +220,13 @@ func resourceNetboxDeviceInterfaceRead(ctx context.Context, d *schema.ResourceDa
 	if iface.UntaggedVlan != nil {
 		d.Set("untagged_vlan", iface.UntaggedVlan.ID)
 	}
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return diags
 }
 
 func resourceNetboxDeviceInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	var diags diag.Diagnostics
 
@@ -238,9 +239,9 @@ func resourceNetboxDeviceInterfaceUpdate(ctx context.Context, d *schema.Resource
 	enabled := d.Get("enabled").(bool)
 	mgmtonly := d.Get("mgmtonly").(bool)
 	mode := d.Get("mode").(string)
-	tags, diagnostics := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-	if diagnostics != nil {
-		diags = append(diags, diagnostics...)
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
 	taggedVlans := toInt64List(d.Get("tagged_vlans"))
 	deviceID := int64(d.Get("device_id").(int))
@@ -285,20 +286,24 @@ func resourceNetboxDeviceInterfaceUpdate(ctx context.Context, d *schema.Resource
 		data.UntaggedVlan = &untaggedvlan
 	}
 
-	params := dcim.NewDcimInterfacesPartialUpdateParams().WithID(id).WithData(&data)
-	_, err := api.Dcim.DcimInterfacesPartialUpdate(params, nil)
+	params := dcim.NewDcimInterfacesPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
+	_, err = api.Dcim.DcimInterfacesPartialUpdate(params, nil, changedFieldsOnly(api, d, map[string][]string{
+		"lag":       {"lag_device_interface_id"},
+		"mgmt_only": {"mgmtonly"},
+		"parent":    {"parent_device_interface_id"},
+	}))
 	if err != nil {
-		return diag.FromErr(err)
+		return netboxErrorDiagnostics(err)
 	}
 
 	return diags
 }
 
 func resourceNetboxDeviceInterfaceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimInterfacesDeleteParams().WithID(id)
+	params := dcim.NewDcimInterfacesDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimInterfacesDelete(params, nil)
 	if err != nil {
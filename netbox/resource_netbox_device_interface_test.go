@@ -307,7 +307,7 @@ func TestAccNetboxDeviceInterface_vlans(t *testing.T) {
 
 func testAccCheckDeviceInterfaceDestroy(s *terraform.State) error {
 	// retrieve the connection established in Provider configuration
-	conn := testAccProvider.Meta().(*client.NetBoxAPI)
+	conn := testAccProvider.Meta().(*providerState)
 
 	// loop through the resources in state, verifying each interface
 	// is destroyed
@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxDeviceModuleBay() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxDeviceModuleBayCreate,
-		Read:   resourceNetboxDeviceModuleBayRead,
-		Update: resourceNetboxDeviceModuleBayUpdate,
-		Delete: resourceNetboxDeviceModuleBayDelete,
+		CreateContext: resourceNetboxDeviceModuleBayCreate,
+		ReadContext:   resourceNetboxDeviceModuleBayRead,
+		UpdateContext: resourceNetboxDeviceModuleBayUpdate,
+		DeleteContext: resourceNetboxDeviceModuleBayDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/modulebay/):
 
@@ -43,8 +44,10 @@ func resourceNetboxDeviceModuleBay() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
-			tagsKey:         tagsSchema,
-			customFieldsKey: customFieldsSchema,
+			tagsKey:             tagsSchema,
+			customFieldsKey:     customFieldsSchema,
+			customFieldsJSONKey: customFieldsJSONSchema,
+			lastUpdatedKey:      lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -52,8 +55,8 @@ func resourceNetboxDeviceModuleBay() *schema.Resource {
 	}
 }
 
-func resourceNetboxDeviceModuleBayCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceModuleBayCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableModuleBay{
 		Device:      int64ToPtr(int64(d.Get("device_id").(int))),
@@ -63,29 +66,30 @@ func resourceNetboxDeviceModuleBayCreate(d *schema.ResourceData, m interface{})
 		Description: getOptionalStr(d, "description", false),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
 
-	params := dcim.NewDcimModuleBaysCreateParams().WithData(&data)
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.modulebay")
+
+	params := dcim.NewDcimModuleBaysCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimModuleBaysCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxDeviceModuleBayRead(d, m)
+	return resourceNetboxDeviceModuleBayRead(ctx, d, m)
 }
 
-func resourceNetboxDeviceModuleBayRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceModuleBayRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimModuleBaysReadParams().WithID(id)
+	params := dcim.NewDcimModuleBaysReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimModuleBaysRead(params, nil)
 
@@ -96,7 +100,7 @@ func resourceNetboxDeviceModuleBayRead(d *schema.ResourceData, m interface{}) er
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	moduleBay := res.GetPayload()
@@ -112,17 +116,23 @@ func resourceNetboxDeviceModuleBayRead(d *schema.ResourceData, m interface{}) er
 	d.Set("position", moduleBay.Position)
 	d.Set("description", moduleBay.Description)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
+	cf := getResourceCustomFields(api, d, res.GetPayload().CustomFields)
 	if cf != nil {
 		d.Set(customFieldsKey, cf)
 	}
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	cfJSON, err := getResourceCustomFieldsJSON(d, res.GetPayload().CustomFields)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set(customFieldsJSONKey, cfJSON)
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, res.GetPayload().Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxDeviceModuleBayUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceModuleBayUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -134,32 +144,33 @@ func resourceNetboxDeviceModuleBayUpdate(d *schema.ResourceData, m interface{})
 		Description: getOptionalStr(d, "description", true),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
+
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.modulebay")
 
-	params := dcim.NewDcimModuleBaysPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimModuleBaysPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimModuleBaysPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimModuleBaysPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxDeviceModuleBayRead(d, m)
+	return resourceNetboxDeviceModuleBayRead(ctx, d, m)
 }
 
-func resourceNetboxDeviceModuleBayDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceModuleBayDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimModuleBaysDeleteParams().WithID(id)
+	params := dcim.NewDcimModuleBaysDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimModuleBaysDelete(params, nil)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -108,7 +108,7 @@ resource "netbox_device_module_bay" "test" {
 
 func testAccCheckDeviceModuleBayDestroy(s *terraform.State) error {
 	// retrieve the connection established in Provider configuration
-	conn := testAccProvider.Meta().(*client.NetBoxAPI)
+	conn := testAccProvider.Meta().(*providerState)
 
 	// loop through the resources in state, verifying each module bay
 	// is destroyed
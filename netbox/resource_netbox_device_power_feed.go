@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxPowerFeed() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxPowerFeedCreate,
-		Read:   resourceNetboxPowerFeedRead,
-		Update: resourceNetboxPowerFeedUpdate,
-		Delete: resourceNetboxPowerFeedDelete,
+		CreateContext: resourceNetboxPowerFeedCreate,
+		ReadContext:   resourceNetboxPowerFeedRead,
+		UpdateContext: resourceNetboxPowerFeedUpdate,
+		DeleteContext: resourceNetboxPowerFeedDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/powerfeed/):
 
@@ -84,8 +85,10 @@ func resourceNetboxPowerFeed() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
-			tagsKey:         tagsSchema,
-			customFieldsKey: customFieldsSchema,
+			tagsKey:             tagsSchema,
+			customFieldsKey:     customFieldsSchema,
+			customFieldsJSONKey: customFieldsJSONSchema,
+			lastUpdatedKey:      lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -93,8 +96,8 @@ func resourceNetboxPowerFeed() *schema.Resource {
 	}
 }
 
-func resourceNetboxPowerFeedCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPowerFeedCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritablePowerFeed{
 		PowerPanel:     int64ToPtr(int64(d.Get("power_panel_id").(int))),
@@ -112,29 +115,30 @@ func resourceNetboxPowerFeedCreate(d *schema.ResourceData, m interface{}) error
 		Comments:       getOptionalStr(d, "comments", false),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
 
-	params := dcim.NewDcimPowerFeedsCreateParams().WithData(&data)
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.powerfeed")
+
+	params := dcim.NewDcimPowerFeedsCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimPowerFeedsCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxPowerFeedRead(d, m)
+	return resourceNetboxPowerFeedRead(ctx, d, m)
 }
 
-func resourceNetboxPowerFeedRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPowerFeedRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimPowerFeedsReadParams().WithID(id)
+	params := dcim.NewDcimPowerFeedsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimPowerFeedsRead(params, nil)
 
@@ -145,7 +149,7 @@ func resourceNetboxPowerFeedRead(d *schema.ResourceData, m interface{}) error {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	powerFeed := res.GetPayload()
@@ -196,17 +200,23 @@ func resourceNetboxPowerFeedRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("description", powerFeed.Description)
 	d.Set("comments", powerFeed.Comments)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
+	cf := getResourceCustomFields(api, d, res.GetPayload().CustomFields)
 	if cf != nil {
 		d.Set(customFieldsKey, cf)
 	}
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	cfJSON, err := getResourceCustomFieldsJSON(d, res.GetPayload().CustomFields)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set(customFieldsJSONKey, cfJSON)
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, res.GetPayload().Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxPowerFeedUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPowerFeedUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -226,32 +236,33 @@ func resourceNetboxPowerFeedUpdate(d *schema.ResourceData, m interface{}) error
 		Comments:       getOptionalStr(d, "comments", true),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
+
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.powerfeed")
 
-	params := dcim.NewDcimPowerFeedsPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimPowerFeedsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimPowerFeedsPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimPowerFeedsPartialUpdate(params, nil, changedFieldsOnly(api, d, map[string][]string{"max_utilization": {"max_percent_utilization"}}))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxPowerFeedRead(d, m)
+	return resourceNetboxPowerFeedRead(ctx, d, m)
 }
 
-func resourceNetboxPowerFeedDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPowerFeedDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimPowerFeedsDeleteParams().WithID(id)
+	params := dcim.NewDcimPowerFeedsDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimPowerFeedsDelete(params, nil)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
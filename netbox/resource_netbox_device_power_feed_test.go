@@ -139,7 +139,7 @@ resource "netbox_power_feed" "test" {
 
 func testAccCheckDevicePowerFeedDestroy(s *terraform.State) error {
 	// retrieve the connection established in Provider configuration
-	conn := testAccProvider.Meta().(*client.NetBoxAPI)
+	conn := testAccProvider.Meta().(*providerState)
 
 	// loop through the resources in state, verifying each power feed
 	// is destroyed
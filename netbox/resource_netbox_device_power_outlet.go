@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxDevicePowerOutlet() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxDevicePowerOutletCreate,
-		Read:   resourceNetboxDevicePowerOutletRead,
-		Update: resourceNetboxDevicePowerOutletUpdate,
-		Delete: resourceNetboxDevicePowerOutletDelete,
+		CreateContext: resourceNetboxDevicePowerOutletCreate,
+		ReadContext:   resourceNetboxDevicePowerOutletRead,
+		UpdateContext: resourceNetboxDevicePowerOutletUpdate,
+		DeleteContext: resourceNetboxDevicePowerOutletDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/poweroutlet/):
 
@@ -64,8 +65,10 @@ For example, imagine a PDU with one power port which draws from a three-phase fe
 				Default:  false,
 				Optional: true,
 			},
-			tagsKey:         tagsSchema,
-			customFieldsKey: customFieldsSchema,
+			tagsKey:             tagsSchema,
+			customFieldsKey:     customFieldsSchema,
+			customFieldsJSONKey: customFieldsJSONSchema,
+			lastUpdatedKey:      lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -73,8 +76,8 @@ For example, imagine a PDU with one power port which draws from a three-phase fe
 	}
 }
 
-func resourceNetboxDevicePowerOutletCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDevicePowerOutletCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritablePowerOutlet{
 		Device:        int64ToPtr(int64(d.Get("device_id").(int))),
@@ -88,29 +91,30 @@ func resourceNetboxDevicePowerOutletCreate(d *schema.ResourceData, m interface{}
 		MarkConnected: d.Get("mark_connected").(bool),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
 
-	params := dcim.NewDcimPowerOutletsCreateParams().WithData(&data)
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.poweroutlet")
+
+	params := dcim.NewDcimPowerOutletsCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimPowerOutletsCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxDevicePowerOutletRead(d, m)
+	return resourceNetboxDevicePowerOutletRead(ctx, d, m)
 }
 
-func resourceNetboxDevicePowerOutletRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDevicePowerOutletRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimPowerOutletsReadParams().WithID(id)
+	params := dcim.NewDcimPowerOutletsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimPowerOutletsRead(params, nil)
 
@@ -121,7 +125,7 @@ func resourceNetboxDevicePowerOutletRead(d *schema.ResourceData, m interface{})
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	powerOutlet := res.GetPayload()
@@ -163,17 +167,23 @@ func resourceNetboxDevicePowerOutletRead(d *schema.ResourceData, m interface{})
 	d.Set("description", powerOutlet.Description)
 	d.Set("mark_connected", powerOutlet.MarkConnected)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
+	cf := getResourceCustomFields(api, d, res.GetPayload().CustomFields)
 	if cf != nil {
 		d.Set(customFieldsKey, cf)
 	}
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	cfJSON, err := getResourceCustomFieldsJSON(d, res.GetPayload().CustomFields)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set(customFieldsJSONKey, cfJSON)
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, res.GetPayload().Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxDevicePowerOutletUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDevicePowerOutletUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -189,32 +199,33 @@ func resourceNetboxDevicePowerOutletUpdate(d *schema.ResourceData, m interface{}
 		MarkConnected: d.Get("mark_connected").(bool),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
+
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.poweroutlet")
 
-	params := dcim.NewDcimPowerOutletsPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimPowerOutletsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimPowerOutletsPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimPowerOutletsPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxDevicePowerOutletRead(d, m)
+	return resourceNetboxDevicePowerOutletRead(ctx, d, m)
 }
 
-func resourceNetboxDevicePowerOutletDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDevicePowerOutletDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimPowerOutletsDeleteParams().WithID(id)
+	params := dcim.NewDcimPowerOutletsDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimPowerOutletsDelete(params, nil)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
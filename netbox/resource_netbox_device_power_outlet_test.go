@@ -143,7 +143,7 @@ resource "netbox_device_power_outlet" "test" {
 
 func testAccCheckDevicePowerOutletDestroy(s *terraform.State) error {
 	// retrieve the connection established in Provider configuration
-	conn := testAccProvider.Meta().(*client.NetBoxAPI)
+	conn := testAccProvider.Meta().(*providerState)
 
 	// loop through the resources in state, verifying each power outlet
 	// is destroyed
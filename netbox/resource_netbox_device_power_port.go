@@ -1,20 +1,21 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceNetboxDevicePowerPort() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxDevicePowerPortCreate,
-		Read:   resourceNetboxDevicePowerPortRead,
-		Update: resourceNetboxDevicePowerPortUpdate,
-		Delete: resourceNetboxDevicePowerPortDelete,
+		CreateContext: resourceNetboxDevicePowerPortCreate,
+		ReadContext:   resourceNetboxDevicePowerPortRead,
+		UpdateContext: resourceNetboxDevicePowerPortUpdate,
+		DeleteContext: resourceNetboxDevicePowerPortDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/powerport/):
 
@@ -59,8 +60,10 @@ func resourceNetboxDevicePowerPort() *schema.Resource {
 				Default:  false,
 				Optional: true,
 			},
-			tagsKey:         tagsSchema,
-			customFieldsKey: customFieldsSchema,
+			tagsKey:             tagsSchema,
+			customFieldsKey:     customFieldsSchema,
+			customFieldsJSONKey: customFieldsJSONSchema,
+			lastUpdatedKey:      lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -68,8 +71,8 @@ func resourceNetboxDevicePowerPort() *schema.Resource {
 	}
 }
 
-func resourceNetboxDevicePowerPortCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDevicePowerPortCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritablePowerPort{
 		Device:        int64ToPtr(int64(d.Get("device_id").(int))),
@@ -83,29 +86,30 @@ func resourceNetboxDevicePowerPortCreate(d *schema.ResourceData, m interface{})
 		MarkConnected: d.Get("mark_connected").(bool),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
 
-	params := dcim.NewDcimPowerPortsCreateParams().WithData(&data)
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.powerport")
+
+	params := dcim.NewDcimPowerPortsCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimPowerPortsCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxDevicePowerPortRead(d, m)
+	return resourceNetboxDevicePowerPortRead(ctx, d, m)
 }
 
-func resourceNetboxDevicePowerPortRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDevicePowerPortRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimPowerPortsReadParams().WithID(id)
+	params := dcim.NewDcimPowerPortsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimPowerPortsRead(params, nil)
 
@@ -116,7 +120,7 @@ func resourceNetboxDevicePowerPortRead(d *schema.ResourceData, m interface{}) er
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	powerPort := res.GetPayload()
@@ -149,17 +153,23 @@ func resourceNetboxDevicePowerPortRead(d *schema.ResourceData, m interface{}) er
 	d.Set("description", powerPort.Description)
 	d.Set("mark_connected", powerPort.MarkConnected)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
+	cf := getResourceCustomFields(api, d, res.GetPayload().CustomFields)
 	if cf != nil {
 		d.Set(customFieldsKey, cf)
 	}
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	cfJSON, err := getResourceCustomFieldsJSON(d, res.GetPayload().CustomFields)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set(customFieldsJSONKey, cfJSON)
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, res.GetPayload().Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxDevicePowerPortUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDevicePowerPortUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -175,32 +185,33 @@ func resourceNetboxDevicePowerPortUpdate(d *schema.ResourceData, m interface{})
 		MarkConnected: d.Get("mark_connected").(bool),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
+
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.powerport")
 
-	params := dcim.NewDcimPowerPortsPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimPowerPortsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimPowerPortsPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimPowerPortsPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxDevicePowerPortRead(d, m)
+	return resourceNetboxDevicePowerPortRead(ctx, d, m)
 }
 
-func resourceNetboxDevicePowerPortDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDevicePowerPortDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimPowerPortsDeleteParams().WithID(id)
+	params := dcim.NewDcimPowerPortsDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimPowerPortsDelete(params, nil)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
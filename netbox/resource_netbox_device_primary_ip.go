@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxDevicePrimaryIP() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxDevicePrimaryIPCreate,
-		Read:   resourceNetboxDevicePrimaryIPRead,
-		Update: resourceNetboxDevicePrimaryIPUpdate,
-		Delete: resourceNetboxDevicePrimaryIPDelete,
+		CreateContext: resourceNetboxDevicePrimaryIPCreate,
+		ReadContext:   resourceNetboxDevicePrimaryIPRead,
+		UpdateContext: resourceNetboxDevicePrimaryIPUpdate,
+		DeleteContext: resourceNetboxDevicePrimaryIPDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):This resource is used to define the primary IP for a given device. The primary IP is reflected in the device Netbox UI, which identifies the Primary IPv4 and IPv6 addresses.`,
 
@@ -34,6 +35,7 @@ func resourceNetboxDevicePrimaryIP() *schema.Resource {
 				Optional:     true,
 				Default:      4,
 			},
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -41,16 +43,16 @@ func resourceNetboxDevicePrimaryIP() *schema.Resource {
 	}
 }
 
-func resourceNetboxDevicePrimaryIPCreate(d *schema.ResourceData, m interface{}) error {
+func resourceNetboxDevicePrimaryIPCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId(strconv.Itoa(d.Get("device_id").(int)))
 
-	return resourceNetboxDevicePrimaryIPUpdate(d, m)
+	return resourceNetboxDevicePrimaryIPUpdate(ctx, d, m)
 }
 
-func resourceNetboxDevicePrimaryIPRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDevicePrimaryIPRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimDevicesReadParams().WithID(id)
+	params := dcim.NewDcimDevicesReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimDevicesRead(params, nil)
 	if err != nil {
@@ -62,7 +64,7 @@ func resourceNetboxDevicePrimaryIPRead(d *schema.ResourceData, m interface{}) er
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	IPAddressVersion := d.Get("ip_address_version")
@@ -78,68 +80,28 @@ func resourceNetboxDevicePrimaryIPRead(d *schema.ResourceData, m interface{}) er
 		return nil
 	}
 	d.Set("device_id", res.GetPayload().ID)
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
+
 	return nil
 }
 
-func resourceNetboxDevicePrimaryIPUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDevicePrimaryIPUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	deviceID := int64(d.Get("device_id").(int))
 	IPAddressID := int64(d.Get("ip_address_id").(int))
 	IPAddressVersion := int64(d.Get("ip_address_version").(int))
 
-	// because the go-netbox library does not have patch support atm, we have to get the whole object and re-put it
-
-	// first, get the device
-	readParams := dcim.NewDcimDevicesReadParams().WithID(deviceID)
-	res, err := api.Dcim.DcimDevicesRead(readParams, nil)
-	if err != nil {
-		return err
-	}
-
-	device := res.GetPayload()
-
-	// then update the FULL device with ALL tracked attributes
+	// Only the primary IP address is sent, all other fields of the device
+	// are left alone
 	data := models.WritableDeviceWithConfigContext{}
-
-	data.Name = device.Name
-	data.Tags = device.Tags
-	// the netbox API sends the URL property as part of NestedTag, but it does not accept the URL property when we send it back
-	// so set it to empty
-	// display too
-	for _, tag := range data.Tags {
-		tag.URL = ""
-		tag.Display = ""
-	}
-
-	if device.DeviceType != nil {
-		data.DeviceType = &device.DeviceType.ID
-	}
-
-	if device.Site != nil {
-		data.Site = &device.Site.ID
-	}
-
-	if device.Role != nil {
-		data.Role = &device.Role.ID
-	}
-
-	if device.PrimaryIp4 != nil {
-		data.PrimaryIp4 = &device.PrimaryIp4.ID
-	}
-
-	if device.PrimaryIp6 != nil {
-		data.PrimaryIp6 = &device.PrimaryIp6.ID
+	field := "primary_ip4"
+	if IPAddressVersion == 6 {
+		field = "primary_ip6"
 	}
 
 	// unset primary ip address if -1 is passed as id
-	if IPAddressID == -1 {
-		if IPAddressVersion == 4 {
-			data.PrimaryIp4 = nil
-		} else {
-			data.PrimaryIp6 = nil
-		}
-	} else {
+	if IPAddressID != -1 {
 		if IPAddressVersion == 4 {
 			data.PrimaryIp4 = &IPAddressID
 		} else {
@@ -147,17 +109,17 @@ func resourceNetboxDevicePrimaryIPUpdate(d *schema.ResourceData, m interface{})
 		}
 	}
 
-	updateParams := dcim.NewDcimDevicesPartialUpdateParams().WithID(deviceID).WithData(&data)
+	updateParams := dcim.NewDcimDevicesPartialUpdateParams().WithContext(ctx).WithID(deviceID).WithData(&data)
 
-	_, err = api.Dcim.DcimDevicesPartialUpdate(updateParams, nil)
+	_, err := api.Dcim.DcimDevicesPartialUpdate(updateParams, nil, onlyFields(field))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
-	return resourceNetboxDevicePrimaryIPRead(d, m)
+	return resourceNetboxDevicePrimaryIPRead(ctx, d, m)
 }
 
-func resourceNetboxDevicePrimaryIPDelete(d *schema.ResourceData, m interface{}) error {
+func resourceNetboxDevicePrimaryIPDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Set ip_address_id to minus one and go to update. Update will set nil
 	d.Set("ip_address_id", -1)
-	return resourceNetboxDevicePrimaryIPUpdate(d, m)
+	return resourceNetboxDevicePrimaryIPUpdate(ctx, d, m)
 }
@@ -1,20 +1,21 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceNetboxDeviceRearPort() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxDeviceRearPortCreate,
-		Read:   resourceNetboxDeviceRearPortRead,
-		Update: resourceNetboxDeviceRearPortUpdate,
-		Delete: resourceNetboxDeviceRearPortDelete,
+		CreateContext: resourceNetboxDeviceRearPortCreate,
+		ReadContext:   resourceNetboxDeviceRearPortRead,
+		UpdateContext: resourceNetboxDeviceRearPortUpdate,
+		DeleteContext: resourceNetboxDeviceRearPortDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/rearport/):
 
@@ -59,8 +60,10 @@ func resourceNetboxDeviceRearPort() *schema.Resource {
 				Default:  false,
 				Optional: true,
 			},
-			tagsKey:         tagsSchema,
-			customFieldsKey: customFieldsSchema,
+			tagsKey:             tagsSchema,
+			customFieldsKey:     customFieldsSchema,
+			customFieldsJSONKey: customFieldsJSONSchema,
+			lastUpdatedKey:      lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -68,8 +71,8 @@ func resourceNetboxDeviceRearPort() *schema.Resource {
 	}
 }
 
-func resourceNetboxDeviceRearPortCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceRearPortCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableRearPort{
 		Device:        int64ToPtr(int64(d.Get("device_id").(int))),
@@ -83,29 +86,30 @@ func resourceNetboxDeviceRearPortCreate(d *schema.ResourceData, m interface{}) e
 		MarkConnected: d.Get("mark_connected").(bool),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
 
-	params := dcim.NewDcimRearPortsCreateParams().WithData(&data)
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.rearport")
+
+	params := dcim.NewDcimRearPortsCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimRearPortsCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxDeviceRearPortRead(d, m)
+	return resourceNetboxDeviceRearPortRead(ctx, d, m)
 }
 
-func resourceNetboxDeviceRearPortRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceRearPortRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimRearPortsReadParams().WithID(id)
+	params := dcim.NewDcimRearPortsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimRearPortsRead(params, nil)
 
@@ -116,7 +120,7 @@ func resourceNetboxDeviceRearPortRead(d *schema.ResourceData, m interface{}) err
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	rearPort := res.GetPayload()
@@ -148,17 +152,23 @@ func resourceNetboxDeviceRearPortRead(d *schema.ResourceData, m interface{}) err
 	d.Set("description", rearPort.Description)
 	d.Set("mark_connected", rearPort.MarkConnected)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
+	cf := getResourceCustomFields(api, d, res.GetPayload().CustomFields)
 	if cf != nil {
 		d.Set(customFieldsKey, cf)
 	}
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	cfJSON, err := getResourceCustomFieldsJSON(d, res.GetPayload().CustomFields)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set(customFieldsJSONKey, cfJSON)
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, res.GetPayload().Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxDeviceRearPortUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceRearPortUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -174,32 +184,33 @@ func resourceNetboxDeviceRearPortUpdate(d *schema.ResourceData, m interface{}) e
 		MarkConnected: d.Get("mark_connected").(bool),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
+
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.rearport")
 
-	params := dcim.NewDcimRearPortsPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimRearPortsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimRearPortsPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimRearPortsPartialUpdate(params, nil, changedFieldsOnly(api, d, map[string][]string{"color": {"color_hex"}}))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxDeviceRearPortRead(d, m)
+	return resourceNetboxDeviceRearPortRead(ctx, d, m)
 }
 
-func resourceNetboxDeviceRearPortDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceRearPortDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimRearPortsDeleteParams().WithID(id)
+	params := dcim.NewDcimRearPortsDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimRearPortsDelete(params, nil)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -140,7 +140,7 @@ resource "netbox_device_rear_port" "test" {
 
 func testAccCheckDeviceRearPortDestroy(s *terraform.State) error {
 	// retrieve the connection established in Provider configuration
-	conn := testAccProvider.Meta().(*client.NetBoxAPI)
+	conn := testAccProvider.Meta().(*providerState)
 
 	// loop through the resources in state, verifying each rear port
 	// is destroyed
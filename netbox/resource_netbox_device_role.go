@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxDeviceRole() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxDeviceRoleCreate,
-		Read:   resourceNetboxDeviceRoleRead,
-		Update: resourceNetboxDeviceRoleUpdate,
-		Delete: resourceNetboxDeviceRoleDelete,
+		CreateContext: resourceNetboxDeviceRoleCreate,
+		ReadContext:   resourceNetboxDeviceRoleRead,
+		UpdateContext: resourceNetboxDeviceRoleUpdate,
+		DeleteContext: resourceNetboxDeviceRoleDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/features/devices/#device-roles):
 
@@ -45,7 +46,8 @@ func resourceNetboxDeviceRole() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
-			tagsKey: tagsSchema,
+			tagsKey:        tagsSchema,
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -53,8 +55,8 @@ func resourceNetboxDeviceRole() *schema.Resource {
 	}
 }
 
-func resourceNetboxDeviceRoleCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 	slugValue, slugOk := d.GetOk("slug")
@@ -71,9 +73,12 @@ func resourceNetboxDeviceRoleCreate(d *schema.ResourceData, m interface{}) error
 	vmRole := d.Get("vm_role").(bool)
 	description := d.Get("description").(string)
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	params := dcim.NewDcimDeviceRolesCreateParams().WithData(
+	params := dcim.NewDcimDeviceRolesCreateParams().WithContext(ctx).WithData(
 		&models.DeviceRole{
 			Name:        &name,
 			Slug:        &slug,
@@ -87,18 +92,18 @@ func resourceNetboxDeviceRoleCreate(d *schema.ResourceData, m interface{}) error
 	res, err := api.Dcim.DcimDeviceRolesCreate(params, nil)
 	if err != nil {
 		//return errors.New(getTextFromError(err))
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxDeviceRoleRead(d, m)
+	return resourceNetboxDeviceRoleRead(ctx, d, m)
 }
 
-func resourceNetboxDeviceRoleRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimDeviceRolesReadParams().WithID(id)
+	params := dcim.NewDcimDeviceRolesReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimDeviceRolesRead(params, nil)
 	if err != nil {
@@ -110,7 +115,7 @@ func resourceNetboxDeviceRoleRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
@@ -118,12 +123,14 @@ func resourceNetboxDeviceRoleRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("vm_role", res.GetPayload().VMRole)
 	d.Set("color_hex", res.GetPayload().Color)
 	d.Set("description", res.GetPayload().Description)
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, res.GetPayload().Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
+
 	return nil
 }
 
-func resourceNetboxDeviceRoleUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.DeviceRole{}
@@ -149,24 +156,27 @@ func resourceNetboxDeviceRoleUpdate(d *schema.ResourceData, m interface{}) error
 	data.Color = color
 	data.Description = description
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	data.Tags = tags
 
-	params := dcim.NewDcimDeviceRolesPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimDeviceRolesPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimDeviceRolesPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimDeviceRolesPartialUpdate(params, nil, changedFieldsOnly(api, d, map[string][]string{"color": {"color_hex"}}))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxDeviceRoleRead(d, m)
+	return resourceNetboxDeviceRoleRead(ctx, d, m)
 }
 
-func resourceNetboxDeviceRoleDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceRoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimDeviceRolesDeleteParams().WithID(id)
+	params := dcim.NewDcimDeviceRolesDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimDeviceRolesDelete(params, nil)
 	if err != nil {
@@ -176,7 +186,7 @@ func resourceNetboxDeviceRoleDelete(d *schema.ResourceData, m interface{}) error
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
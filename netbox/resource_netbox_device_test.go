@@ -90,6 +90,84 @@ resource "netbox_config_template" "test" {
 }`, testName)
 }
 
+func TestUnitNetboxDevice_basic(t *testing.T) {
+	f := testFakeNetbox(t)
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testUnitProviders,
+		CheckDestroy: func(*terraform.State) error {
+			if n := f.count("dcim/devices"); n != 0 {
+				return fmt.Errorf("%d devices left", n)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetboxDeviceFullDependencies("test") + `
+resource "netbox_device" "test" {
+  name               = "test"
+  asset_tag          = "asset"
+  comments           = "comment"
+  description        = "description"
+  tenant_id          = netbox_tenant.test.id
+  platform_id        = netbox_platform.test.id
+  role_id            = netbox_device_role.test.id
+  device_type_id     = netbox_device_type.test.id
+  tags               = [netbox_tag.test_a.name]
+  site_id            = netbox_site.test.id
+  cluster_id         = netbox_cluster.test.id
+  location_id        = netbox_location.test.id
+  config_template_id = netbox_config_template.test.id
+  status             = "staged"
+  serial             = "ABCDEF"
+  rack_id            = netbox_rack.test.id
+  rack_face          = "front"
+  rack_position      = 10
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("netbox_device.test", "tenant_id", "netbox_tenant.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_device.test", "platform_id", "netbox_platform.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_device.test", "role_id", "netbox_device_role.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_device.test", "device_type_id", "netbox_device_type.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_device.test", "cluster_id", "netbox_cluster.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_device.test", "location_id", "netbox_location.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_device.test", "config_template_id", "netbox_config_template.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_device.test", "rack_id", "netbox_rack.test", "id"),
+					resource.TestCheckResourceAttr("netbox_device.test", "status", "staged"),
+					resource.TestCheckResourceAttr("netbox_device.test", "rack_face", "front"),
+					resource.TestCheckResourceAttr("netbox_device.test", "rack_position", "10"),
+					resource.TestCheckResourceAttr("netbox_device.test", "tags.0", "testa"),
+				),
+			},
+			{
+				ResourceName:      "netbox_device.test",
+				ImportState:       true,
+				ImportStateId:     "test/test",
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccNetboxDeviceFullDependencies("test") + `
+resource "netbox_device" "test" {
+  name           = "test"
+  role_id        = netbox_device_role.test.id
+  device_type_id = netbox_device_type.test.id
+  site_id        = netbox_site.test.id
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_device.test", "status", "active"),
+					resource.TestCheckResourceAttr("netbox_device.test", "asset_tag", ""),
+					resource.TestCheckResourceAttr("netbox_device.test", "serial", ""),
+					resource.TestCheckResourceAttr("netbox_device.test", "tenant_id", "0"),
+					resource.TestCheckResourceAttr("netbox_device.test", "platform_id", "0"),
+					resource.TestCheckResourceAttr("netbox_device.test", "cluster_id", "0"),
+					resource.TestCheckResourceAttr("netbox_device.test", "location_id", "0"),
+					resource.TestCheckResourceAttr("netbox_device.test", "rack_id", "0"),
+					resource.TestCheckResourceAttr("netbox_device.test", "tags.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccNetboxDevice_basic(t *testing.T) {
 	testSlug := "device_basic"
 	testName := testAccGetTestName(testSlug)
@@ -359,7 +437,7 @@ resource "netbox_device" "test" {
 
 func testAccCheckDeviceDestroy(s *terraform.State) error {
 	// retrieve the connection established in Provider configuration
-	conn := testAccProvider.Meta().(*client.NetBoxAPI)
+	conn := testAccProvider.Meta().(*providerState)
 
 	// loop through the resources in state, verifying each device
 	// is destroyed
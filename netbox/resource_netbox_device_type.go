@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxDeviceType() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxDeviceTypeCreate,
-		Read:   resourceNetboxDeviceTypeRead,
-		Update: resourceNetboxDeviceTypeUpdate,
-		Delete: resourceNetboxDeviceTypeDelete,
+		CreateContext: resourceNetboxDeviceTypeCreate,
+		ReadContext:   resourceNetboxDeviceTypeRead,
+		UpdateContext: resourceNetboxDeviceTypeUpdate,
+		DeleteContext: resourceNetboxDeviceTypeDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/features/device-types/#device-types_1):
 
@@ -49,7 +50,8 @@ func resourceNetboxDeviceType() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
-			tagsKey: tagsSchema,
+			tagsKey:        tagsSchema,
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -57,8 +59,8 @@ func resourceNetboxDeviceType() *schema.Resource {
 	}
 }
 
-func resourceNetboxDeviceTypeCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceTypeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableDeviceType{}
 
@@ -90,24 +92,28 @@ func resourceNetboxDeviceTypeCreate(d *schema.ResourceData, m interface{}) error
 		data.IsFullDepth = isFullDepthValue.(bool)
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.Tags = tags
 
-	params := dcim.NewDcimDeviceTypesCreateParams().WithData(&data)
+	params := dcim.NewDcimDeviceTypesCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimDeviceTypesCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxDeviceTypeRead(d, m)
+	return resourceNetboxDeviceTypeRead(ctx, d, m)
 }
 
-func resourceNetboxDeviceTypeRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceTypeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimDeviceTypesReadParams().WithID(id)
+	params := dcim.NewDcimDeviceTypesReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimDeviceTypesRead(params, nil)
 
@@ -120,7 +126,7 @@ func resourceNetboxDeviceTypeRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	deviceType := res.GetPayload()
@@ -130,13 +136,14 @@ func resourceNetboxDeviceTypeRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("part_number", deviceType.PartNumber)
 	d.Set("u_height", deviceType.UHeight)
 	d.Set("is_full_depth", deviceType.IsFullDepth)
-	d.Set(tagsKey, getTagListFromNestedTagList(deviceType.Tags))
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, deviceType.Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxDeviceTypeUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceTypeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableDeviceType{}
@@ -169,23 +176,27 @@ func resourceNetboxDeviceTypeUpdate(d *schema.ResourceData, m interface{}) error
 		data.IsFullDepth = isFullDepthValue.(bool)
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.Tags = tags
 
-	params := dcim.NewDcimDeviceTypesPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimDeviceTypesPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimDeviceTypesPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimDeviceTypesPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxDeviceTypeRead(d, m)
+	return resourceNetboxDeviceTypeRead(ctx, d, m)
 }
 
-func resourceNetboxDeviceTypeDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceTypeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimDeviceTypesDeleteParams().WithID(id)
+	params := dcim.NewDcimDeviceTypesDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimDeviceTypesDelete(params, nil)
 	if err != nil {
@@ -195,7 +206,7 @@ func resourceNetboxDeviceTypeDelete(d *schema.ResourceData, m interface{}) error
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -1,12 +1,13 @@
 package netbox
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/extras"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -15,10 +16,10 @@ var resourceNetboxEventRuleActionTypeOptions = []string{"webhook"}
 
 func resourceNetboxEventRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxEventRuleCreate,
-		Read:   resourceNetboxEventRuleRead,
-		Update: resourceNetboxEventRuleUpdate,
-		Delete: resourceNetboxEventRuleDelete,
+		CreateContext: resourceNetboxEventRuleCreate,
+		ReadContext:   resourceNetboxEventRuleRead,
+		UpdateContext: resourceNetboxEventRuleUpdate,
+		DeleteContext: resourceNetboxEventRuleDelete,
 
 		Description: `:meta:subcategory:Extras:From the [official documentation](https://docs.netbox.dev/en/stable/features/event-rules/):
 
@@ -87,7 +88,8 @@ func resourceNetboxEventRule() *schema.Resource {
 				Type:     schema.TypeInt,
 				Required: true,
 			},
-			tagsKey: tagsSchema,
+			tagsKey:        tagsSchema,
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -95,8 +97,8 @@ func resourceNetboxEventRule() *schema.Resource {
 	}
 }
 
-func resourceNetboxEventRuleCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxEventRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := &models.WritableEventRule{}
 
@@ -123,7 +125,10 @@ func resourceNetboxEventRuleCreate(d *schema.ResourceData, m interface{}) error
 	data.Enabled = enabled
 	data.ActionObjectID = getOptionalInt(d, "action_object_id")
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	data.Tags = tags
 
 	ctypes := d.Get("content_types").(*schema.Set).List()
@@ -137,27 +142,27 @@ func resourceNetboxEventRuleCreate(d *schema.ResourceData, m interface{}) error
 		var conditions any
 		err := json.Unmarshal([]byte(conditionsData.(string)), &conditions)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		data.Conditions = conditions
 	}
 
-	params := extras.NewExtrasEventRulesCreateParams().WithData(data)
+	params := extras.NewExtrasEventRulesCreateParams().WithContext(ctx).WithData(data)
 
 	res, err := api.Extras.ExtrasEventRulesCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxEventRuleRead(d, m)
+	return resourceNetboxEventRuleRead(ctx, d, m)
 }
 
-func resourceNetboxEventRuleRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxEventRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := extras.NewExtrasEventRulesReadParams().WithID(id)
+	params := extras.NewExtrasEventRulesReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Extras.ExtrasEventRulesRead(params, nil)
 	if err != nil {
@@ -168,7 +173,7 @@ func resourceNetboxEventRuleRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	eventRule := res.GetPayload()
@@ -188,18 +193,19 @@ func resourceNetboxEventRuleRead(d *schema.ResourceData, m interface{}) error {
 	if eventRule.Conditions != nil {
 		conditions, err := json.Marshal(eventRule.Conditions)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		d.Set("conditions", string(conditions))
 	}
 
-	d.Set(tagsKey, getTagListFromNestedTagList(eventRule.Tags))
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, eventRule.Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxEventRuleUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxEventRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableEventRule{}
@@ -231,12 +237,15 @@ func resourceNetboxEventRuleUpdate(d *schema.ResourceData, m interface{}) error
 		var conditions any
 		err := json.Unmarshal([]byte(conditionsData.(string)), &conditions)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		data.Conditions = conditions
 	}
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	data.Tags = tags
 
 	ctypes := d.Get("content_types").(*schema.Set).List()
@@ -246,21 +255,30 @@ func resourceNetboxEventRuleUpdate(d *schema.ResourceData, m interface{}) error
 	}
 	data.ObjectTypes = objectTypes
 
-	params := extras.NewExtrasEventRulesUpdateParams().WithID(id).WithData(&data)
-
-	_, err := api.Extras.ExtrasEventRulesUpdate(params, nil)
+	params := extras.NewExtrasEventRulesUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
+
+	_, err = api.Extras.ExtrasEventRulesUpdate(params, nil, changedFieldsOnly(api, d, map[string][]string{
+		"type_create":    {"trigger_on_create"},
+		"type_update":    {"trigger_on_update"},
+		"type_delete":    {"trigger_on_delete"},
+		"type_job_start": {"trigger_on_job_start"},
+		"type_job_end":   {"trigger_on_job_end"},
+		"object_types":   {"content_types"},
+		// Always a webhook
+		"action_object_type": nil,
+	}))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxEventRuleRead(d, m)
+	return resourceNetboxEventRuleRead(ctx, d, m)
 }
 
-func resourceNetboxEventRuleDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxEventRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := extras.NewExtrasEventRulesDeleteParams().WithID(id)
+	params := extras.NewExtrasEventRulesDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Extras.ExtrasEventRulesDelete(params, nil)
 	if err != nil {
@@ -270,7 +288,7 @@ func resourceNetboxEventRuleDelete(d *schema.ResourceData, m interface{}) error
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -17,6 +17,7 @@ func TestAccNetboxEventRule_basic(t *testing.T) {
 	testName := testAccGetTestName("evt_rule_basic")
 	resource.ParallelTest(t, resource.TestCase{
 		Providers:    testAccProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
 		CheckDestroy: testAccCheckNetBoxEventRuleDestroy,
 		Steps: []resource.TestStep{
 			{
@@ -88,7 +89,7 @@ resource "netbox_event_rule" "test" {
 }
 
 func testAccCheckNetBoxEventRuleDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*client.NetBoxAPI)
+	client := testAccProvider.Meta().(*providerState)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "netbox_event_rule" {
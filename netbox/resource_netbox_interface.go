@@ -5,7 +5,6 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/virtualization"
 	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -80,6 +79,7 @@ func resourceNetboxInterface() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -88,7 +88,7 @@ func resourceNetboxInterface() *schema.Resource {
 }
 
 func resourceNetboxInterfaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	var diags diag.Diagnostics
 
@@ -96,9 +96,9 @@ func resourceNetboxInterfaceCreate(ctx context.Context, d *schema.ResourceData,
 	description := d.Get("description").(string)
 	enabled := d.Get("enabled").(bool)
 	mode := d.Get("mode").(string)
-	tags, diagnostics := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-	if diagnostics != nil {
-		diags = append(diags, diagnostics...)
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
 	taggedVlans := toInt64List(d.Get("tagged_vlans"))
 	virtualMachineID := int64(d.Get("virtual_machine_id").(int))
@@ -121,11 +121,11 @@ func resourceNetboxInterfaceCreate(ctx context.Context, d *schema.ResourceData,
 	if untaggedVlan, ok := d.Get("untagged_vlan").(int); ok && untaggedVlan != 0 {
 		data.UntaggedVlan = int64ToPtr(int64(untaggedVlan))
 	}
-	params := virtualization.NewVirtualizationInterfacesCreateParams().WithData(&data)
+	params := virtualization.NewVirtualizationInterfacesCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Virtualization.VirtualizationInterfacesCreate(params, nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
@@ -134,12 +134,12 @@ func resourceNetboxInterfaceCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceNetboxInterfaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
 	var diags diag.Diagnostics
 
-	params := virtualization.NewVirtualizationInterfacesReadParams().WithID(id)
+	params := virtualization.NewVirtualizationInterfacesReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Virtualization.VirtualizationInterfacesRead(params, nil)
 	if err != nil {
@@ -161,7 +161,7 @@ func resourceNetboxInterfaceRead(ctx context.Context, d *schema.ResourceData, m
 	d.Set("enabled", iface.Enabled)
 	d.Set("mac_address", iface.MacAddress)
 	d.Set("mtu", iface.Mtu)
-	d.Set(tagsKey, getTagListFromNestedTagList(iface.Tags))
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, iface.Tags))
 	d.Set("tagged_vlans", getIDsFromNestedVLAN(iface.TaggedVlans))
 	d.Set("virtual_machine_id", iface.VirtualMachine.ID)
 
@@ -171,12 +171,13 @@ func resourceNetboxInterfaceRead(ctx context.Context, d *schema.ResourceData, m
 	if iface.UntaggedVlan != nil {
 		d.Set("untagged_vlan", iface.UntaggedVlan.ID)
 	}
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return diags
 }
 
 func resourceNetboxInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	var diags diag.Diagnostics
 
@@ -186,9 +187,9 @@ func resourceNetboxInterfaceUpdate(ctx context.Context, d *schema.ResourceData,
 	description := d.Get("description").(string)
 	enabled := d.Get("enabled").(bool)
 	mode := d.Get("mode").(string)
-	tags, diagnostics := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-	if diagnostics != nil {
-		diags = append(diags, diagnostics...)
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
 	taggedVlans := toInt64List(d.Get("tagged_vlans"))
 	virtualMachineID := int64(d.Get("virtual_machine_id").(int))
@@ -216,20 +217,20 @@ func resourceNetboxInterfaceUpdate(ctx context.Context, d *schema.ResourceData,
 		data.UntaggedVlan = &untaggedvlan
 	}
 
-	params := virtualization.NewVirtualizationInterfacesPartialUpdateParams().WithID(id).WithData(&data)
-	_, err := api.Virtualization.VirtualizationInterfacesPartialUpdate(params, nil)
+	params := virtualization.NewVirtualizationInterfacesPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
+	_, err = api.Virtualization.VirtualizationInterfacesPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return diag.FromErr(err)
+		return netboxErrorDiagnostics(err)
 	}
 
 	return diags
 }
 
 func resourceNetboxInterfaceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := virtualization.NewVirtualizationInterfacesDeleteParams().WithID(id)
+	params := virtualization.NewVirtualizationInterfacesDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Virtualization.VirtualizationInterfacesDelete(params, nil)
 	if err != nil {
@@ -4,7 +4,6 @@ import (
 	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -56,6 +55,7 @@ func resourceNetboxInterfaceTemplate() *schema.Resource {
 				ExactlyOneOf: []string{"device_type_id", "module_type_id"},
 				ForceNew:     true,
 			},
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -64,7 +64,7 @@ func resourceNetboxInterfaceTemplate() *schema.Resource {
 }
 
 func resourceNetboxInterfaceTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	var diags diag.Diagnostics
 
@@ -88,11 +88,11 @@ func resourceNetboxInterfaceTemplateCreate(ctx context.Context, d *schema.Resour
 	if moduleTypeID, ok := d.Get("module_type_id").(int); ok && moduleTypeID != 0 {
 		data.ModuleType = int64ToPtr(int64(moduleTypeID))
 	}
-	params := dcim.NewDcimInterfaceTemplatesCreateParams().WithData(&data)
+	params := dcim.NewDcimInterfaceTemplatesCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimInterfaceTemplatesCreate(params, nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
@@ -101,12 +101,12 @@ func resourceNetboxInterfaceTemplateCreate(ctx context.Context, d *schema.Resour
 }
 
 func resourceNetboxInterfaceTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
 	var diags diag.Diagnostics
 
-	params := dcim.NewDcimInterfaceTemplatesReadParams().WithID(id)
+	params := dcim.NewDcimInterfaceTemplatesReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimInterfaceTemplatesRead(params, nil)
 	if err != nil {
@@ -135,12 +135,13 @@ func resourceNetboxInterfaceTemplateRead(ctx context.Context, d *schema.Resource
 	if tmpl.ModuleType != nil {
 		d.Set("module_type_id", tmpl.ModuleType.ID)
 	}
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return diags
 }
 
 func resourceNetboxInterfaceTemplateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	var diags diag.Diagnostics
 
@@ -170,20 +171,20 @@ func resourceNetboxInterfaceTemplateUpdate(ctx context.Context, d *schema.Resour
 		data.ModuleType = &moduleTypeID
 	}
 
-	params := dcim.NewDcimInterfaceTemplatesPartialUpdateParams().WithID(id).WithData(&data)
-	_, err := api.Dcim.DcimInterfaceTemplatesPartialUpdate(params, nil)
+	params := dcim.NewDcimInterfaceTemplatesPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
+	_, err := api.Dcim.DcimInterfaceTemplatesPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return diag.FromErr(err)
+		return netboxErrorDiagnostics(err)
 	}
 
 	return diags
 }
 
 func resourceNetboxInterfaceTemplateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimInterfaceTemplatesDeleteParams().WithID(id)
+	params := dcim.NewDcimInterfaceTemplatesDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimInterfaceTemplatesDelete(params, nil)
 	if err != nil {
@@ -203,7 +203,7 @@ func TestAccNetboxInterface_vlans(t *testing.T) {
 
 func testAccCheckInterfaceDestroy(s *terraform.State) error {
 	// retrieve the connection established in Provider configuration
-	conn := testAccProvider.Meta().(*client.NetBoxAPI)
+	conn := testAccProvider.Meta().(*providerState)
 
 	// loop through the resources in state, verifying each interface
 	// is destroyed
@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxInventoryItem() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxInventoryItemCreate,
-		Read:   resourceNetboxInventoryItemRead,
-		Update: resourceNetboxInventoryItemUpdate,
-		Delete: resourceNetboxInventoryItemDelete,
+		CreateContext: resourceNetboxInventoryItemCreate,
+		ReadContext:   resourceNetboxInventoryItemRead,
+		UpdateContext: resourceNetboxInventoryItemUpdate,
+		DeleteContext: resourceNetboxInventoryItemDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/inventoryitem/):
 
@@ -85,8 +86,10 @@ func resourceNetboxInventoryItem() *schema.Resource {
 				Optional:     true,
 				RequiredWith: []string{"component_type"},
 			},
-			tagsKey:         tagsSchema,
-			customFieldsKey: customFieldsSchema,
+			tagsKey:             tagsSchema,
+			customFieldsKey:     customFieldsSchema,
+			customFieldsJSONKey: customFieldsJSONSchema,
+			lastUpdatedKey:      lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -94,8 +97,8 @@ func resourceNetboxInventoryItem() *schema.Resource {
 	}
 }
 
-func resourceNetboxInventoryItemCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxInventoryItemCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	data := models.WritableInventoryItem{
 		Device:       int64ToPtr(int64(d.Get("device_id").(int))),
 		Name:         strToPtr(d.Get("name").(string)),
@@ -118,29 +121,30 @@ func resourceNetboxInventoryItemCreate(d *schema.ResourceData, m interface{}) er
 		data.ComponentID = getOptionalInt(d, "component_id")
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
 
-	params := dcim.NewDcimInventoryItemsCreateParams().WithData(&data)
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.inventoryitem")
+
+	params := dcim.NewDcimInventoryItemsCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimInventoryItemsCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxInventoryItemRead(d, m)
+	return resourceNetboxInventoryItemRead(ctx, d, m)
 }
 
-func resourceNetboxInventoryItemRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxInventoryItemRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimInventoryItemsReadParams().WithID(id)
+	params := dcim.NewDcimInventoryItemsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimInventoryItemsRead(params, nil)
 
@@ -151,7 +155,7 @@ func resourceNetboxInventoryItemRead(d *schema.ResourceData, m interface{}) erro
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	item := res.GetPayload()
@@ -188,17 +192,23 @@ func resourceNetboxInventoryItemRead(d *schema.ResourceData, m interface{}) erro
 	d.Set("component_type", item.ComponentType)
 	d.Set("component_id", item.ComponentID)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
+	cf := getResourceCustomFields(api, d, res.GetPayload().CustomFields)
 	if cf != nil {
 		d.Set(customFieldsKey, cf)
 	}
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	cfJSON, err := getResourceCustomFieldsJSON(d, res.GetPayload().CustomFields)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set(customFieldsJSONKey, cfJSON)
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, res.GetPayload().Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxInventoryItemUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxInventoryItemUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -224,32 +234,33 @@ func resourceNetboxInventoryItemUpdate(d *schema.ResourceData, m interface{}) er
 		data.ComponentID = getOptionalInt(d, "component_id")
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
+
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.inventoryitem")
 
-	params := dcim.NewDcimInventoryItemsPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimInventoryItemsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimInventoryItemsPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimInventoryItemsPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxInventoryItemRead(d, m)
+	return resourceNetboxInventoryItemRead(ctx, d, m)
 }
 
-func resourceNetboxInventoryItemDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxInventoryItemDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimInventoryItemsDeleteParams().WithID(id)
+	params := dcim.NewDcimInventoryItemsDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimInventoryItemsDelete(params, nil)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
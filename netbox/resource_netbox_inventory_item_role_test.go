@@ -74,7 +74,7 @@ resource "netbox_inventory_item_role" "test" {
 
 func testAccCheckInventoryItemRoleDestroy(s *terraform.State) error {
 	// retrieve the connection established in Provider configuration
-	conn := testAccProvider.Meta().(*client.NetBoxAPI)
+	conn := testAccProvider.Meta().(*providerState)
 
 	// loop through the resources in state, verifying each inventory item role
 	// is destroyed
@@ -177,7 +177,7 @@ resource "netbox_inventory_item" "test" {
 
 func testAccCheckInventoryItemDestroy(s *terraform.State) error {
 	// retrieve the connection established in Provider configuration
-	conn := testAccProvider.Meta().(*client.NetBoxAPI)
+	conn := testAccProvider.Meta().(*providerState)
 
 	// loop through the resources in state, verifying each inventory item
 	// is destroyed
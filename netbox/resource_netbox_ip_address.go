@@ -1,11 +1,12 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -14,12 +15,21 @@ var resourceNetboxIPAddressObjectTypeOptions = []string{"virtualization.vminterf
 var resourceNetboxIPAddressStatusOptions = []string{"active", "reserved", "deprecated", "dhcp", "slaac"}
 var resourceNetboxIPAddressRoleOptions = []string{"loopback", "secondary", "anycast", "vip", "vrrp", "hsrp", "glbp", "carp"}
 
+// ipAddressFieldAliases maps the fields of IP addresses in Netbox to the
+// attributes they are set from.
+var ipAddressFieldAliases = map[string][]string{
+	"address":              {"ip_address"},
+	"assigned_object_type": {"interface_id", "object_type", "device_interface_id", "virtual_machine_interface_id"},
+	"assigned_object_id":   {"interface_id", "object_type", "device_interface_id", "virtual_machine_interface_id"},
+	"nat_inside":           {"nat_inside_address_id"},
+}
+
 func resourceNetboxIPAddress() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxIPAddressCreate,
-		Read:   resourceNetboxIPAddressRead,
-		Update: resourceNetboxIPAddressUpdate,
-		Delete: resourceNetboxIPAddressDelete,
+		CreateContext: resourceNetboxIPAddressCreate,
+		ReadContext:   resourceNetboxIPAddressRead,
+		UpdateContext: resourceNetboxIPAddressUpdate,
+		DeleteContext: resourceNetboxIPAddressDelete,
 
 		Description: `:meta:subcategory:IP Address Management (IPAM):From the [official documentation](https://docs.netbox.dev/en/stable/features/ipam/#ip-addresses):
 
@@ -108,7 +118,9 @@ func resourceNetboxIPAddress() *schema.Resource {
 					},
 				},
 			},
-			customFieldsKey: customFieldsSchema,
+			customFieldsKey:     customFieldsSchema,
+			customFieldsJSONKey: customFieldsJSONSchema,
+			lastUpdatedKey:      lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -116,8 +128,8 @@ func resourceNetboxIPAddress() *schema.Resource {
 	}
 }
 
-func resourceNetboxIPAddressCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxIPAddressCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableIPAddress{}
 
@@ -152,30 +164,31 @@ func resourceNetboxIPAddressCreate(d *schema.ResourceData, m interface{}) error
 		data.AssignedObjectID = nil
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	cf, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = cf
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
+
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "ipam.ipaddress")
 
-	params := ipam.NewIpamIPAddressesCreateParams().WithData(&data)
+	params := ipam.NewIpamIPAddressesCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Ipam.IpamIPAddressesCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxIPAddressRead(d, m)
+	return resourceNetboxIPAddressRead(ctx, d, m)
 }
 
-func resourceNetboxIPAddressRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxIPAddressRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamIPAddressesReadParams().WithID(id)
+	params := ipam.NewIpamIPAddressesReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Ipam.IpamIPAddressesRead(params, nil)
 	if err != nil {
@@ -187,7 +200,7 @@ func resourceNetboxIPAddressRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	ipAddress := res.GetPayload()
@@ -260,16 +273,23 @@ func resourceNetboxIPAddressRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("ip_address", ipAddress.Address)
 	d.Set("description", ipAddress.Description)
 	d.Set("status", ipAddress.Status.Value)
-	d.Set(tagsKey, getTagListFromNestedTagList(ipAddress.Tags))
-	cf := getCustomFields(res.GetPayload().CustomFields)
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, ipAddress.Tags))
+	cf := getResourceCustomFields(api, d, res.GetPayload().CustomFields)
 	if cf != nil {
 		d.Set(customFieldsKey, cf)
 	}
+	cfJSON, err := getResourceCustomFieldsJSON(d, res.GetPayload().CustomFields)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set(customFieldsJSONKey, cfJSON)
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
+
 	return nil
 }
 
-func resourceNetboxIPAddressUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxIPAddressUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableIPAddress{}
@@ -305,27 +325,29 @@ func resourceNetboxIPAddressUpdate(d *schema.ResourceData, m interface{}) error
 		data.AssignedObjectID = nil
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	if cf, ok := d.GetOk(customFieldsKey); ok {
-		data.CustomFields = cf
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
+
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "ipam.ipaddress")
 
-	params := ipam.NewIpamIPAddressesUpdateParams().WithID(id).WithData(&data)
+	params := ipam.NewIpamIPAddressesPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Ipam.IpamIPAddressesUpdate(params, nil)
+	_, err = api.Ipam.IpamIPAddressesPartialUpdate(params, nil, changedFieldsOnly(api, d, ipAddressFieldAliases))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxIPAddressRead(d, m)
+	return resourceNetboxIPAddressRead(ctx, d, m)
 }
 
-func resourceNetboxIPAddressDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxIPAddressDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamIPAddressesDeleteParams().WithID(id)
+	params := ipam.NewIpamIPAddressesDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Ipam.IpamIPAddressesDelete(params, nil)
 	if err != nil {
@@ -335,7 +357,7 @@ func resourceNetboxIPAddressDelete(d *schema.ResourceData, m interface{}) error
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
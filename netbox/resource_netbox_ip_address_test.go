@@ -10,6 +10,7 @@ import (
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func testAccNetboxIPAddressFullDependencies(testName string) string {
@@ -88,6 +89,7 @@ func TestAccNetboxIPAddress_basic(t *testing.T) {
 	testName := testAccGetTestName(testSlug)
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNetboxIPAddressFullDependencies(testName) + fmt.Sprintf(`
@@ -175,8 +177,7 @@ resource "netbox_ip_address" "test" {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("netbox_ip_address.test", "ip_address", testIP),
 					resource.TestCheckResourceAttr("netbox_ip_address.test", "status", "deprecated"),
-					resource.TestCheckResourceAttr("netbox_ip_address.test", "object_type", "virtualization.vminterface"),
-					resource.TestCheckResourceAttrPair("netbox_ip_address.test", "interface_id", "netbox_interface.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_ip_address.test", "virtual_machine_interface_id", "netbox_interface.test", "id"),
 				),
 			},
 			{
@@ -238,6 +239,7 @@ func TestAccNetboxIPAddress_deviceByObjectType(t *testing.T) {
 	testName := testAccGetTestName(testSlug)
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNetboxIPAddressFullDeviceDependencies(testName) + fmt.Sprintf(`
@@ -250,8 +252,8 @@ resource "netbox_ip_address" "test" {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("netbox_ip_address.test", "ip_address", testIP),
 					resource.TestCheckResourceAttr("netbox_ip_address.test", "status", "active"),
-					resource.TestCheckResourceAttr("netbox_ip_address.test", "object_type", "dcim.interface"),
-					resource.TestCheckResourceAttrPair("netbox_ip_address.test", "interface_id", "netbox_device_interface.test", "id"),
+					resource.TestCheckResourceAttr("netbox_ip_address.test", "virtual_machine_interface_id", "0"),
+					resource.TestCheckResourceAttrPair("netbox_ip_address.test", "device_interface_id", "netbox_device_interface.test", "id"),
 				),
 			},
 			{
@@ -270,6 +272,7 @@ func TestAccNetboxIPAddress_vmByObjectType(t *testing.T) {
 	testName := testAccGetTestName(testSlug)
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNetboxIPAddressFullDependencies(testName) + fmt.Sprintf(`
@@ -282,8 +285,7 @@ resource "netbox_ip_address" "test" {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("netbox_ip_address.test", "ip_address", testIP),
 					resource.TestCheckResourceAttr("netbox_ip_address.test", "status", "active"),
-					resource.TestCheckResourceAttr("netbox_ip_address.test", "object_type", "virtualization.vminterface"),
-					resource.TestCheckResourceAttrPair("netbox_ip_address.test", "interface_id", "netbox_interface.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_ip_address.test", "virtual_machine_interface_id", "netbox_interface.test", "id"),
 				),
 			},
 			{
@@ -302,6 +304,7 @@ func TestAccNetboxIPAddress_vmSwitchStyle(t *testing.T) {
 	testName := testAccGetTestName(testSlug)
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNetboxIPAddressFullDependencies(testName) + fmt.Sprintf(`
@@ -337,6 +340,7 @@ func TestAccNetboxIPAddress_deviceByFieldName(t *testing.T) {
 	testName := testAccGetTestName(testSlug)
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNetboxIPAddressFullDeviceDependencies(testName) + fmt.Sprintf(`
@@ -367,6 +371,7 @@ func TestAccNetboxIPAddress_vmByFieldName(t *testing.T) {
 	testName := testAccGetTestName(testSlug)
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNetboxIPAddressFullDependencies(testName) + fmt.Sprintf(`
@@ -391,11 +396,75 @@ resource "netbox_ip_address" "test" {
 	})
 }
 
+func TestUnitNetboxIPAddress_basic(t *testing.T) {
+	f := testFakeNetbox(t)
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testUnitProviders,
+		CheckDestroy: func(*terraform.State) error {
+			if n := f.count("ipam/ip-addresses"); n != 0 {
+				return fmt.Errorf("%d IP addresses left", n)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetboxIPAddressFullDependencies("test") + testAccNetboxIPAddressFullDeviceDependencies("test") + `
+resource "netbox_ip_address" "test" {
+  ip_address                   = "10.0.0.1/24"
+  status                       = "reserved"
+  role                         = "vip"
+  dns_name                     = "test.example.com"
+  description                  = "description"
+  vrf_id                       = netbox_vrf.test.id
+  tenant_id                    = netbox_tenant.test.id
+  virtual_machine_interface_id = netbox_interface.test.id
+  tags                         = [netbox_tag.test.name]
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_ip_address.test", "status", "reserved"),
+					resource.TestCheckResourceAttr("netbox_ip_address.test", "role", "vip"),
+					resource.TestCheckResourceAttr("netbox_ip_address.test", "dns_name", "test.example.com"),
+					resource.TestCheckResourceAttrPair("netbox_ip_address.test", "vrf_id", "netbox_vrf.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_ip_address.test", "tenant_id", "netbox_tenant.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_ip_address.test", "virtual_machine_interface_id", "netbox_interface.test", "id"),
+					resource.TestCheckResourceAttr("netbox_ip_address.test", "tags.0", "test"),
+				),
+			},
+			{
+				ResourceName:            "netbox_ip_address.test",
+				ImportState:             true,
+				ImportStateId:           "10.0.0.1/24@test",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"virtual_machine_interface_id"},
+			},
+			{
+				Config: testAccNetboxIPAddressFullDependencies("test") + testAccNetboxIPAddressFullDeviceDependencies("test") + `
+resource "netbox_ip_address" "test" {
+  ip_address          = "10.0.0.1/24"
+  status              = "active"
+  device_interface_id = netbox_device_interface.test.id
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_ip_address.test", "status", "active"),
+					resource.TestCheckResourceAttr("netbox_ip_address.test", "role", ""),
+					resource.TestCheckResourceAttr("netbox_ip_address.test", "dns_name", ""),
+					resource.TestCheckResourceAttr("netbox_ip_address.test", "vrf_id", "0"),
+					resource.TestCheckResourceAttr("netbox_ip_address.test", "tenant_id", "0"),
+					resource.TestCheckResourceAttr("netbox_ip_address.test", "virtual_machine_interface_id", "0"),
+					resource.TestCheckResourceAttrPair("netbox_ip_address.test", "device_interface_id", "netbox_device_interface.test", "id"),
+					resource.TestCheckResourceAttr("netbox_ip_address.test", "tags.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 // TestAccNetboxIPAddress_standalone tests the case where an ip address is not linked to a vm or device
 func TestAccNetboxIPAddress_standalone(t *testing.T) {
 	testIP := "1.1.1.6/32"
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
@@ -422,6 +491,7 @@ func TestAccNetboxIPAddress_nat(t *testing.T) {
 	testIPInside := "1.1.1.11/32"
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
@@ -474,6 +544,7 @@ func TestAccNetboxIPAddress_invalidConfig(t *testing.T) {
 	testIP := "1.1.1.7/32"
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
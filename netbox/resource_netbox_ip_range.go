@@ -1,11 +1,12 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -14,10 +15,10 @@ var resourceNetboxIPRangeStatusOptions = []string{"active", "reserved", "depreca
 
 func resourceNetboxIPRange() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxIPRangeCreate,
-		Read:   resourceNetboxIPRangeRead,
-		Update: resourceNetboxIPRangeUpdate,
-		Delete: resourceNetboxIPRangeDelete,
+		CreateContext: resourceNetboxIPRangeCreate,
+		ReadContext:   resourceNetboxIPRangeRead,
+		UpdateContext: resourceNetboxIPRangeUpdate,
+		DeleteContext: resourceNetboxIPRangeDelete,
 
 		Description: `:meta:subcategory:IP Address Management (IPAM):From the [official documentation](https://docs.netbox.dev/en/stable/features/ipam/#ip-ranges):
 
@@ -55,7 +56,8 @@ func resourceNetboxIPRange() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
-			tagsKey: tagsSchema,
+			tagsKey:        tagsSchema,
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -63,8 +65,8 @@ func resourceNetboxIPRange() *schema.Resource {
 	}
 }
 
-func resourceNetboxIPRangeCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxIPRangeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	data := models.WritableIPRange{}
 
 	startAddress := d.Get("start_address").(string)
@@ -77,22 +79,26 @@ func resourceNetboxIPRangeCreate(d *schema.ResourceData, m interface{}) error {
 	data.Status = status
 	data.Description = description
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.Tags = tags
 
-	params := ipam.NewIpamIPRangesCreateParams().WithData(&data)
+	params := ipam.NewIpamIPRangesCreateParams().WithContext(ctx).WithData(&data)
 	res, err := api.Ipam.IpamIPRangesCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxIPRangeUpdate(d, m)
+	return resourceNetboxIPRangeUpdate(ctx, d, m)
 }
 
-func resourceNetboxIPRangeRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxIPRangeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamIPRangesReadParams().WithID(id)
+	params := ipam.NewIpamIPRangesReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Ipam.IpamIPRangesRead(params, nil)
 	if err != nil {
@@ -104,7 +110,7 @@ func resourceNetboxIPRangeRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if res.GetPayload().StartAddress != nil {
@@ -135,13 +141,14 @@ func resourceNetboxIPRangeRead(d *schema.ResourceData, m interface{}) error {
 		d.Set("role_id", res.GetPayload().Role.ID)
 	}
 
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, res.GetPayload().Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxIPRangeUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxIPRangeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableIPRange{}
 	startAddress := d.Get("start_address").(string)
@@ -167,20 +174,24 @@ func resourceNetboxIPRangeUpdate(d *schema.ResourceData, m interface{}) error {
 		data.Role = int64ToPtr(int64(roleID.(int)))
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.Tags = tags
 
-	params := ipam.NewIpamIPRangesUpdateParams().WithID(id).WithData(&data)
-	_, err := api.Ipam.IpamIPRangesUpdate(params, nil)
+	params := ipam.NewIpamIPRangesPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
+	_, err = api.Ipam.IpamIPRangesPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
-	return resourceNetboxIPRangeRead(d, m)
+	return resourceNetboxIPRangeRead(ctx, d, m)
 }
 
-func resourceNetboxIPRangeDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxIPRangeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamIPRangesDeleteParams().WithID(id)
+	params := ipam.NewIpamIPRangesDeleteParams().WithContext(ctx).WithID(id)
 	_, err := api.Ipam.IpamIPRangesDelete(params, nil)
 	if err != nil {
 		if errresp, ok := err.(*ipam.IpamIPRangesDeleteDefault); ok {
@@ -189,7 +200,7 @@ func resourceNetboxIPRangeDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxModule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxModuleCreate,
-		Read:   resourceNetboxModuleRead,
-		Update: resourceNetboxModuleUpdate,
-		Delete: resourceNetboxModuleDelete,
+		CreateContext: resourceNetboxModuleCreate,
+		ReadContext:   resourceNetboxModuleRead,
+		UpdateContext: resourceNetboxModuleUpdate,
+		DeleteContext: resourceNetboxModuleDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/module/):
 
@@ -58,8 +59,10 @@ Similar to devices, modules are instantiated from module types, and any componen
 				Type:     schema.TypeString,
 				Optional: true,
 			},
-			tagsKey:         tagsSchema,
-			customFieldsKey: customFieldsSchema,
+			tagsKey:             tagsSchema,
+			customFieldsKey:     customFieldsSchema,
+			customFieldsJSONKey: customFieldsJSONSchema,
+			lastUpdatedKey:      lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -67,8 +70,8 @@ Similar to devices, modules are instantiated from module types, and any componen
 	}
 }
 
-func resourceNetboxModuleCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxModuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableModule{
 		Device:      int64ToPtr(int64(d.Get("device_id").(int))),
@@ -84,29 +87,30 @@ func resourceNetboxModuleCreate(d *schema.ResourceData, m interface{}) error {
 		data.AssetTag = &assetTag
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
 
-	params := dcim.NewDcimModulesCreateParams().WithData(&data)
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.module")
+
+	params := dcim.NewDcimModulesCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimModulesCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxModuleRead(d, m)
+	return resourceNetboxModuleRead(ctx, d, m)
 }
 
-func resourceNetboxModuleRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxModuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimModulesReadParams().WithID(id)
+	params := dcim.NewDcimModulesReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimModulesRead(params, nil)
 
@@ -117,7 +121,7 @@ func resourceNetboxModuleRead(d *schema.ResourceData, m interface{}) error {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	module := res.GetPayload()
@@ -151,17 +155,23 @@ func resourceNetboxModuleRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("description", module.Description)
 	d.Set("comments", module.Comments)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
+	cf := getResourceCustomFields(api, d, res.GetPayload().CustomFields)
 	if cf != nil {
 		d.Set(customFieldsKey, cf)
 	}
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	cfJSON, err := getResourceCustomFieldsJSON(d, res.GetPayload().CustomFields)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set(customFieldsJSONKey, cfJSON)
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, res.GetPayload().Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxModuleUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxModuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -179,32 +189,33 @@ func resourceNetboxModuleUpdate(d *schema.ResourceData, m interface{}) error {
 		data.AssetTag = &assetTag
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
+
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.module")
 
-	params := dcim.NewDcimModulesPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimModulesPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimModulesPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimModulesPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxModuleRead(d, m)
+	return resourceNetboxModuleRead(ctx, d, m)
 }
 
-func resourceNetboxModuleDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxModuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimModulesDeleteParams().WithID(id)
+	params := dcim.NewDcimModulesDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimModulesDelete(params, nil)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
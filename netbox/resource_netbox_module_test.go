@@ -129,7 +129,7 @@ resource "netbox_module" "test" {
 
 func testAccCheckModuleDestroy(s *terraform.State) error {
 	// retrieve the connection established in Provider configuration
-	conn := testAccProvider.Meta().(*client.NetBoxAPI)
+	conn := testAccProvider.Meta().(*providerState)
 
 	// loop through the resources in state, verifying each module
 	// is destroyed
@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxModuleType() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxModuleTypeCreate,
-		Read:   resourceNetboxModuleTypeRead,
-		Update: resourceNetboxModuleTypeUpdate,
-		Delete: resourceNetboxModuleTypeDelete,
+		CreateContext: resourceNetboxModuleTypeCreate,
+		ReadContext:   resourceNetboxModuleTypeRead,
+		UpdateContext: resourceNetboxModuleTypeUpdate,
+		DeleteContext: resourceNetboxModuleTypeDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/moduletype/):
 
@@ -53,8 +54,10 @@ func resourceNetboxModuleType() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
-			tagsKey:         tagsSchema,
-			customFieldsKey: customFieldsSchema,
+			tagsKey:             tagsSchema,
+			customFieldsKey:     customFieldsSchema,
+			customFieldsJSONKey: customFieldsJSONSchema,
+			lastUpdatedKey:      lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -62,8 +65,8 @@ func resourceNetboxModuleType() *schema.Resource {
 	}
 }
 
-func resourceNetboxModuleTypeCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxModuleTypeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableModuleType{
 		Manufacturer: int64ToPtr(int64(d.Get("manufacturer_id").(int))),
@@ -75,29 +78,30 @@ func resourceNetboxModuleTypeCreate(d *schema.ResourceData, m interface{}) error
 		Comments:     getOptionalStr(d, "comments", false),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
 
-	params := dcim.NewDcimModuleTypesCreateParams().WithData(&data)
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.moduletype")
+
+	params := dcim.NewDcimModuleTypesCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimModuleTypesCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxModuleTypeRead(d, m)
+	return resourceNetboxModuleTypeRead(ctx, d, m)
 }
 
-func resourceNetboxModuleTypeRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxModuleTypeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimModuleTypesReadParams().WithID(id)
+	params := dcim.NewDcimModuleTypesReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimModuleTypesRead(params, nil)
 
@@ -108,7 +112,7 @@ func resourceNetboxModuleTypeRead(d *schema.ResourceData, m interface{}) error {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	moduleType := res.GetPayload()
@@ -132,17 +136,23 @@ func resourceNetboxModuleTypeRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("description", moduleType.Description)
 	d.Set("comments", moduleType.Comments)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
+	cf := getResourceCustomFields(api, d, res.GetPayload().CustomFields)
 	if cf != nil {
 		d.Set(customFieldsKey, cf)
 	}
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	cfJSON, err := getResourceCustomFieldsJSON(d, res.GetPayload().CustomFields)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set(customFieldsJSONKey, cfJSON)
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, res.GetPayload().Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxModuleTypeUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxModuleTypeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -156,32 +166,33 @@ func resourceNetboxModuleTypeUpdate(d *schema.ResourceData, m interface{}) error
 		Comments:     getOptionalStr(d, "comments", true),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
+
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.moduletype")
 
-	params := dcim.NewDcimModuleTypesPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimModuleTypesPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimModuleTypesPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimModuleTypesPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxModuleTypeRead(d, m)
+	return resourceNetboxModuleTypeRead(ctx, d, m)
 }
 
-func resourceNetboxModuleTypeDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxModuleTypeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimModuleTypesDeleteParams().WithID(id)
+	params := dcim.NewDcimModuleTypesDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimModuleTypesDelete(params, nil)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
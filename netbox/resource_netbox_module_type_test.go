@@ -88,7 +88,7 @@ resource "netbox_module_type" "test" {
 
 func testAccCheckModuleTypeDestroy(s *terraform.State) error {
 	// retrieve the connection established in Provider configuration
-	conn := testAccProvider.Meta().(*client.NetBoxAPI)
+	conn := testAccProvider.Meta().(*providerState)
 
 	// loop through the resources in state, verifying each module type
 	// is destroyed
@@ -0,0 +1,347 @@
+package netbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var objectPathRegexp = regexp.MustCompile(`^/?[A-Za-z0-9_-]+(/[A-Za-z0-9_-]+)*/?$`)
+
+func resourceNetboxObject() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetboxObjectCreate,
+		ReadContext:   resourceNetboxObjectRead,
+		UpdateContext: resourceNetboxObjectUpdate,
+		DeleteContext: resourceNetboxObjectDelete,
+
+		Description: `:meta:subcategory:Extras:Manages an object of any endpoint of the Netbox REST API, e.g. of models not covered by other resources or of plugins like netbox-dns or netbox-bgp.
+
+The object is sent as the JSON ` + "`body`" + `. References to other objects are set by ID, choices by value, just like in the REST API. When the object is read, only the fields set in ` + "`body`" + ` are compared to the object in Netbox. Fields removed from ` + "`body`" + ` are left unchanged in Netbox.
+
+When an object is imported, ` + "`body`" + ` is set to all fields of the object, with references by ID and choices by value. Fields missing in the configuration are no change, so the first plan after the import only shows fields whose values differ.`,
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(objectPathRegexp, "must be an API path like `plugins/bgp/session`"),
+				Description:  "The path of the list endpoint of the object, relative to the API root, e.g. `plugins/bgp/session` or `dcim/sites`.",
+			},
+			"body": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsJSON,
+				// Fields removed from the body are left unchanged in Netbox,
+				// so only the fields of the new body are compared
+				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+					return oldValue != "" && objectBodyContains(oldValue, newValue)
+				},
+				Description: "The fields of the object as a JSON object, e.g. `jsonencode({ name = \"session1\", device = 1 })`.",
+			},
+			"ignore_fields": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Fields of `body` whose values in Netbox are not compared to `body`, e.g. fields that Netbox computes or changes, or write-only fields like passwords.",
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The object as returned by Netbox, as a JSON object. Use `jsondecode` to access its fields.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceNetboxObjectImport,
+		},
+		// The object returned by Netbox changes with the body. HasChange does
+		// not know about the suppressed diffs, so the bodies are compared
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			oldBody, newBody := d.GetChange("body")
+			if d.Id() != "" && (!d.NewValueKnown("body") || !objectBodyContains(oldBody.(string), newBody.(string))) {
+				return d.SetNewComputed("json")
+			}
+			return nil
+		},
+	}
+}
+
+// objectEndpoint returns the endpoint for the path of an object, relative to
+// the API root.
+func objectEndpoint(path string) string {
+	return "/" + strings.Trim(path, "/") + "/"
+}
+
+func resourceNetboxObjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	body, err := decodeObjectJSON(d.Get("body").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var res json.RawMessage
+	err = api.rawAPIRequest(ctx, http.MethodPost, objectEndpoint(d.Get("path").(string)), nil, body, &res)
+	if err != nil {
+		return netboxErrorDiagnostics(err)
+	}
+
+	object, err := decodeObjectJSON(string(res))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id, ok := object["id"]
+	if !ok {
+		return diag.Errorf("the response of Netbox has no `id`: %s", string(res))
+	}
+	d.SetId(fmt.Sprint(id))
+
+	return resourceNetboxObjectRead(ctx, d, m)
+}
+
+func resourceNetboxObjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	var res json.RawMessage
+	err := api.rawAPIRequest(ctx, http.MethodGet, objectEndpoint(d.Get("path").(string))+d.Id()+"/", nil, nil, &res)
+	if err != nil {
+		if isRawAPINotFound(err) {
+			// If the ID is updated to blank, this tells Terraform the resource no longer exists (maybe it was destroyed out of band). Just like the destroy callback, the Read function should gracefully handle this case. https://www.terraform.io/docs/extend/writing-custom-providers.html
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	object, err := decodeObjectJSON(string(res))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	objectJSON, err := json.Marshal(object)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("json", string(objectJSON))
+
+	// Imported objects have no body yet, it is set to all fields of the object
+	if body := d.Get("body").(string); body == "" {
+		bodyJSON, err := json.Marshal(objectImportBody(object))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("body", string(bodyJSON))
+	} else {
+		decoded, err := decodeObjectJSON(body)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		ignored := map[string]bool{}
+		for _, field := range d.Get("ignore_fields").(*schema.Set).List() {
+			ignored[field.(string)] = true
+		}
+		current := map[string]interface{}{}
+		for field, value := range decoded {
+			if objectValue, ok := object[field]; ok && !ignored[field] {
+				current[field] = objectBodyValue(value, objectValue)
+			} else {
+				current[field] = value
+			}
+		}
+		currentJSON, err := json.Marshal(current)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("body", string(currentJSON))
+	}
+
+	return nil
+}
+
+func resourceNetboxObjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	if d.HasChange("body") {
+		body, err := decodeObjectJSON(d.Get("body").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		err = api.rawAPIRequest(ctx, http.MethodPatch, objectEndpoint(d.Get("path").(string))+d.Id()+"/", nil, body, nil)
+		if err != nil {
+			return netboxErrorDiagnostics(err)
+		}
+	}
+
+	return resourceNetboxObjectRead(ctx, d, m)
+}
+
+func resourceNetboxObjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	err := api.rawAPIRequest(ctx, http.MethodDelete, objectEndpoint(d.Get("path").(string))+d.Id()+"/", nil, nil, nil)
+	if err != nil {
+		if isRawAPINotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceNetboxObjectImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	key := strings.Trim(d.Id(), "/")
+	i := strings.LastIndex(key, "/")
+	if i < 0 || !objectPathRegexp.MatchString(key[:i]) {
+		return nil, fmt.Errorf("unexpected format of (%s), expected 'path/id', e.g. 'plugins/bgp/session/12'", d.Id())
+	}
+	if _, err := strconv.ParseInt(key[i+1:], 10, 64); err != nil {
+		return nil, fmt.Errorf("unexpected format of (%s), expected 'path/id', e.g. 'plugins/bgp/session/12'", d.Id())
+	}
+	path, id := key[:i], key[i+1:]
+	d.Set("path", path)
+	d.SetId(id)
+	return []*schema.ResourceData{d}, nil
+}
+
+// decodeObjectJSON decodes a JSON object. Numbers are kept as they are, so
+// that IDs are not turned into floats.
+func decodeObjectJSON(s string) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(s)))
+	decoder.UseNumber()
+	var object map[string]interface{}
+	if err := decoder.Decode(&object); err != nil {
+		return nil, fmt.Errorf("could not decode JSON object: %w", err)
+	}
+	return object, nil
+}
+
+// objectReadOnlyFields are the fields of every object that are not set in a
+// body.
+var objectReadOnlyFields = []string{"id", "url", "display", "display_url", "created", "last_updated"}
+
+// objectImportBody returns the body of an imported object, i.e. all fields of
+// the object except read-only ones, with references by ID and choices by
+// value.
+func objectImportBody(object map[string]interface{}) map[string]interface{} {
+	body := map[string]interface{}{}
+	for field, value := range object {
+		if !slices.Contains(objectReadOnlyFields, field) {
+			body[field] = objectImportValue(value)
+		}
+	}
+	return body
+}
+
+func objectImportValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if choice, ok := v["value"]; ok {
+			if _, ok := v["label"]; ok {
+				return choice
+			}
+		}
+		if id, ok := v["id"]; ok {
+			return id
+		}
+		result := map[string]interface{}{}
+		for field, fieldValue := range v {
+			result[field] = objectImportValue(fieldValue)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = objectImportValue(item)
+		}
+		return result
+	}
+	return value
+}
+
+// objectBodyContains returns true if every field of the JSON body b has the
+// same value in the JSON body a.
+func objectBodyContains(a, b string) bool {
+	var aDecoded, bDecoded map[string]interface{}
+	if json.Unmarshal([]byte(a), &aDecoded) != nil || json.Unmarshal([]byte(b), &bDecoded) != nil {
+		return false
+	}
+	for field, value := range bDecoded {
+		aValue, ok := aDecoded[field]
+		if !ok || !reflect.DeepEqual(aValue, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// objectBodyValue converts a value returned by Netbox into the form of the
+// corresponding value of a body. Netbox returns references as nested objects
+// and choices as objects with value and label, while both are set by their
+// ID or value. IDs may be set as strings, like the IDs of resources. Nested
+// objects are reduced to the fields set in the body.
+func objectBodyValue(body, object interface{}) interface{} {
+	switch b := body.(type) {
+	case json.Number:
+		if o, ok := object.(map[string]interface{}); ok {
+			if id, ok := o["id"]; ok {
+				return id
+			}
+		}
+	case string:
+		if o, ok := object.(map[string]interface{}); ok {
+			if value, ok := o["value"]; ok {
+				return value
+			}
+			if id, ok := o["id"]; ok {
+				if fmt.Sprint(id) == b {
+					return b
+				}
+				return fmt.Sprint(id)
+			}
+		}
+	case map[string]interface{}:
+		o, ok := object.(map[string]interface{})
+		if !ok {
+			return object
+		}
+		result := map[string]interface{}{}
+		for field, value := range b {
+			if objectValue, ok := o[field]; ok {
+				result[field] = objectBodyValue(value, objectValue)
+			} else {
+				result[field] = value
+			}
+		}
+		return result
+	case []interface{}:
+		o, ok := object.([]interface{})
+		if !ok {
+			return object
+		}
+		result := make([]interface{}, len(o))
+		for i, objectValue := range o {
+			// Elements beyond the body are converted like its first element
+			var value interface{}
+			if i < len(b) {
+				value = b[i]
+			} else if len(b) > 0 {
+				value = b[0]
+			}
+			result[i] = objectBodyValue(value, objectValue)
+		}
+		return result
+	}
+	return object
+}
@@ -0,0 +1,213 @@
+package netbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccNetboxObject_basic(t *testing.T) {
+	testSlug := "object_basic"
+	testName := testAccGetTestName(testSlug)
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "netbox_tenant_group" "test" {
+  name = "%[1]s"
+}
+
+resource "netbox_object" "test" {
+  path = "tenancy/tenants"
+  body = jsonencode({
+    name  = "%[1]s"
+    slug  = "%[2]s"
+    group = netbox_tenant_group.test.id
+  })
+}`, testName, testSlug),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_object.test", "path", "tenancy/tenants"),
+					resource.TestCheckResourceAttrSet("netbox_object.test", "json"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "netbox_tenant_group" "test" {
+  name = "%[1]s"
+}
+
+resource "netbox_object" "test" {
+  path = "tenancy/tenants"
+  body = jsonencode({
+    name        = "%[1]s"
+    slug        = "%[2]s"
+    group       = netbox_tenant_group.test.id
+    description = "updated"
+  })
+}`, testName, testSlug),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("netbox_object.test", "json", func(value string) error {
+						var object map[string]interface{}
+						if err := json.Unmarshal([]byte(value), &object); err != nil {
+							return err
+						}
+						if object["description"] != "updated" {
+							return fmt.Errorf("expected description to be updated, got %v", object["description"])
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitNetboxObject_import(t *testing.T) {
+	f := testFakeNetbox(t)
+	// The tenant is created outside of Terraform and imported
+	create := func(path, body string) {
+		r, err := http.NewRequest(http.MethodPost, f.URL+"/api/"+path+"/", strings.NewReader(body))
+		assert.NoError(t, err)
+		r.Header.Set("Authorization", "Token "+fakeNetboxToken)
+		r.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(r)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+	config := func(description string) string {
+		return fmt.Sprintf(`
+resource "netbox_object" "test" {
+  path = "tenancy/tenants"
+  body = jsonencode({
+    name        = "test"
+    group       = 1
+    description = "%s"
+  })
+}`, description)
+	}
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testUnitProviders,
+		CheckDestroy: func(*terraform.State) error {
+			if n := f.count("tenancy/tenants"); n != 0 {
+				return fmt.Errorf("%d tenants left", n)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					create("tenancy/tenant-groups", `{"name": "test", "slug": "test"}`)
+					create("tenancy/tenants", `{"name": "test", "slug": "test", "group": 1, "description": "first"}`)
+				},
+				Config:             config("first"),
+				ResourceName:       "netbox_object.test",
+				ImportState:        true,
+				ImportStateId:      "tenancy/tenants/1",
+				ImportStatePersist: true,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					body, err := decodeObjectJSON(states[0].Attributes["body"])
+					if err != nil {
+						return err
+					}
+					if body["slug"] != "test" || body["group"] != json.Number("1") {
+						return fmt.Errorf("expected the body to have all fields of the tenant, got %v", body)
+					}
+					return nil
+				},
+			},
+			{
+				// The fields of the imported body missing in the
+				// configuration are no change
+				Config:   config("first"),
+				PlanOnly: true,
+			},
+			{
+				Config: config("second"),
+				Check: resource.TestCheckResourceAttrWith("netbox_object.test", "json", func(value string) error {
+					var object map[string]interface{}
+					if err := json.Unmarshal([]byte(value), &object); err != nil {
+						return err
+					}
+					if object["description"] != "second" {
+						return fmt.Errorf("expected description to be second, got %v", object["description"])
+					}
+					return nil
+				}),
+			},
+		},
+	})
+}
+
+func TestObjectImportBody(t *testing.T) {
+	object, err := decodeObjectJSON(`{
+		"id": 12,
+		"url": "http://netbox/api/tenancy/tenants/12/",
+		"display": "tenant1",
+		"name": "tenant1",
+		"group": {"id": 3, "name": "group1"},
+		"status": {"value": "active", "label": "Active"},
+		"tags": [{"id": 4, "name": "bgp", "slug": "bgp"}],
+		"custom_fields": {"asn": 65000, "site": {"id": 5, "name": "site1"}},
+		"last_updated": "2024-01-01T00:00:00Z"
+	}`)
+	assert.NoError(t, err)
+
+	expected, err := decodeObjectJSON(`{"name": "tenant1", "group": 3, "status": "active", "tags": [4], "custom_fields": {"asn": 65000, "site": 5}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, objectImportBody(object))
+}
+
+func TestObjectBodyContains(t *testing.T) {
+	assert.True(t, objectBodyContains(`{"name": "a", "group": 3}`, `{"group": 3}`))
+	assert.True(t, objectBodyContains(`{"name": "a"}`, `{ "name": "a" }`))
+	assert.False(t, objectBodyContains(`{"name": "a", "group": 3}`, `{"group": 4}`))
+	assert.False(t, objectBodyContains(`{"name": "a"}`, `{"name": "a", "group": 3}`))
+}
+
+func TestObjectBodyValue(t *testing.T) {
+	body, err := decodeObjectJSON(`{"name": "session1", "device": 1, "site": "2", "status": "active", "tags": [{"name": "bgp"}], "custom_fields": {"asn": 65000}}`)
+	assert.NoError(t, err)
+	object, err := decodeObjectJSON(`{
+		"id": 12,
+		"name": "session1",
+		"device": {"id": 1, "name": "router1"},
+		"site": {"id": 3, "name": "site1"},
+		"status": {"value": "planned", "label": "Planned"},
+		"tags": [{"id": 3, "name": "bgp", "slug": "bgp"}, {"id": 4, "name": "ebgp", "slug": "ebgp"}],
+		"custom_fields": {"asn": 65000, "other": null}
+	}`)
+	assert.NoError(t, err)
+
+	value := objectBodyValue(body, object)
+	expected, err := decodeObjectJSON(`{"name": "session1", "device": 1, "site": "3", "status": "planned", "tags": [{"name": "bgp"}, {"name": "ebgp"}], "custom_fields": {"asn": 65000}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, value)
+}
+
+func TestNetboxObjectImport(t *testing.T) {
+	r := resourceNetboxObject()
+
+	d := r.Data(nil)
+	d.SetId("plugins/bgp/session/12")
+	imported, err := r.Importer.StateContext(context.Background(), d, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "12", imported[0].Id())
+	assert.Equal(t, "plugins/bgp/session", imported[0].Get("path"))
+
+	for _, id := range []string{"12", "plugins/bgp/session/", "plugins?x/12"} {
+		d := r.Data(nil)
+		d.SetId(id)
+		_, err := r.Importer.StateContext(context.Background(), d, nil)
+		assert.Error(t, err, id)
+	}
+}
@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxPlatform() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxPlatformCreate,
-		Read:   resourceNetboxPlatformRead,
-		Update: resourceNetboxPlatformUpdate,
-		Delete: resourceNetboxPlatformDelete,
+		CreateContext: resourceNetboxPlatformCreate,
+		ReadContext:   resourceNetboxPlatformRead,
+		UpdateContext: resourceNetboxPlatformUpdate,
+		DeleteContext: resourceNetboxPlatformDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/features/devices/#platforms):
 
@@ -36,6 +37,7 @@ func resourceNetboxPlatform() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -43,8 +45,8 @@ func resourceNetboxPlatform() *schema.Resource {
 	}
 }
 
-func resourceNetboxPlatformCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPlatformCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 
@@ -68,23 +70,23 @@ func resourceNetboxPlatformCreate(d *schema.ResourceData, m interface{}) error {
 		data.Manufacturer = int64ToPtr(int64(manufacturerIDValue.(int)))
 	}
 
-	params := dcim.NewDcimPlatformsCreateParams().WithData(&data)
+	params := dcim.NewDcimPlatformsCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimPlatformsCreate(params, nil)
 	if err != nil {
 		//return errors.New(getTextFromError(err))
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxPlatformRead(d, m)
+	return resourceNetboxPlatformRead(ctx, d, m)
 }
 
-func resourceNetboxPlatformRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPlatformRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimPlatformsReadParams().WithID(id)
+	params := dcim.NewDcimPlatformsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimPlatformsRead(params, nil)
 
@@ -97,7 +99,7 @@ func resourceNetboxPlatformRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	result := res.GetPayload()
@@ -107,11 +109,13 @@ func resourceNetboxPlatformRead(d *schema.ResourceData, m interface{}) error {
 	if result.Manufacturer != nil {
 		d.Set("manufacturer_id", result.Manufacturer.ID)
 	}
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
+
 	return nil
 }
 
-func resourceNetboxPlatformUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPlatformUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritablePlatform{}
@@ -136,21 +140,21 @@ func resourceNetboxPlatformUpdate(d *schema.ResourceData, m interface{}) error {
 		data.Manufacturer = int64ToPtr(int64(manufacturerIDValue.(int)))
 	}
 
-	params := dcim.NewDcimPlatformsPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimPlatformsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimPlatformsPartialUpdate(params, nil)
+	_, err := api.Dcim.DcimPlatformsPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxPlatformRead(d, m)
+	return resourceNetboxPlatformRead(ctx, d, m)
 }
 
-func resourceNetboxPlatformDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPlatformDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimPlatformsDeleteParams().WithID(id)
+	params := dcim.NewDcimPlatformsDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimPlatformsDelete(params, nil)
 	if err != nil {
@@ -160,7 +164,7 @@ func resourceNetboxPlatformDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
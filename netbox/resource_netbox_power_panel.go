@@ -1,20 +1,21 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceNetboxPowerPanel() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxPowerPanelCreate,
-		Read:   resourceNetboxPowerPanelRead,
-		Update: resourceNetboxPowerPanelUpdate,
-		Delete: resourceNetboxPowerPanelDelete,
+		CreateContext: resourceNetboxPowerPanelCreate,
+		ReadContext:   resourceNetboxPowerPanelRead,
+		UpdateContext: resourceNetboxPowerPanelUpdate,
+		DeleteContext: resourceNetboxPowerPanelDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/powerpanel/):
 
@@ -41,8 +42,10 @@ func resourceNetboxPowerPanel() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
-			tagsKey:         tagsSchema,
-			customFieldsKey: customFieldsSchema,
+			tagsKey:             tagsSchema,
+			customFieldsKey:     customFieldsSchema,
+			customFieldsJSONKey: customFieldsJSONSchema,
+			lastUpdatedKey:      lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -50,8 +53,8 @@ func resourceNetboxPowerPanel() *schema.Resource {
 	}
 }
 
-func resourceNetboxPowerPanelCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPowerPanelCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritablePowerPanel{
 		Site:        int64ToPtr(int64(d.Get("site_id").(int))),
@@ -61,29 +64,30 @@ func resourceNetboxPowerPanelCreate(d *schema.ResourceData, m interface{}) error
 		Comments:    getOptionalStr(d, "comments", false),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
 
-	params := dcim.NewDcimPowerPanelsCreateParams().WithData(&data)
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.powerpanel")
+
+	params := dcim.NewDcimPowerPanelsCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimPowerPanelsCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxPowerPanelRead(d, m)
+	return resourceNetboxPowerPanelRead(ctx, d, m)
 }
 
-func resourceNetboxPowerPanelRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPowerPanelRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimPowerPanelsReadParams().WithID(id)
+	params := dcim.NewDcimPowerPanelsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimPowerPanelsRead(params, nil)
 
@@ -94,7 +98,7 @@ func resourceNetboxPowerPanelRead(d *schema.ResourceData, m interface{}) error {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	powerPanel := res.GetPayload()
@@ -116,17 +120,23 @@ func resourceNetboxPowerPanelRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("description", powerPanel.Description)
 	d.Set("comments", powerPanel.Comments)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
+	cf := getResourceCustomFields(api, d, res.GetPayload().CustomFields)
 	if cf != nil {
 		d.Set(customFieldsKey, cf)
 	}
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	cfJSON, err := getResourceCustomFieldsJSON(d, res.GetPayload().CustomFields)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set(customFieldsJSONKey, cfJSON)
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, res.GetPayload().Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxPowerPanelUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPowerPanelUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -138,32 +148,33 @@ func resourceNetboxPowerPanelUpdate(d *schema.ResourceData, m interface{}) error
 		Comments:    getOptionalStr(d, "comments", true),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
+
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.powerpanel")
 
-	params := dcim.NewDcimPowerPanelsPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimPowerPanelsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimPowerPanelsPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimPowerPanelsPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxPowerPanelRead(d, m)
+	return resourceNetboxPowerPanelRead(ctx, d, m)
 }
 
-func resourceNetboxPowerPanelDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPowerPanelDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimPowerPanelsDeleteParams().WithID(id)
+	params := dcim.NewDcimPowerPanelsDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimPowerPanelsDelete(params, nil)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -87,7 +87,7 @@ resource "netbox_power_panel" "test" {
 
 func testAccCheckPowerPanelDestroy(s *terraform.State) error {
 	// retrieve the connection established in Provider configuration
-	conn := testAccProvider.Meta().(*client.NetBoxAPI)
+	conn := testAccProvider.Meta().(*providerState)
 
 	// loop through the resources in state, verifying each power panel
 	// is destroyed
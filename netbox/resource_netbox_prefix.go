@@ -1,11 +1,12 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -14,10 +15,10 @@ var resourceNetboxPrefixStatusOptions = []string{"active", "container", "reserve
 
 func resourceNetboxPrefix() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxPrefixCreate,
-		Read:   resourceNetboxPrefixRead,
-		Update: resourceNetboxPrefixUpdate,
-		Delete: resourceNetboxPrefixDelete,
+		CreateContext: resourceNetboxPrefixCreate,
+		ReadContext:   resourceNetboxPrefixRead,
+		UpdateContext: resourceNetboxPrefixUpdate,
+		DeleteContext: resourceNetboxPrefixDelete,
 
 		Description: `:meta:subcategory:IP Address Management (IPAM):From the [official documentation](https://docs.netbox.dev/en/stable/features/ipam/#prefixes):
 
@@ -69,16 +70,18 @@ func resourceNetboxPrefix() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
-			customFieldsKey: customFieldsSchema,
-			tagsKey:         tagsSchema,
+			customFieldsKey:     customFieldsSchema,
+			customFieldsJSONKey: customFieldsJSONSchema,
+			tagsKey:             tagsSchema,
+			lastUpdatedKey:      lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 	}
 }
-func resourceNetboxPrefixCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPrefixCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	data := models.WritablePrefix{}
 
 	prefix := d.Get("prefix").(string)
@@ -115,27 +118,28 @@ func resourceNetboxPrefixCreate(d *schema.ResourceData, m interface{}) error {
 		data.Role = int64ToPtr(int64(roleID.(int)))
 	}
 
-	cf, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = cf
-	}
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "ipam.prefix")
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.Tags = tags
 
-	params := ipam.NewIpamPrefixesCreateParams().WithData(&data)
+	params := ipam.NewIpamPrefixesCreateParams().WithContext(ctx).WithData(&data)
 	res, err := api.Ipam.IpamPrefixesCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxPrefixRead(d, m)
+	return resourceNetboxPrefixRead(ctx, d, m)
 }
 
-func resourceNetboxPrefixRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPrefixRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamPrefixesReadParams().WithID(id)
+	params := ipam.NewIpamPrefixesReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Ipam.IpamPrefixesRead(params, nil)
 	if err != nil {
@@ -147,7 +151,7 @@ func resourceNetboxPrefixRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("description", res.GetPayload().Description)
@@ -190,19 +194,25 @@ func resourceNetboxPrefixRead(d *schema.ResourceData, m interface{}) error {
 		d.Set("role_id", nil)
 	}
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
+	cf := getResourceCustomFields(api, d, res.GetPayload().CustomFields)
 	if cf != nil {
 		d.Set(customFieldsKey, cf)
 	}
+	cfJSON, err := getResourceCustomFieldsJSON(d, res.GetPayload().CustomFields)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set(customFieldsJSONKey, cfJSON)
 
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, res.GetPayload().Tags))
 	// FIGURE OUT NESTED VRF AND NESTED VLAN (from maybe interfaces?)
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxPrefixUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPrefixUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritablePrefix{}
 	prefix := d.Get("prefix").(string)
@@ -242,24 +252,26 @@ func resourceNetboxPrefixUpdate(d *schema.ResourceData, m interface{}) error {
 		data.Role = int64ToPtr(int64(roleID.(int)))
 	}
 
-	if cf, ok := d.GetOk(customFieldsKey); ok {
-		data.CustomFields = cf
-	}
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "ipam.prefix")
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.Tags = tags
 
-	params := ipam.NewIpamPrefixesUpdateParams().WithID(id).WithData(&data)
-	_, err := api.Ipam.IpamPrefixesUpdate(params, nil)
+	params := ipam.NewIpamPrefixesPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
+	_, err = api.Ipam.IpamPrefixesPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
-	return resourceNetboxPrefixRead(d, m)
+	return resourceNetboxPrefixRead(ctx, d, m)
 }
 
-func resourceNetboxPrefixDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPrefixDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamPrefixesDeleteParams().WithID(id)
+	params := ipam.NewIpamPrefixesDeleteParams().WithContext(ctx).WithID(id)
 	_, err := api.Ipam.IpamPrefixesDelete(params, nil)
 	if err != nil {
 		if errresp, ok := err.(*ipam.IpamPrefixesDeleteDefault); ok {
@@ -268,7 +280,7 @@ func resourceNetboxPrefixDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("")
 	return nil
@@ -10,6 +10,7 @@ import (
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func testAccNetboxPrefixFullDependencies(testName string, testSlug string, testVid string) string {
@@ -46,6 +47,104 @@ resource "netbox_vlan" "test" {
 `, testName, testSlug, testVid)
 }
 
+func TestUnitNetboxPrefix_basic(t *testing.T) {
+	f := testFakeNetbox(t)
+	dependencies := `
+resource "netbox_tag" "test" {
+  name = "test"
+}
+
+resource "netbox_vrf" "test" {
+  name = "test"
+}
+
+resource "netbox_tenant" "test" {
+  name = "test"
+}
+
+resource "netbox_site" "test" {
+  name   = "test"
+  status = "active"
+}
+
+resource "netbox_vlan" "test" {
+  name = "test"
+  vid  = 100
+}
+
+resource "netbox_ipam_role" "test" {
+  name = "test"
+}
+`
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testUnitProviders,
+		CheckDestroy: func(*terraform.State) error {
+			if n := f.count("ipam/prefixes"); n != 0 {
+				return fmt.Errorf("%d prefixes left", n)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: dependencies + `
+resource "netbox_prefix" "test" {
+  prefix        = "10.0.0.0/24"
+  status        = "reserved"
+  description   = "description"
+  is_pool       = true
+  mark_utilized = true
+  vrf_id        = netbox_vrf.test.id
+  tenant_id     = netbox_tenant.test.id
+  site_id       = netbox_site.test.id
+  vlan_id       = netbox_vlan.test.id
+  role_id       = netbox_ipam_role.test.id
+  tags          = [netbox_tag.test.name]
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_prefix.test", "status", "reserved"),
+					resource.TestCheckResourceAttr("netbox_prefix.test", "is_pool", "true"),
+					resource.TestCheckResourceAttr("netbox_prefix.test", "mark_utilized", "true"),
+					resource.TestCheckResourceAttrPair("netbox_prefix.test", "vrf_id", "netbox_vrf.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_prefix.test", "tenant_id", "netbox_tenant.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_prefix.test", "site_id", "netbox_site.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_prefix.test", "vlan_id", "netbox_vlan.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_prefix.test", "role_id", "netbox_ipam_role.test", "id"),
+					resource.TestCheckResourceAttr("netbox_prefix.test", "tags.0", "test"),
+				),
+			},
+			{
+				ResourceName:      "netbox_prefix.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "netbox_prefix.test",
+				ImportState:       true,
+				ImportStateId:     "test/10.0.0.0/24",
+				ImportStateVerify: true,
+			},
+			{
+				Config: dependencies + `
+resource "netbox_prefix" "test" {
+  prefix = "10.0.0.0/25"
+  status = "active"
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_prefix.test", "prefix", "10.0.0.0/25"),
+					resource.TestCheckResourceAttr("netbox_prefix.test", "description", ""),
+					resource.TestCheckResourceAttr("netbox_prefix.test", "is_pool", "false"),
+					resource.TestCheckResourceAttr("netbox_prefix.test", "vrf_id", "0"),
+					resource.TestCheckResourceAttr("netbox_prefix.test", "tenant_id", "0"),
+					resource.TestCheckResourceAttr("netbox_prefix.test", "site_id", "0"),
+					resource.TestCheckResourceAttr("netbox_prefix.test", "vlan_id", "0"),
+					resource.TestCheckResourceAttr("netbox_prefix.test", "role_id", "0"),
+					resource.TestCheckResourceAttr("netbox_prefix.test", "tags.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccNetboxPrefix_basic(t *testing.T) {
 	testPrefix := "1.1.1.128/25"
 	testSlug := "prefix"
@@ -55,6 +154,7 @@ func TestAccNetboxPrefix_basic(t *testing.T) {
 	testName := testAccGetTestName(testSlug)
 	resource.ParallelTest(t, resource.TestCase{
 		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNetboxPrefixFullDependencies(testName, randomSlug, testVid) + fmt.Sprintf(`
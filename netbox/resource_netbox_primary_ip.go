@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/virtualization"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxPrimaryIP() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxPrimaryIPCreate,
-		Read:   resourceNetboxPrimaryIPRead,
-		Update: resourceNetboxPrimaryIPUpdate,
-		Delete: resourceNetboxPrimaryIPDelete,
+		CreateContext: resourceNetboxPrimaryIPCreate,
+		ReadContext:   resourceNetboxPrimaryIPRead,
+		UpdateContext: resourceNetboxPrimaryIPUpdate,
+		DeleteContext: resourceNetboxPrimaryIPDelete,
 
 		Description: `:meta:subcategory:Virtualization:This resource is used to define the primary IP for a given virtual machine. The primary IP is reflected in the Virtual machine Netbox UI, which identifies the Primary IPv4 and IPv6 addresses.`,
 
@@ -34,6 +35,7 @@ func resourceNetboxPrimaryIP() *schema.Resource {
 				Optional:     true,
 				Default:      4,
 			},
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -41,16 +43,16 @@ func resourceNetboxPrimaryIP() *schema.Resource {
 	}
 }
 
-func resourceNetboxPrimaryIPCreate(d *schema.ResourceData, m interface{}) error {
+func resourceNetboxPrimaryIPCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId(strconv.Itoa(d.Get("virtual_machine_id").(int)))
 
-	return resourceNetboxPrimaryIPUpdate(d, m)
+	return resourceNetboxPrimaryIPUpdate(ctx, d, m)
 }
 
-func resourceNetboxPrimaryIPRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPrimaryIPRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := virtualization.NewVirtualizationVirtualMachinesReadParams().WithID(id)
+	params := virtualization.NewVirtualizationVirtualMachinesReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Virtualization.VirtualizationVirtualMachinesRead(params, nil)
 	if err != nil {
@@ -62,7 +64,7 @@ func resourceNetboxPrimaryIPRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	IPAddressVersion := d.Get("ip_address_version")
@@ -78,88 +80,28 @@ func resourceNetboxPrimaryIPRead(d *schema.ResourceData, m interface{}) error {
 		return nil
 	}
 	d.Set("virtual_machine_id", res.GetPayload().ID)
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
+
 	return nil
 }
 
-func resourceNetboxPrimaryIPUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPrimaryIPUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	virtualMachineID := int64(d.Get("virtual_machine_id").(int))
 	IPAddressID := int64(d.Get("ip_address_id").(int))
 	IPAddressVersion := int64(d.Get("ip_address_version").(int))
 
-	// because the go-netbox library does not have patch support atm, we have to get the whole object and re-put it
-
-	// first, get the vm
-	readParams := virtualization.NewVirtualizationVirtualMachinesReadParams().WithID(virtualMachineID)
-	res, err := api.Virtualization.VirtualizationVirtualMachinesRead(readParams, nil)
-	if err != nil {
-		return err
-	}
-
-	vm := res.GetPayload()
-
-	// then update the FULL vm with ALL tracked attributes
+	// Only the primary IP address is sent, all other fields of the virtual
+	// machine are left alone
 	data := models.WritableVirtualMachineWithConfigContext{}
-	data.Name = vm.Name
-	data.Tags = vm.Tags
-	// the netbox API sends the URL property as part of NestedTag, but it does not accept the URL property when we send it back
-	// so set it to empty
-	// display too
-	for _, tag := range data.Tags {
-		tag.URL = ""
-		tag.Display = ""
-	}
-	data.Comments = vm.Comments
-	data.Description = vm.Description
-	data.Memory = vm.Memory
-	data.Vcpus = vm.Vcpus
-	data.Disk = vm.Disk
-
-	if vm.Cluster != nil {
-		data.Cluster = &vm.Cluster.ID
-	}
-
-	if vm.Site != nil {
-		data.Site = &vm.Site.ID
-	}
-
-	if vm.PrimaryIp4 != nil {
-		data.PrimaryIp4 = &vm.PrimaryIp4.ID
-	}
-
-	if vm.PrimaryIp6 != nil {
-		data.PrimaryIp6 = &vm.PrimaryIp6.ID
-	}
-
-	if vm.Platform != nil {
-		data.Platform = &vm.Platform.ID
-	}
-
-	if vm.Tenant != nil {
-		data.Tenant = &vm.Tenant.ID
-	}
-
-	if vm.Role != nil {
-		data.Role = &vm.Role.ID
-	}
-
-	if vm.Device != nil {
-		data.Device = &vm.Device.ID
-	}
-
-	if vm.LocalContextData != nil {
-		data.LocalContextData = vm.LocalContextData
+	field := "primary_ip4"
+	if IPAddressVersion == 6 {
+		field = "primary_ip6"
 	}
 
 	// unset primary ip address if -1 is passed as id
-	if IPAddressID == -1 {
-		if IPAddressVersion == 4 {
-			data.PrimaryIp4 = nil
-		} else {
-			data.PrimaryIp6 = nil
-		}
-	} else {
+	if IPAddressID != -1 {
 		if IPAddressVersion == 4 {
 			data.PrimaryIp4 = &IPAddressID
 		} else {
@@ -167,17 +109,17 @@ func resourceNetboxPrimaryIPUpdate(d *schema.ResourceData, m interface{}) error
 		}
 	}
 
-	updateParams := virtualization.NewVirtualizationVirtualMachinesUpdateParams().WithID(virtualMachineID).WithData(&data)
+	updateParams := virtualization.NewVirtualizationVirtualMachinesPartialUpdateParams().WithContext(ctx).WithID(virtualMachineID).WithData(&data)
 
-	_, err = api.Virtualization.VirtualizationVirtualMachinesUpdate(updateParams, nil)
+	_, err := api.Virtualization.VirtualizationVirtualMachinesPartialUpdate(updateParams, nil, onlyFields(field))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
-	return resourceNetboxPrimaryIPRead(d, m)
+	return resourceNetboxPrimaryIPRead(ctx, d, m)
 }
 
-func resourceNetboxPrimaryIPDelete(d *schema.ResourceData, m interface{}) error {
+func resourceNetboxPrimaryIPDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Set ip_address_id to minus one and go to update. Update will set nil
 	d.Set("ip_address_id", -1)
-	return resourceNetboxPrimaryIPUpdate(d, m)
+	return resourceNetboxPrimaryIPUpdate(ctx, d, m)
 }
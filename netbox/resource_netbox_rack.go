@@ -1,11 +1,12 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -18,10 +19,10 @@ var resourceNetboxRackWidthOptions = []int{10, 19, 21, 23}
 
 func resourceNetboxRack() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxRackCreate,
-		Read:   resourceNetboxRackRead,
-		Update: resourceNetboxRackUpdate,
-		Delete: resourceNetboxRackDelete,
+		CreateContext: resourceNetboxRackCreate,
+		ReadContext:   resourceNetboxRackRead,
+		UpdateContext: resourceNetboxRackUpdate,
+		DeleteContext: resourceNetboxRackDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/rack/):
 
@@ -143,7 +144,9 @@ Each rack is assigned a name and (optionally) a separate facility ID. This is he
 				Type:     schema.TypeString,
 				Optional: true,
 			},
-			customFieldsKey: customFieldsSchema,
+			customFieldsKey:     customFieldsSchema,
+			customFieldsJSONKey: customFieldsJSONSchema,
+			lastUpdatedKey:      lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -151,8 +154,8 @@ Each rack is assigned a name and (optionally) a separate facility ID. This is he
 	}
 }
 
-func resourceNetboxRackCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 	siteID := int64(d.Get("site_id").(int))
@@ -194,29 +197,30 @@ func resourceNetboxRackCreate(d *schema.ResourceData, m interface{}) error {
 	data.Description = getOptionalStr(d, "description", false)
 	data.Comments = getOptionalStr(d, "comments", false)
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
 
-	params := dcim.NewDcimRacksCreateParams().WithData(&data)
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.rack")
+
+	params := dcim.NewDcimRacksCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimRacksCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxRackRead(d, m)
+	return resourceNetboxRackRead(ctx, d, m)
 }
 
-func resourceNetboxRackRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimRacksReadParams().WithID(id)
+	params := dcim.NewDcimRacksReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimRacksRead(params, nil)
 
@@ -229,7 +233,7 @@ func resourceNetboxRackRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	rack := res.GetPayload()
@@ -308,17 +312,23 @@ func resourceNetboxRackRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("description", rack.Description)
 	d.Set("comments", rack.Comments)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
+	cf := getResourceCustomFields(api, d, res.GetPayload().CustomFields)
 	if cf != nil {
 		d.Set(customFieldsKey, cf)
 	}
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	cfJSON, err := getResourceCustomFieldsJSON(d, res.GetPayload().CustomFields)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set(customFieldsJSONKey, cfJSON)
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, res.GetPayload().Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxRackUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -364,28 +374,29 @@ func resourceNetboxRackUpdate(d *schema.ResourceData, m interface{}) error {
 	data.Description = getOptionalStr(d, "description", true)
 	data.Comments = getOptionalStr(d, "comments", true)
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-
-	cf, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = cf
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
+
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.rack")
 
-	params := dcim.NewDcimRacksPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimRacksPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimRacksPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimRacksPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxRackRead(d, m)
+	return resourceNetboxRackRead(ctx, d, m)
 }
 
-func resourceNetboxRackDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimRacksDeleteParams().WithID(id)
+	params := dcim.NewDcimRacksDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimRacksDelete(params, nil)
 	if err != nil {
@@ -395,7 +406,7 @@ func resourceNetboxRackDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -1,20 +1,21 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceNetboxRackReservation() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxRackReservationCreate,
-		Read:   resourceNetboxRackReservationRead,
-		Update: resourceNetboxRackReservationUpdate,
-		Delete: resourceNetboxRackReservationDelete,
+		CreateContext: resourceNetboxRackReservationCreate,
+		ReadContext:   resourceNetboxRackReservationRead,
+		UpdateContext: resourceNetboxRackReservationUpdate,
+		DeleteContext: resourceNetboxRackReservationDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/rackreservation/):
 
@@ -49,7 +50,8 @@ func resourceNetboxRackReservation() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
-			tagsKey: tagsSchema,
+			tagsKey:        tagsSchema,
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -57,12 +59,15 @@ func resourceNetboxRackReservation() *schema.Resource {
 	}
 }
 
-func resourceNetboxRackReservationCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackReservationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	params := dcim.NewDcimRackReservationsCreateParams().WithData(
+	params := dcim.NewDcimRackReservationsCreateParams().WithContext(ctx).WithData(
 		&models.WritableRackReservation{
 			Rack:        getOptionalInt(d, "rack_id"),
 			Units:       toInt64PtrList(d.Get("units")),
@@ -76,18 +81,18 @@ func resourceNetboxRackReservationCreate(d *schema.ResourceData, m interface{})
 
 	res, err := api.Dcim.DcimRackReservationsCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxRackReservationRead(d, m)
+	return resourceNetboxRackReservationRead(ctx, d, m)
 }
 
-func resourceNetboxRackReservationRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackReservationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimRackReservationsReadParams().WithID(id)
+	params := dcim.NewDcimRackReservationsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimRackReservationsRead(params, nil)
 	if err != nil {
@@ -99,7 +104,7 @@ func resourceNetboxRackReservationRead(d *schema.ResourceData, m interface{}) er
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	rackRes := res.GetPayload()
@@ -126,16 +131,21 @@ func resourceNetboxRackReservationRead(d *schema.ResourceData, m interface{}) er
 
 	d.Set("comments", rackRes.Comments)
 
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, res.GetPayload().Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
+
 	return nil
 }
 
-func resourceNetboxRackReservationUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackReservationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	data := models.WritableRackReservation{
 		Rack:        getOptionalInt(d, "rack_id"),
@@ -147,21 +157,21 @@ func resourceNetboxRackReservationUpdate(d *schema.ResourceData, m interface{})
 		Tags:        tags,
 	}
 
-	params := dcim.NewDcimRackReservationsPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimRackReservationsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimRackReservationsPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimRackReservationsPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxRackReservationRead(d, m)
+	return resourceNetboxRackReservationRead(ctx, d, m)
 }
 
-func resourceNetboxRackReservationDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackReservationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimRackReservationsDeleteParams().WithID(id)
+	params := dcim.NewDcimRackReservationsDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimRackReservationsDelete(params, nil)
 	if err != nil {
@@ -171,7 +181,7 @@ func resourceNetboxRackReservationDelete(d *schema.ResourceData, m interface{})
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxRackRole() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxRackRoleCreate,
-		Read:   resourceNetboxRackRoleRead,
-		Update: resourceNetboxRackRoleUpdate,
-		Delete: resourceNetboxRackRoleDelete,
+		CreateContext: resourceNetboxRackRoleCreate,
+		ReadContext:   resourceNetboxRackRoleRead,
+		UpdateContext: resourceNetboxRackRoleUpdate,
+		DeleteContext: resourceNetboxRackRoleDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/rackrole/):
 
@@ -40,7 +41,8 @@ func resourceNetboxRackRole() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
-			tagsKey: tagsSchema,
+			tagsKey:        tagsSchema,
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -48,8 +50,8 @@ func resourceNetboxRackRole() *schema.Resource {
 	}
 }
 
-func resourceNetboxRackRoleCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 	slugValue, slugOk := d.GetOk("slug")
@@ -65,9 +67,12 @@ func resourceNetboxRackRoleCreate(d *schema.ResourceData, m interface{}) error {
 	color := d.Get("color_hex").(string)
 	description := getOptionalStr(d, "description", false)
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	params := dcim.NewDcimRackRolesCreateParams().WithData(
+	params := dcim.NewDcimRackRolesCreateParams().WithContext(ctx).WithData(
 		&models.RackRole{
 			Name:        &name,
 			Slug:        &slug,
@@ -79,18 +84,18 @@ func resourceNetboxRackRoleCreate(d *schema.ResourceData, m interface{}) error {
 
 	res, err := api.Dcim.DcimRackRolesCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxRackRoleRead(d, m)
+	return resourceNetboxRackRoleRead(ctx, d, m)
 }
 
-func resourceNetboxRackRoleRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimRackRolesReadParams().WithID(id)
+	params := dcim.NewDcimRackRolesReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimRackRolesRead(params, nil)
 	if err != nil {
@@ -102,7 +107,7 @@ func resourceNetboxRackRoleRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	rackRole := res.GetPayload()
@@ -111,12 +116,14 @@ func resourceNetboxRackRoleRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("slug", rackRole.Slug)
 	d.Set("description", rackRole.Description)
 	d.Set("color_hex", rackRole.Color)
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, res.GetPayload().Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
+
 	return nil
 }
 
-func resourceNetboxRackRoleUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.RackRole{}
@@ -139,24 +146,27 @@ func resourceNetboxRackRoleUpdate(d *schema.ResourceData, m interface{}) error {
 	data.Description = getOptionalStr(d, "description", true)
 	data.Color = color
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	data.Tags = tags
 
-	params := dcim.NewDcimRackRolesPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimRackRolesPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimRackRolesPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimRackRolesPartialUpdate(params, nil, changedFieldsOnly(api, d, map[string][]string{"color": {"color_hex"}}))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxRackRoleRead(d, m)
+	return resourceNetboxRackRoleRead(ctx, d, m)
 }
 
-func resourceNetboxRackRoleDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackRoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimRackRolesDeleteParams().WithID(id)
+	params := dcim.NewDcimRackRolesDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimRackRolesDelete(params, nil)
 	if err != nil {
@@ -166,7 +176,7 @@ func resourceNetboxRackRoleDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -153,7 +153,7 @@ resource "netbox_rack" "test" {
 
 func testAccCheckRackDestroy(s *terraform.State) error {
 	// retrieve the connection established in Provider configuration
-	conn := testAccProvider.Meta().(*client.NetBoxAPI)
+	conn := testAccProvider.Meta().(*providerState)
 
 	// loop through the resources in state, verifying each rack
 	// is destroyed
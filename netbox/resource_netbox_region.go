@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxRegion() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxRegionCreate,
-		Read:   resourceNetboxRegionRead,
-		Update: resourceNetboxRegionUpdate,
-		Delete: resourceNetboxRegionDelete,
+		CreateContext: resourceNetboxRegionCreate,
+		ReadContext:   resourceNetboxRegionRead,
+		UpdateContext: resourceNetboxRegionUpdate,
+		DeleteContext: resourceNetboxRegionDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/features/sites-and-racks/#regions):
 
@@ -47,6 +48,7 @@ func resourceNetboxRegion() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -54,8 +56,8 @@ func resourceNetboxRegion() *schema.Resource {
 	}
 }
 
-func resourceNetboxRegionCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRegionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableRegion{}
 
@@ -81,21 +83,21 @@ func resourceNetboxRegionCreate(d *schema.ResourceData, m interface{}) error {
 
 	data.Tags = []*models.NestedTag{}
 
-	params := dcim.NewDcimRegionsCreateParams().WithData(&data)
+	params := dcim.NewDcimRegionsCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimRegionsCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxRegionRead(d, m)
+	return resourceNetboxRegionRead(ctx, d, m)
 }
 
-func resourceNetboxRegionRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRegionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimRegionsReadParams().WithID(id)
+	params := dcim.NewDcimRegionsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimRegionsRead(params, nil)
 
@@ -108,7 +110,7 @@ func resourceNetboxRegionRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
@@ -119,11 +121,13 @@ func resourceNetboxRegionRead(d *schema.ResourceData, m interface{}) error {
 		d.Set("parent_region_id", nil)
 	}
 	d.Set("description", res.GetPayload().Description)
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
+
 	return nil
 }
 
-func resourceNetboxRegionUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRegionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableRegion{}
@@ -150,21 +154,21 @@ func resourceNetboxRegionUpdate(d *schema.ResourceData, m interface{}) error {
 
 	data.Tags = []*models.NestedTag{}
 
-	params := dcim.NewDcimRegionsPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimRegionsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimRegionsPartialUpdate(params, nil)
+	_, err := api.Dcim.DcimRegionsPartialUpdate(params, nil, changedFieldsOnly(api, d, map[string][]string{"parent": {"parent_region_id"}}))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxRegionRead(d, m)
+	return resourceNetboxRegionRead(ctx, d, m)
 }
 
-func resourceNetboxRegionDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRegionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimRegionsDeleteParams().WithID(id)
+	params := dcim.NewDcimRegionsDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimRegionsDelete(params, nil)
 	if err != nil {
@@ -174,7 +178,7 @@ func resourceNetboxRegionDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
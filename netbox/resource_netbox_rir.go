@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxRir() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxRirCreate,
-		Read:   resourceNetboxRirRead,
-		Update: resourceNetboxRirUpdate,
-		Delete: resourceNetboxRirDelete,
+		CreateContext: resourceNetboxRirCreate,
+		ReadContext:   resourceNetboxRirRead,
+		UpdateContext: resourceNetboxRirUpdate,
+		DeleteContext: resourceNetboxRirDelete,
 
 		Description: `:meta:subcategory:IP Address Management (IPAM):From the [official documentation](https://docs.netbox.dev/en/stable/features/ipam/#regional-internet-registries-rirs):
 
@@ -41,6 +42,7 @@ func resourceNetboxRir() *schema.Resource {
 				Optional: true,
 				Default:  false,
 			},
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -48,8 +50,8 @@ func resourceNetboxRir() *schema.Resource {
 	}
 }
 
-func resourceNetboxRirCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRirCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	data := models.RIR{}
 
 	name := d.Get("name").(string)
@@ -68,20 +70,20 @@ func resourceNetboxRirCreate(d *schema.ResourceData, m interface{}) error {
 	data.Tags = []*models.NestedTag{}
 	data.IsPrivate = d.Get("is_private").(bool)
 
-	params := ipam.NewIpamRirsCreateParams().WithData(&data)
+	params := ipam.NewIpamRirsCreateParams().WithContext(ctx).WithData(&data)
 	res, err := api.Ipam.IpamRirsCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxRirUpdate(d, m)
+	return resourceNetboxRirUpdate(ctx, d, m)
 }
 
-func resourceNetboxRirRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRirRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamRirsReadParams().WithID(id)
+	params := ipam.NewIpamRirsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Ipam.IpamRirsRead(params, nil)
 	if err != nil {
@@ -93,7 +95,7 @@ func resourceNetboxRirRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	rir := res.GetPayload()
@@ -102,12 +104,13 @@ func resourceNetboxRirRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("slug", rir.Slug)
 	d.Set("description", rir.Description)
 	d.Set("is_private", rir.IsPrivate)
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxRirUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRirUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.RIR{}
 
@@ -127,18 +130,18 @@ func resourceNetboxRirUpdate(d *schema.ResourceData, m interface{}) error {
 	data.Tags = []*models.NestedTag{}
 	data.IsPrivate = d.Get("is_private").(bool)
 
-	params := ipam.NewIpamRirsUpdateParams().WithID(id).WithData(&data)
-	_, err := api.Ipam.IpamRirsUpdate(params, nil)
+	params := ipam.NewIpamRirsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
+	_, err := api.Ipam.IpamRirsPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
-	return resourceNetboxRirRead(d, m)
+	return resourceNetboxRirRead(ctx, d, m)
 }
 
-func resourceNetboxRirDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRirDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamRirsDeleteParams().WithID(id)
+	params := ipam.NewIpamRirsDeleteParams().WithContext(ctx).WithID(id)
 	_, err := api.Ipam.IpamRirsDelete(params, nil)
 	if err != nil {
 		if errresp, ok := err.(*ipam.IpamRirsDeleteDefault); ok {
@@ -147,7 +150,7 @@ func resourceNetboxRirDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("")
 	return nil
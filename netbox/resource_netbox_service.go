@@ -1,11 +1,12 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -14,10 +15,10 @@ var resourceNetboxServiceProtocolOptions = []string{"tcp", "udp", "sctp"}
 
 func resourceNetboxService() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxServiceCreate,
-		Read:   resourceNetboxServiceRead,
-		Update: resourceNetboxServiceUpdate,
-		Delete: resourceNetboxServiceDelete,
+		CreateContext: resourceNetboxServiceCreate,
+		ReadContext:   resourceNetboxServiceRead,
+		UpdateContext: resourceNetboxServiceUpdate,
+		DeleteContext: resourceNetboxServiceDelete,
 
 		Description: `:meta:subcategory:IP Address Management (IPAM):From the [official documentation](https://docs.netbox.dev/en/stable/features/services/#services):
 
@@ -55,15 +56,17 @@ func resourceNetboxService() *schema.Resource {
 					Type: schema.TypeInt,
 				},
 			},
-			customFieldsKey: customFieldsSchema,
+			customFieldsKey:     customFieldsSchema,
+			customFieldsJSONKey: customFieldsJSONSchema,
+			lastUpdatedKey:      lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 	}
 }
-func resourceNetboxServiceCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxServiceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	data := models.WritableService{}
 
 	dataName := d.Get("name").(string)
@@ -95,25 +98,22 @@ func resourceNetboxServiceCreate(d *schema.ResourceData, m interface{}) error {
 	data.Tags = []*models.NestedTag{}
 	data.Ipaddresses = []int64{}
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
-	}
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "ipam.service")
 
-	params := ipam.NewIpamServicesCreateParams().WithData(&data)
+	params := ipam.NewIpamServicesCreateParams().WithContext(ctx).WithData(&data)
 	res, err := api.Ipam.IpamServicesCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxServiceUpdate(d, m)
+	return resourceNetboxServiceUpdate(ctx, d, m)
 }
 
-func resourceNetboxServiceRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamServicesReadParams().WithID(id)
+	params := ipam.NewIpamServicesReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Ipam.IpamServicesRead(params, nil)
 	if err != nil {
@@ -125,7 +125,7 @@ func resourceNetboxServiceRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
@@ -133,16 +133,22 @@ func resourceNetboxServiceRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("ports", res.GetPayload().Ports)
 	d.Set("virtual_machine_id", res.GetPayload().VirtualMachine.ID)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
+	cf := getResourceCustomFields(api, d, res.GetPayload().CustomFields)
 	if cf != nil {
 		d.Set(customFieldsKey, cf)
 	}
+	cfJSON, err := getResourceCustomFieldsJSON(d, res.GetPayload().CustomFields)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set(customFieldsJSONKey, cfJSON)
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxServiceUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxServiceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableService{}
 
@@ -172,23 +178,20 @@ func resourceNetboxServiceUpdate(d *schema.ResourceData, m interface{}) error {
 	dataVirtualMachineID := int64(d.Get("virtual_machine_id").(int))
 	data.VirtualMachine = &dataVirtualMachineID
 
-	cf, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = cf
-	}
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "ipam.service")
 
-	params := ipam.NewIpamServicesUpdateParams().WithID(id).WithData(&data)
-	_, err := api.Ipam.IpamServicesUpdate(params, nil)
+	params := ipam.NewIpamServicesPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
+	_, err := api.Ipam.IpamServicesPartialUpdate(params, nil, changedFieldsOnly(api, d, map[string][]string{"ports": {"port", "ports"}}))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
-	return resourceNetboxServiceRead(d, m)
+	return resourceNetboxServiceRead(ctx, d, m)
 }
 
-func resourceNetboxServiceDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxServiceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamServicesDeleteParams().WithID(id)
+	params := ipam.NewIpamServicesDeleteParams().WithContext(ctx).WithID(id)
 	_, err := api.Ipam.IpamServicesDelete(params, nil)
 	if err != nil {
 		if errresp, ok := err.(*ipam.IpamServicesDeleteDefault); ok {
@@ -197,7 +200,7 @@ func resourceNetboxServiceDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
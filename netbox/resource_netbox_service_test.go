@@ -107,7 +107,7 @@ resource "netbox_service" "test_customfield" {
 
 func testAccCheckServiceDestroy(s *terraform.State) error {
 	// retrieve the connection established in Provider configuration
-	conn := testAccProvider.Meta().(*client.NetBoxAPI)
+	conn := testAccProvider.Meta().(*providerState)
 
 	// loop through the resources in state, verifying each service
 	// is destroyed
@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxSiteGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxSiteGroupCreate,
-		Read:   resourceNetboxSiteGroupRead,
-		Update: resourceNetboxSiteGroupUpdate,
-		Delete: resourceNetboxSiteGroupDelete,
+		CreateContext: resourceNetboxSiteGroupCreate,
+		ReadContext:   resourceNetboxSiteGroupRead,
+		UpdateContext: resourceNetboxSiteGroupUpdate,
+		DeleteContext: resourceNetboxSiteGroupDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/features/facilities/#site-groups):
 
@@ -42,6 +43,7 @@ func resourceNetboxSiteGroup() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -49,8 +51,8 @@ func resourceNetboxSiteGroup() *schema.Resource {
 	}
 }
 
-func resourceNetboxSiteGroupCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxSiteGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 	parentID := int64(d.Get("parent_id").(int))
@@ -75,23 +77,23 @@ func resourceNetboxSiteGroupCreate(d *schema.ResourceData, m interface{}) error
 		data.Parent = &parentID
 	}
 
-	params := dcim.NewDcimSiteGroupsCreateParams().WithData(data)
+	params := dcim.NewDcimSiteGroupsCreateParams().WithContext(ctx).WithData(data)
 
 	res, err := api.Dcim.DcimSiteGroupsCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxSiteGroupRead(d, m)
+	return resourceNetboxSiteGroupRead(ctx, d, m)
 }
 
-func resourceNetboxSiteGroupRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxSiteGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
-	params := dcim.NewDcimSiteGroupsReadParams().WithID(id)
+	params := dcim.NewDcimSiteGroupsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimSiteGroupsRead(params, nil)
 	if err != nil {
@@ -103,7 +105,7 @@ func resourceNetboxSiteGroupRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	siteGroup := res.GetPayload()
@@ -113,11 +115,13 @@ func resourceNetboxSiteGroupRead(d *schema.ResourceData, m interface{}) error {
 	if siteGroup.Parent != nil {
 		d.Set("parent_id", siteGroup.Parent.ID)
 	}
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
+
 	return nil
 }
 
-func resourceNetboxSiteGroupUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxSiteGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableSiteGroup{}
@@ -143,21 +147,21 @@ func resourceNetboxSiteGroupUpdate(d *schema.ResourceData, m interface{}) error
 	if parentID != 0 {
 		data.Parent = &parentID
 	}
-	params := dcim.NewDcimSiteGroupsPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimSiteGroupsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimSiteGroupsPartialUpdate(params, nil)
+	_, err := api.Dcim.DcimSiteGroupsPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxSiteGroupRead(d, m)
+	return resourceNetboxSiteGroupRead(ctx, d, m)
 }
 
-func resourceNetboxSiteGroupDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxSiteGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimSiteGroupsDeleteParams().WithID(id)
+	params := dcim.NewDcimSiteGroupsDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimSiteGroupsDelete(params, nil)
 	if err != nil {
@@ -167,7 +171,7 @@ func resourceNetboxSiteGroupDelete(d *schema.ResourceData, m interface{}) error
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
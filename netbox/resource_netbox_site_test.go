@@ -3,12 +3,14 @@ package netbox
 import (
 	"fmt"
 	"log"
+	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccNetboxSite_basic(t *testing.T) {
@@ -66,6 +68,104 @@ resource "netbox_site" "test" {
 	})
 }
 
+func TestUnitNetboxSite_basic(t *testing.T) {
+	f := testFakeNetbox(t)
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testUnitProviders,
+		CheckDestroy: func(*terraform.State) error {
+			if n := f.count("dcim/sites"); n != 0 {
+				return fmt.Errorf("%d sites left", n)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "netbox_site_group" "test" {
+  name = "test"
+}
+
+resource "netbox_region" "test" {
+  name = "test"
+}
+
+resource "netbox_tenant" "test" {
+  name = "test"
+}
+
+resource "netbox_rir" "test" {
+  name = "test"
+}
+
+resource "netbox_asn" "test" {
+  asn    = 1338
+  rir_id = netbox_rir.test.id
+}
+
+resource "netbox_site" "test" {
+  name             = "test site"
+  status           = "planned"
+  description      = "description"
+  facility         = "facility"
+  physical_address = "physical address"
+  shipping_address = "shipping address"
+  latitude         = 48.1
+  longitude        = 11.5
+  timezone         = "Europe/Berlin"
+  group_id         = netbox_site_group.test.id
+  region_id        = netbox_region.test.id
+  tenant_id        = netbox_tenant.test.id
+  asn_ids          = [netbox_asn.test.id]
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_site.test", "slug", "test-site"),
+					resource.TestCheckResourceAttr("netbox_site.test", "status", "planned"),
+					resource.TestCheckResourceAttr("netbox_site.test", "latitude", "48.1"),
+					resource.TestCheckResourceAttr("netbox_site.test", "timezone", "Europe/Berlin"),
+					resource.TestCheckResourceAttrPair("netbox_site.test", "group_id", "netbox_site_group.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_site.test", "region_id", "netbox_region.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_site.test", "tenant_id", "netbox_tenant.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_site.test", "asn_ids.0", "netbox_asn.test", "id"),
+				),
+			},
+			{
+				ResourceName:      "netbox_site.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: `
+resource "netbox_site" "test" {
+  name   = "test site"
+  status = "active"
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_site.test", "status", "active"),
+					resource.TestCheckResourceAttr("netbox_site.test", "description", ""),
+					resource.TestCheckResourceAttr("netbox_site.test", "group_id", "0"),
+					resource.TestCheckResourceAttr("netbox_site.test", "region_id", "0"),
+					resource.TestCheckResourceAttr("netbox_site.test", "tenant_id", "0"),
+					resource.TestCheckResourceAttr("netbox_site.test", "asn_ids.#", "0"),
+				),
+			},
+			{
+				Config: `
+resource "netbox_site" "test" {
+  name   = "test site"
+  status = "active"
+}
+
+resource "netbox_site" "duplicate" {
+  name   = "duplicate"
+  slug   = "test-site"
+  status = "active"
+}`,
+				ExpectError: regexp.MustCompile("site with this slug already exists"),
+			},
+		},
+	})
+}
+
 func TestAccNetboxSite_defaultSlug(t *testing.T) {
 	testSlug := "site_defSlug"
 	testName := testAccGetTestName(testSlug)
@@ -134,6 +234,76 @@ resource "netbox_site" "test" {
 	})
 }
 
+func TestAccNetboxSite_typedCustomFields(t *testing.T) {
+	testSlug := "site_typed_cf"
+	testName := testAccGetTestName(testSlug)
+	testField := strings.ReplaceAll(testAccGetTestName(testSlug), "-", "_")
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "netbox_custom_field" "integer" {
+  name          = "%[1]s_integer"
+  type          = "integer"
+  content_types = ["dcim.site"]
+}
+resource "netbox_custom_field" "boolean" {
+  name          = "%[1]s_boolean"
+  type          = "boolean"
+  content_types = ["dcim.site"]
+}
+resource "netbox_custom_field" "json" {
+  name          = "%[1]s_json"
+  type          = "json"
+  content_types = ["dcim.site"]
+}
+resource "netbox_site" "test" {
+  name = "%[2]s"
+  custom_fields_json = jsonencode({
+    (netbox_custom_field.integer.name) = 42
+    (netbox_custom_field.boolean.name) = true
+    (netbox_custom_field.json.name)    = { id = 1, nested = ["a", "b"] }
+  })
+}`, testField, testName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("netbox_site.test", "custom_fields_json"),
+					resource.TestCheckNoResourceAttr("netbox_site.test", "custom_fields.%"),
+				),
+			},
+			{
+				// Reading the custom fields back must not result in a diff
+				PlanOnly: true,
+				Config: fmt.Sprintf(`
+resource "netbox_custom_field" "integer" {
+  name          = "%[1]s_integer"
+  type          = "integer"
+  content_types = ["dcim.site"]
+}
+resource "netbox_custom_field" "boolean" {
+  name          = "%[1]s_boolean"
+  type          = "boolean"
+  content_types = ["dcim.site"]
+}
+resource "netbox_custom_field" "json" {
+  name          = "%[1]s_json"
+  type          = "json"
+  content_types = ["dcim.site"]
+}
+resource "netbox_site" "test" {
+  name = "%[2]s"
+  custom_fields_json = jsonencode({
+    (netbox_custom_field.integer.name) = 42
+    (netbox_custom_field.boolean.name) = true
+    (netbox_custom_field.json.name)    = { id = 1, nested = ["a", "b"] }
+  })
+}`, testField, testName),
+			},
+		},
+	})
+}
+
 func TestAccNetboxSite_fieldUpdate(t *testing.T) {
 	testSlug := "site_field_update"
 	testName := testAccGetTestName(testSlug)
@@ -1,22 +1,23 @@
 package netbox
 
 import (
+	"context"
 	"regexp"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/extras"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxTag() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxTagCreate,
-		Read:   resourceNetboxTagRead,
-		Update: resourceNetboxTagUpdate,
-		Delete: resourceNetboxTagDelete,
+		CreateContext: resourceNetboxTagCreate,
+		ReadContext:   resourceNetboxTagRead,
+		UpdateContext: resourceNetboxTagUpdate,
+		DeleteContext: resourceNetboxTagDelete,
 
 		Description: `:meta:subcategory:Extras:From the [official documentation](https://docs.netbox.dev/en/stable/models/extras/tag/):
 > Tags are user-defined labels which can be applied to a variety of objects within NetBox. They can be used to establish dimensions of organization beyond the relationships built into NetBox. For example, you might create a tag to identify a particular ownership or condition across several types of objects.
@@ -44,7 +45,8 @@ func resourceNetboxTag() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
-			tagsKey: tagsSchema,
+			tagsKey:        tagsSchema,
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -52,8 +54,8 @@ func resourceNetboxTag() *schema.Resource {
 	}
 }
 
-func resourceNetboxTagCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTagCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 
@@ -68,7 +70,7 @@ func resourceNetboxTagCreate(d *schema.ResourceData, m interface{}) error {
 
 	color := d.Get("color_hex").(string)
 	description := d.Get("description").(string)
-	params := extras.NewExtrasTagsCreateParams().WithData(
+	params := extras.NewExtrasTagsCreateParams().WithContext(ctx).WithData(
 		&models.Tag{
 			Name:        &name,
 			Slug:        &slug,
@@ -80,18 +82,18 @@ func resourceNetboxTagCreate(d *schema.ResourceData, m interface{}) error {
 	res, err := api.Extras.ExtrasTagsCreate(params, nil)
 	if err != nil {
 		//return errors.New(getTextFromError(err))
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxTagRead(d, m)
+	return resourceNetboxTagRead(ctx, d, m)
 }
 
-func resourceNetboxTagRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTagRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := extras.NewExtrasTagsReadParams().WithID(id)
+	params := extras.NewExtrasTagsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Extras.ExtrasTagsRead(params, nil)
 	if err != nil {
@@ -103,18 +105,20 @@ func resourceNetboxTagRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
 	d.Set("slug", res.GetPayload().Slug)
 	d.Set("color_hex", res.GetPayload().Color)
 	d.Set("description", res.GetPayload().Description)
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
+
 	return nil
 }
 
-func resourceNetboxTagUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTagUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.Tag{}
@@ -137,21 +141,21 @@ func resourceNetboxTagUpdate(d *schema.ResourceData, m interface{}) error {
 	data.Color = color
 	data.Description = description
 
-	params := extras.NewExtrasTagsUpdateParams().WithID(id).WithData(&data)
+	params := extras.NewExtrasTagsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Extras.ExtrasTagsUpdate(params, nil)
+	_, err := api.Extras.ExtrasTagsPartialUpdate(params, nil, changedFieldsOnly(api, d, map[string][]string{"color": {"color_hex"}}))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxTagRead(d, m)
+	return resourceNetboxTagRead(ctx, d, m)
 }
 
-func resourceNetboxTagDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTagDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := extras.NewExtrasTagsDeleteParams().WithID(id)
+	params := extras.NewExtrasTagsDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Extras.ExtrasTagsDelete(params, nil)
 	if err != nil {
@@ -161,7 +165,7 @@ func resourceNetboxTagDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/extras"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccNetboxTag_basic(t *testing.T) {
@@ -43,6 +44,52 @@ resource "netbox_tag" "test" {
 	})
 }
 
+func TestUnitNetboxTag_basic(t *testing.T) {
+	f := testFakeNetbox(t)
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testUnitProviders,
+		CheckDestroy: func(*terraform.State) error {
+			if n := f.count("extras/tags"); n != 0 {
+				return fmt.Errorf("%d tags left", n)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "netbox_tag" "test" {
+  name        = "test tag"
+  color_hex   = "112233"
+  description = "description"
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_tag.test", "slug", "test-tag"),
+					resource.TestCheckResourceAttr("netbox_tag.test", "color_hex", "112233"),
+					resource.TestCheckResourceAttr("netbox_tag.test", "description", "description"),
+				),
+			},
+			{
+				ResourceName:      "netbox_tag.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: `
+resource "netbox_tag" "test" {
+  name      = "test tag"
+  slug      = "test"
+  color_hex = "445566"
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_tag.test", "slug", "test"),
+					resource.TestCheckResourceAttr("netbox_tag.test", "color_hex", "445566"),
+					resource.TestCheckResourceAttr("netbox_tag.test", "description", ""),
+				),
+			},
+		},
+	})
+}
+
 func TestAccNetboxTag_defaultSlug(t *testing.T) {
 	testSlug := "tag_defSlug"
 	testName := testAccGetTestName(testSlug)
@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/tenancy"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxTenant() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxTenantCreate,
-		Read:   resourceNetboxTenantRead,
-		Update: resourceNetboxTenantUpdate,
-		Delete: resourceNetboxTenantDelete,
+		CreateContext: resourceNetboxTenantCreate,
+		ReadContext:   resourceNetboxTenantRead,
+		UpdateContext: resourceNetboxTenantUpdate,
+		DeleteContext: resourceNetboxTenantDelete,
 
 		Description: `:meta:subcategory:Tenancy:From the [official documentation](https://docs.netbox.dev/en/stable/features/tenancy/#tenants):
 
@@ -43,6 +44,7 @@ func resourceNetboxTenant() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -50,8 +52,8 @@ func resourceNetboxTenant() *schema.Resource {
 	}
 }
 
-func resourceNetboxTenantCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTenantCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 	groupID := int64(d.Get("group_id").(int))
@@ -66,7 +68,10 @@ func resourceNetboxTenantCreate(d *schema.ResourceData, m interface{}) error {
 		slug = slugValue.(string)
 	}
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	data := &models.WritableTenant{}
 
@@ -79,22 +84,22 @@ func resourceNetboxTenantCreate(d *schema.ResourceData, m interface{}) error {
 		data.Group = &groupID
 	}
 
-	params := tenancy.NewTenancyTenantsCreateParams().WithData(data)
+	params := tenancy.NewTenancyTenantsCreateParams().WithContext(ctx).WithData(data)
 
 	res, err := api.Tenancy.TenancyTenantsCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxTenantRead(d, m)
+	return resourceNetboxTenantRead(ctx, d, m)
 }
 
-func resourceNetboxTenantRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTenantRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := tenancy.NewTenancyTenantsReadParams().WithID(id)
+	params := tenancy.NewTenancyTenantsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Tenancy.TenancyTenantsRead(params, nil)
 	if err != nil {
@@ -106,7 +111,7 @@ func resourceNetboxTenantRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
@@ -114,13 +119,17 @@ func resourceNetboxTenantRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("description", res.GetPayload().Description)
 	if res.GetPayload().Group != nil {
 		d.Set("group_id", res.GetPayload().Group.ID)
+	} else {
+		d.Set("group_id", nil)
 	}
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, res.GetPayload().Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxTenantUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTenantUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableTenant{}
@@ -137,7 +146,10 @@ func resourceNetboxTenantUpdate(d *schema.ResourceData, m interface{}) error {
 		slug = slugValue.(string)
 	}
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	data.Slug = &slug
 	data.Name = &name
@@ -147,21 +159,21 @@ func resourceNetboxTenantUpdate(d *schema.ResourceData, m interface{}) error {
 		data.Group = &groupID
 	}
 
-	params := tenancy.NewTenancyTenantsPartialUpdateParams().WithID(id).WithData(&data)
+	params := tenancy.NewTenancyTenantsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Tenancy.TenancyTenantsPartialUpdate(params, nil)
+	_, err = api.Tenancy.TenancyTenantsPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxTenantRead(d, m)
+	return resourceNetboxTenantRead(ctx, d, m)
 }
 
-func resourceNetboxTenantDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTenantDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := tenancy.NewTenancyTenantsDeleteParams().WithID(id)
+	params := tenancy.NewTenancyTenantsDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Tenancy.TenancyTenantsDelete(params, nil)
 	if err != nil {
@@ -171,7 +183,7 @@ func resourceNetboxTenantDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
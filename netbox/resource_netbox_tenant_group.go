@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/tenancy"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxTenantGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxTenantGroupCreate,
-		Read:   resourceNetboxTenantGroupRead,
-		Update: resourceNetboxTenantGroupUpdate,
-		Delete: resourceNetboxTenantGroupDelete,
+		CreateContext: resourceNetboxTenantGroupCreate,
+		ReadContext:   resourceNetboxTenantGroupRead,
+		UpdateContext: resourceNetboxTenantGroupUpdate,
+		DeleteContext: resourceNetboxTenantGroupDelete,
 
 		Description: `:meta:subcategory:Tenancy:From the [official documentation](https://docs.netbox.dev/en/stable/features/tenancy/#tenant-groups):
 
@@ -42,6 +43,7 @@ func resourceNetboxTenantGroup() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -49,8 +51,8 @@ func resourceNetboxTenantGroup() *schema.Resource {
 	}
 }
 
-func resourceNetboxTenantGroupCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTenantGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 	parentID := int64(d.Get("parent_id").(int))
@@ -75,23 +77,23 @@ func resourceNetboxTenantGroupCreate(d *schema.ResourceData, m interface{}) erro
 		data.Parent = &parentID
 	}
 
-	params := tenancy.NewTenancyTenantGroupsCreateParams().WithData(data)
+	params := tenancy.NewTenancyTenantGroupsCreateParams().WithContext(ctx).WithData(data)
 
 	res, err := api.Tenancy.TenancyTenantGroupsCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxTenantGroupRead(d, m)
+	return resourceNetboxTenantGroupRead(ctx, d, m)
 }
 
-func resourceNetboxTenantGroupRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTenantGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
-	params := tenancy.NewTenancyTenantGroupsReadParams().WithID(id)
+	params := tenancy.NewTenancyTenantGroupsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Tenancy.TenancyTenantGroupsRead(params, nil)
 	if err != nil {
@@ -103,7 +105,7 @@ func resourceNetboxTenantGroupRead(d *schema.ResourceData, m interface{}) error
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
@@ -112,11 +114,13 @@ func resourceNetboxTenantGroupRead(d *schema.ResourceData, m interface{}) error
 	if res.GetPayload().Parent != nil {
 		d.Set("parent", res.GetPayload().Parent.ID)
 	}
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
+
 	return nil
 }
 
-func resourceNetboxTenantGroupUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTenantGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableTenantGroup{}
@@ -142,21 +146,21 @@ func resourceNetboxTenantGroupUpdate(d *schema.ResourceData, m interface{}) erro
 	if parentID != 0 {
 		data.Parent = &parentID
 	}
-	params := tenancy.NewTenancyTenantGroupsPartialUpdateParams().WithID(id).WithData(&data)
+	params := tenancy.NewTenancyTenantGroupsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Tenancy.TenancyTenantGroupsPartialUpdate(params, nil)
+	_, err := api.Tenancy.TenancyTenantGroupsPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxTenantGroupRead(d, m)
+	return resourceNetboxTenantGroupRead(ctx, d, m)
 }
 
-func resourceNetboxTenantGroupDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTenantGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := tenancy.NewTenancyTenantGroupsDeleteParams().WithID(id)
+	params := tenancy.NewTenancyTenantGroupsDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Tenancy.TenancyTenantGroupsDelete(params, nil)
 	if err != nil {
@@ -166,7 +170,7 @@ func resourceNetboxTenantGroupDelete(d *schema.ResourceData, m interface{}) erro
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -3,12 +3,14 @@ package netbox
 import (
 	"fmt"
 	"log"
+	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/tenancy"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func testAccNetboxTenantTagDependencies(testName string) string {
@@ -54,6 +56,64 @@ resource "netbox_tenant" "test" {
 	})
 }
 
+func TestUnitNetboxTenant_basic(t *testing.T) {
+	f := testFakeNetbox(t)
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testUnitProviders,
+		CheckDestroy: func(*terraform.State) error {
+			if n := f.count("tenancy/tenants"); n != 0 {
+				return fmt.Errorf("%d tenants left", n)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetboxTenantTagDependencies("test") + `
+resource "netbox_tenant_group" "test" {
+  name = "test"
+}
+
+resource "netbox_tenant" "test" {
+  name        = "test"
+  description = "description"
+  group_id    = netbox_tenant_group.test.id
+  tags        = [netbox_tag.test_a.name]
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_tenant.test", "slug", "test"),
+					resource.TestCheckResourceAttrPair("netbox_tenant.test", "group_id", "netbox_tenant_group.test", "id"),
+					resource.TestCheckResourceAttr("netbox_tenant.test", "tags.#", "1"),
+					resource.TestCheckResourceAttr("netbox_tenant.test", "tags.0", "testa"),
+				),
+			},
+			{
+				ResourceName:      "netbox_tenant.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccNetboxTenantTagDependencies("test") + `
+resource "netbox_tenant" "test" {
+  name = "test"
+  tags = [netbox_tag.test_b.name]
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_tenant.test", "description", ""),
+					resource.TestCheckResourceAttr("netbox_tenant.test", "group_id", "0"),
+					resource.TestCheckResourceAttr("netbox_tenant.test", "tags.0", "testb"),
+				),
+			},
+			{
+				Config: `resource "netbox_tenant" "test" {
+  name = "test"
+  slug = "invalid slug"
+}`,
+				ExpectError: regexp.MustCompile("slug"),
+			},
+		},
+	})
+}
+
 func TestAccNetboxTenant_defaultSlug(t *testing.T) {
 	testSlug := "tenant_defSlug"
 	testName := testAccGetTestName(testSlug)
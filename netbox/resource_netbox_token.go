@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/users"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxToken() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxTokenCreate,
-		Read:   resourceNetboxTokenRead,
-		Update: resourceNetboxTokenUpdate,
-		Delete: resourceNetboxTokenDelete,
+		CreateContext: resourceNetboxTokenCreate,
+		ReadContext:   resourceNetboxTokenRead,
+		UpdateContext: resourceNetboxTokenUpdate,
+		DeleteContext: resourceNetboxTokenDelete,
 
 		Description: `:meta:subcategory:Authentication:From the [official documentation](https://docs.netbox.dev/en/stable/rest-api/authentication/#tokens):
 
@@ -63,8 +64,8 @@ func resourceNetboxToken() *schema.Resource {
 	}
 }
 
-func resourceNetboxTokenCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTokenCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	data := models.WritableToken{}
 
 	userid := int64(d.Get("user_id").(int))
@@ -83,20 +84,20 @@ func resourceNetboxTokenCreate(d *schema.ResourceData, m interface{}) error {
 	data.WriteEnabled = d.Get("write_enabled").(bool)
 	data.Description = d.Get("description").(string)
 
-	params := users.NewUsersTokensCreateParams().WithData(&data)
+	params := users.NewUsersTokensCreateParams().WithContext(ctx).WithData(&data)
 	res, err := api.Users.UsersTokensCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxTokenUpdate(d, m)
+	return resourceNetboxTokenUpdate(ctx, d, m)
 }
 
-func resourceNetboxTokenRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTokenRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := users.NewUsersTokensReadParams().WithID(id)
+	params := users.NewUsersTokensReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Users.UsersTokensRead(params, nil)
 	if err != nil {
@@ -108,7 +109,7 @@ func resourceNetboxTokenRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	token := res.GetPayload()
 
@@ -126,8 +127,8 @@ func resourceNetboxTokenRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceNetboxTokenUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTokenUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableToken{}
 
@@ -146,18 +147,18 @@ func resourceNetboxTokenUpdate(d *schema.ResourceData, m interface{}) error {
 	data.WriteEnabled = d.Get("write_enabled").(bool)
 	data.Description = d.Get("description").(string)
 
-	params := users.NewUsersTokensUpdateParams().WithID(id).WithData(&data)
-	_, err := api.Users.UsersTokensUpdate(params, nil)
+	params := users.NewUsersTokensPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
+	_, err := api.Users.UsersTokensPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
-	return resourceNetboxTokenRead(d, m)
+	return resourceNetboxTokenRead(ctx, d, m)
 }
 
-func resourceNetboxTokenDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTokenDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := users.NewUsersTokensDeleteParams().WithID(id)
+	params := users.NewUsersTokensDeleteParams().WithContext(ctx).WithID(id)
 	_, err := api.Users.UsersTokensDelete(params, nil)
 	if err != nil {
 		if errresp, ok := err.(*users.UsersTokensDeleteDefault); ok {
@@ -166,7 +167,7 @@ func resourceNetboxTokenDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("")
 	return nil
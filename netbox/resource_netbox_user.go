@@ -1,20 +1,21 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/users"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceNetboxUser() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxUserCreate,
-		Read:   resourceNetboxUserRead,
-		Update: resourceNetboxUserUpdate,
-		Delete: resourceNetboxUserDelete,
+		CreateContext: resourceNetboxUserCreate,
+		ReadContext:   resourceNetboxUserRead,
+		UpdateContext: resourceNetboxUserUpdate,
+		DeleteContext: resourceNetboxUserDelete,
 
 		Description: `:meta:subcategory:Authentication:This resource is used to manage users.`,
 
@@ -51,8 +52,8 @@ func resourceNetboxUser() *schema.Resource {
 		},
 	}
 }
-func resourceNetboxUserCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	data := models.WritableUser{}
 
 	username := d.Get("username").(string)
@@ -67,20 +68,20 @@ func resourceNetboxUserCreate(d *schema.ResourceData, m interface{}) error {
 	data.IsStaff = staff
 	data.Groups = groupIDs
 
-	params := users.NewUsersUsersCreateParams().WithData(&data)
+	params := users.NewUsersUsersCreateParams().WithContext(ctx).WithData(&data)
 	res, err := api.Users.UsersUsersCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxUserRead(d, m)
+	return resourceNetboxUserRead(ctx, d, m)
 }
 
-func resourceNetboxUserRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := users.NewUsersUsersReadParams().WithID(id)
+	params := users.NewUsersUsersReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Users.UsersUsersRead(params, nil)
 	if err != nil {
@@ -92,7 +93,7 @@ func resourceNetboxUserRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if res.GetPayload().Username != nil {
@@ -108,8 +109,8 @@ func resourceNetboxUserRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceNetboxUserUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableUser{}
 
@@ -125,18 +126,21 @@ func resourceNetboxUserUpdate(d *schema.ResourceData, m interface{}) error {
 	data.IsStaff = staff
 	data.Groups = groupIDs
 
-	params := users.NewUsersUsersUpdateParams().WithID(id).WithData(&data)
-	_, err := api.Users.UsersUsersUpdate(params, nil)
+	params := users.NewUsersUsersPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
+	_, err := api.Users.UsersUsersPartialUpdate(params, nil, changedFieldsOnly(api, d, map[string][]string{
+		"is_active": {"active"},
+		"is_staff":  {"staff"},
+	}))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
-	return resourceNetboxUserRead(d, m)
+	return resourceNetboxUserRead(ctx, d, m)
 }
 
-func resourceNetboxUserDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := users.NewUsersUsersDeleteParams().WithID(id)
+	params := users.NewUsersUsersDeleteParams().WithContext(ctx).WithID(id)
 	_, err := api.Users.UsersUsersDelete(params, nil)
 	if err != nil {
 		if errresp, ok := err.(*users.UsersUsersDeleteDefault); ok {
@@ -145,7 +149,7 @@ func resourceNetboxUserDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("")
 	return nil
@@ -4,7 +4,6 @@ import (
 	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -38,8 +37,10 @@ func resourceNetboxVirtualChassis() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
-			tagsKey:         tagsSchema,
-			customFieldsKey: customFieldsSchema,
+			tagsKey:             tagsSchema,
+			customFieldsKey:     customFieldsSchema,
+			customFieldsJSONKey: customFieldsJSONSchema,
+			lastUpdatedKey:      lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -48,7 +49,7 @@ func resourceNetboxVirtualChassis() *schema.Resource {
 }
 
 func resourceNetboxVirtualChassisCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 
@@ -74,18 +75,19 @@ func resourceNetboxVirtualChassisCreate(ctx context.Context, d *schema.ResourceD
 		data.Comments = comments
 	}
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
-	}
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.virtualchassis")
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.Tags = tags
 
-	params := dcim.NewDcimVirtualChassisCreateParams().WithData(&data)
+	params := dcim.NewDcimVirtualChassisCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimVirtualChassisCreate(params, nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
@@ -94,11 +96,11 @@ func resourceNetboxVirtualChassisCreate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceNetboxVirtualChassisRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
-	params := dcim.NewDcimVirtualChassisReadParams().WithID(id)
+	params := dcim.NewDcimVirtualChassisReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimVirtualChassisRead(params, nil)
 	if err != nil {
@@ -119,17 +121,24 @@ func resourceNetboxVirtualChassisRead(ctx context.Context, d *schema.ResourceDat
 	d.Set("description", virtualChassis.Description)
 	d.Set("comments", virtualChassis.Comments)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
+	cf := getResourceCustomFields(api, d, res.GetPayload().CustomFields)
 	if cf != nil {
 		d.Set(customFieldsKey, cf)
 	}
+	cfJSON, err := getResourceCustomFieldsJSON(d, res.GetPayload().CustomFields)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set(customFieldsJSONKey, cfJSON)
+
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, virtualChassis.Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
-	d.Set(tagsKey, getTagListFromNestedTagList(virtualChassis.Tags))
 	return nil
 }
 
 func resourceNetboxVirtualChassisUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableVirtualChassis{}
@@ -143,12 +152,13 @@ func resourceNetboxVirtualChassisUpdate(ctx context.Context, d *schema.ResourceD
 		data.Domain = domain
 	}
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
-	}
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "dcim.virtualchassis")
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.Tags = tags
 
 	if d.HasChanges("comments") {
 		// check if comment is set
@@ -167,21 +177,21 @@ func resourceNetboxVirtualChassisUpdate(ctx context.Context, d *schema.ResourceD
 		}
 	}
 
-	params := dcim.NewDcimVirtualChassisUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimVirtualChassisPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimVirtualChassisUpdate(params, nil)
+	_, err = api.Dcim.DcimVirtualChassisPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return diag.FromErr(err)
+		return netboxErrorDiagnostics(err)
 	}
 
 	return resourceNetboxVirtualChassisRead(ctx, d, m)
 }
 
 func resourceNetboxVirtualChassisDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimVirtualChassisDeleteParams().WithID(id)
+	params := dcim.NewDcimVirtualChassisDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimVirtualChassisDelete(params, nil)
 	if err != nil {
@@ -197,36 +207,11 @@ func resourceNetboxVirtualChassisDelete(ctx context.Context, d *schema.ResourceD
 	return nil
 }
 
-func virtualChassisUpdateMaster(api *client.NetBoxAPI, id int64, master *int64) error {
-	// Need to read the virtual chassis because we cannot do a partial update
-	// because setting `master` to nil would omit it entirely, so we need to
-	// do a PUT request instead of PATCH
-	vcRes, err := api.Dcim.DcimVirtualChassisRead(dcim.NewDcimVirtualChassisReadParams().WithID(id), nil)
-	if err != nil {
-		return err
-	}
-	vcData := vcRes.GetPayload()
-	vcData.Master = nil
-	newTags := make([]*models.NestedTag, 0)
-	for _, Tag := range vcData.Tags {
-		Tag.Display = ""
-		Tag.ID = 0
-		Tag.URL = ""
-		newTags = append(newTags, Tag)
-	}
-
-	// Need to manually copy data because there is no automatic method to convert
-	// from VirtualChassis to WritableVirtualChassis
-	vcUpdateData := models.WritableVirtualChassis{
-		ID:          vcData.ID,
-		Description: vcData.Description,
-		Domain:      vcData.Domain,
-		Name:        vcData.Name,
-		Comments:    vcData.Comments,
-		Tags:        newTags,
-		Master:      master,
-	}
-
-	_, err = api.Dcim.DcimVirtualChassisUpdate(dcim.NewDcimVirtualChassisUpdateParams().WithID(id).WithData(&vcUpdateData), nil)
+func virtualChassisUpdateMaster(ctx context.Context, api *providerState, id int64, master *int64) error {
+	// Only `master` is sent, so that it is cleared if master is nil and all
+	// other fields of the virtual chassis are left alone
+	data := models.WritableVirtualChassis{Master: master}
+	params := dcim.NewDcimVirtualChassisPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
+	_, err := api.Dcim.DcimVirtualChassisPartialUpdate(params, nil, onlyFields("master"))
 	return err
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/virtualization"
 	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -38,8 +37,10 @@ func resourceNetboxVirtualDisks() *schema.Resource {
 				Type:     schema.TypeInt,
 				Required: true,
 			},
-			tagsKey:         tagsSchema,
-			customFieldsKey: customFieldsSchema,
+			tagsKey:             tagsSchema,
+			customFieldsKey:     customFieldsSchema,
+			customFieldsJSONKey: customFieldsJSONSchema,
+			lastUpdatedKey:      lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -48,7 +49,7 @@ func resourceNetboxVirtualDisks() *schema.Resource {
 }
 
 func resourceNetboxVirtualDisksCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 	size := d.Get("size_gb").(int)
@@ -66,18 +67,19 @@ func resourceNetboxVirtualDisksCreate(ctx context.Context, d *schema.ResourceDat
 		data.Description = description
 	}
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
-	}
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "virtualization.virtualdisk")
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.Tags = tags
 
-	params := virtualization.NewVirtualizationVirtualDisksCreateParams().WithData(&data)
+	params := virtualization.NewVirtualizationVirtualDisksCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Virtualization.VirtualizationVirtualDisksCreate(params, nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
@@ -86,11 +88,11 @@ func resourceNetboxVirtualDisksCreate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceNetboxVirtualDisksRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
-	params := virtualization.NewVirtualizationVirtualDisksReadParams().WithID(id)
+	params := virtualization.NewVirtualizationVirtualDisksReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Virtualization.VirtualizationVirtualDisksRead(params, nil)
 	if err != nil {
@@ -116,17 +118,24 @@ func resourceNetboxVirtualDisksRead(ctx context.Context, d *schema.ResourceData,
 		d.Set("virtual_machine_id", VirtualDisks.VirtualMachine.ID)
 	}
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
+	cf := getResourceCustomFields(api, d, res.GetPayload().CustomFields)
 	if cf != nil {
 		d.Set(customFieldsKey, cf)
 	}
+	cfJSON, err := getResourceCustomFieldsJSON(d, res.GetPayload().CustomFields)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set(customFieldsJSONKey, cfJSON)
+
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, VirtualDisks.Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
-	d.Set(tagsKey, getTagListFromNestedTagList(VirtualDisks.Tags))
 	return nil
 }
 
 func resourceNetboxVirtualDisksUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableVirtualDisk{}
@@ -139,12 +148,13 @@ func resourceNetboxVirtualDisksUpdate(ctx context.Context, d *schema.ResourceDat
 	data.Size = &size
 	data.VirtualMachine = &virtualMachineID
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
-	}
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "virtualization.virtualdisk")
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.Tags = tags
 
 	if d.HasChanges("description") {
 		// check if description is set
@@ -155,21 +165,21 @@ func resourceNetboxVirtualDisksUpdate(ctx context.Context, d *schema.ResourceDat
 		}
 	}
 
-	params := virtualization.NewVirtualizationVirtualDisksUpdateParams().WithID(id).WithData(&data)
+	params := virtualization.NewVirtualizationVirtualDisksPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Virtualization.VirtualizationVirtualDisksUpdate(params, nil)
+	_, err = api.Virtualization.VirtualizationVirtualDisksPartialUpdate(params, nil, changedFieldsOnly(api, d, map[string][]string{"size": {"size_gb"}}))
 	if err != nil {
-		return diag.FromErr(err)
+		return netboxErrorDiagnostics(err)
 	}
 
 	return resourceNetboxVirtualDisksRead(ctx, d, m)
 }
 
 func resourceNetboxVirtualDisksDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := virtualization.NewVirtualizationVirtualDisksDeleteParams().WithID(id)
+	params := virtualization.NewVirtualizationVirtualDisksDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Virtualization.VirtualizationVirtualDisksDelete(params, nil)
 	if err != nil {
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/virtualization"
 	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -99,7 +98,9 @@ func resourceNetboxVirtualMachine() *schema.Resource {
 				Optional:    true,
 				Description: "This is best managed through the use of `jsonencode` and a map of settings.",
 			},
-			customFieldsKey: customFieldsSchema,
+			customFieldsKey:     customFieldsSchema,
+			customFieldsJSONKey: customFieldsJSONSchema,
+			lastUpdatedKey:      lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -116,7 +117,7 @@ func resourceNetboxVirtualMachine() *schema.Resource {
 }
 
 func resourceNetboxVirtualMachineCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 
@@ -192,33 +193,33 @@ func resourceNetboxVirtualMachineCreate(ctx context.Context, d *schema.ResourceD
 
 	data.Status = d.Get("status").(string)
 
-	tags, diags := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-	data.Tags = tags
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "virtualization.virtualmachine")
 
-	params := virtualization.NewVirtualizationVirtualMachinesCreateParams().WithData(&data)
+	params := virtualization.NewVirtualizationVirtualMachinesCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Virtualization.VirtualizationVirtualMachinesCreate(params, nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return append(resourceNetboxVirtualMachineRead(ctx, d, m), diags...)
+	return resourceNetboxVirtualMachineRead(ctx, d, m)
 }
 
 func resourceNetboxVirtualMachineRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	var diags diag.Diagnostics
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
-	params := virtualization.NewVirtualizationVirtualMachinesReadParams().WithID(id)
+	params := virtualization.NewVirtualizationVirtualMachinesReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Virtualization.VirtualizationVirtualMachinesRead(params, nil)
 	if err != nil {
@@ -314,18 +315,24 @@ func resourceNetboxVirtualMachineRead(ctx context.Context, d *schema.ResourceDat
 	} else {
 		d.Set("status", nil)
 	}
-	d.Set(tagsKey, getTagListFromNestedTagList(vm.Tags))
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, vm.Tags))
 
-	cf := getCustomFields(vm.CustomFields)
+	cf := getResourceCustomFields(api, d, vm.CustomFields)
 	if cf != nil {
 		d.Set(customFieldsKey, cf)
 	}
+	cfJSON, err := getResourceCustomFieldsJSON(d, vm.CustomFields)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set(customFieldsJSONKey, cfJSON)
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return diags
 }
 
 func resourceNetboxVirtualMachineUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableVirtualMachineWithConfigContext{}
@@ -408,12 +415,12 @@ func resourceNetboxVirtualMachineUpdate(ctx context.Context, d *schema.ResourceD
 		}
 	}
 
-	tags, diags := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
-	data.Tags = tags
-	cf, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = cf
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	data.Tags = tags
+	data.CustomFields = getCustomFieldsFromResourceData(api, d, "virtualization.virtualmachine")
 
 	if d.HasChanges("comments") {
 		// check if comment is set
@@ -438,23 +445,28 @@ func resourceNetboxVirtualMachineUpdate(ctx context.Context, d *schema.ResourceD
 	}
 	//}
 
-	params := virtualization.NewVirtualizationVirtualMachinesUpdateParams().WithID(id).WithData(&data)
+	params := virtualization.NewVirtualizationVirtualMachinesPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Virtualization.VirtualizationVirtualMachinesUpdate(params, nil)
+	_, err = api.Virtualization.VirtualizationVirtualMachinesPartialUpdate(params, nil, changedFieldsOnly(api, d, map[string][]string{
+		"disk":        {"disk_size_gb"},
+		"memory":      {"memory_mb"},
+		"primary_ip4": {"primary_ipv4"},
+		"primary_ip6": {"primary_ipv6"},
+	}))
 	if err != nil {
-		return diag.FromErr(err)
+		return netboxErrorDiagnostics(err)
 	}
 
-	return append(resourceNetboxVirtualMachineRead(ctx, d, m), diags...)
+	return resourceNetboxVirtualMachineRead(ctx, d, m)
 }
 
 func resourceNetboxVirtualMachineDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	var diags diag.Diagnostics
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := virtualization.NewVirtualizationVirtualMachinesDeleteParams().WithID(id)
+	params := virtualization.NewVirtualizationVirtualMachinesDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Virtualization.VirtualizationVirtualMachinesDelete(params, nil)
 	if err != nil {
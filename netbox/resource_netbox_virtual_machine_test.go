@@ -161,6 +161,81 @@ resource "netbox_virtual_machine" "cluster_without_site" {
 	})
 }
 
+func TestUnitNetboxVirtualMachine_basic(t *testing.T) {
+	f := testFakeNetbox(t)
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testUnitProviders,
+		CheckDestroy: func(*terraform.State) error {
+			if n := f.count("virtualization/virtual-machines"); n != 0 {
+				return fmt.Errorf("%d virtual machines left", n)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetboxVirtualMachineFullDependencies("test") + `
+resource "netbox_virtual_machine" "test" {
+  name         = "test"
+  cluster_id   = netbox_cluster.test.id
+  site_id      = netbox_site.test.id
+  comments     = "comment"
+  description  = "description"
+  memory_mb    = 1024
+  disk_size_gb = 256
+  tenant_id    = netbox_tenant.test.id
+  role_id      = netbox_device_role.test.id
+  platform_id  = netbox_platform.test.id
+  device_id    = netbox_device.test.id
+  vcpus        = 4
+  status       = "planned"
+  tags         = [netbox_tag.test_a.name]
+  local_context_data = jsonencode({
+    context = "data"
+  })
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("netbox_virtual_machine.test", "cluster_id", "netbox_cluster.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_virtual_machine.test", "site_id", "netbox_site.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_virtual_machine.test", "tenant_id", "netbox_tenant.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_virtual_machine.test", "role_id", "netbox_device_role.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_virtual_machine.test", "platform_id", "netbox_platform.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_virtual_machine.test", "device_id", "netbox_device.test", "id"),
+					resource.TestCheckResourceAttr("netbox_virtual_machine.test", "memory_mb", "1024"),
+					resource.TestCheckResourceAttr("netbox_virtual_machine.test", "disk_size_gb", "256"),
+					resource.TestCheckResourceAttr("netbox_virtual_machine.test", "vcpus", "4"),
+					resource.TestCheckResourceAttr("netbox_virtual_machine.test", "status", "planned"),
+					resource.TestCheckResourceAttr("netbox_virtual_machine.test", "tags.0", "testa"),
+					resource.TestCheckResourceAttr("netbox_virtual_machine.test", "local_context_data", `{"context":"data"}`),
+				),
+			},
+			{
+				ResourceName:      "netbox_virtual_machine.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccNetboxVirtualMachineFullDependencies("test") + `
+resource "netbox_virtual_machine" "test" {
+  name       = "test"
+  cluster_id = netbox_cluster.test.id
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_virtual_machine.test", "status", "active"),
+					resource.TestCheckResourceAttr("netbox_virtual_machine.test", "comments", ""),
+					resource.TestCheckResourceAttr("netbox_virtual_machine.test", "memory_mb", "0"),
+					resource.TestCheckResourceAttr("netbox_virtual_machine.test", "vcpus", "0"),
+					resource.TestCheckResourceAttr("netbox_virtual_machine.test", "tenant_id", "0"),
+					resource.TestCheckResourceAttr("netbox_virtual_machine.test", "role_id", "0"),
+					resource.TestCheckResourceAttr("netbox_virtual_machine.test", "platform_id", "0"),
+					resource.TestCheckResourceAttr("netbox_virtual_machine.test", "device_id", "0"),
+					resource.TestCheckResourceAttr("netbox_virtual_machine.test", "tags.#", "0"),
+					resource.TestCheckResourceAttr("netbox_virtual_machine.test", "local_context_data", ""),
+				),
+			},
+		},
+	})
+}
+
 func TestAccNetboxVirtualMachine_basic(t *testing.T) {
 	testSlug := "vm_basic"
 	testName := testAccGetTestName(testSlug)
@@ -322,7 +397,7 @@ resource "netbox_virtual_machine" "test" {
 
 func testAccCheckVirtualMachineDestroy(s *terraform.State) error {
 	// retrieve the connection established in Provider configuration
-	conn := testAccProvider.Meta().(*client.NetBoxAPI)
+	conn := testAccProvider.Meta().(*providerState)
 
 	// loop through the resources in state, verifying each virtual machine
 	// is destroyed
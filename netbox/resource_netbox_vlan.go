@@ -1,11 +1,12 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -14,10 +15,10 @@ var resourceNetboxVlanStatusOptions = []string{"active", "reserved", "deprecated
 
 func resourceNetboxVlan() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxVlanCreate,
-		Read:   resourceNetboxVlanRead,
-		Update: resourceNetboxVlanUpdate,
-		Delete: resourceNetboxVlanDelete,
+		CreateContext: resourceNetboxVlanCreate,
+		ReadContext:   resourceNetboxVlanRead,
+		UpdateContext: resourceNetboxVlanUpdate,
+		DeleteContext: resourceNetboxVlanDelete,
 
 		Description: `:meta:subcategory:IP Address Management (IPAM):From the [official documentation](https://docs.netbox.dev/en/stable/features/vlans/#vlans):
 
@@ -62,7 +63,8 @@ func resourceNetboxVlan() *schema.Resource {
 				Optional: true,
 				Default:  "",
 			},
-			tagsKey: tagsSchema,
+			tagsKey:        tagsSchema,
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -70,8 +72,8 @@ func resourceNetboxVlan() *schema.Resource {
 	}
 }
 
-func resourceNetboxVlanCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVlanCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	data := models.WritableVLAN{}
 
 	name := d.Get("name").(string)
@@ -100,22 +102,26 @@ func resourceNetboxVlanCreate(d *schema.ResourceData, m interface{}) error {
 		data.Role = int64ToPtr(int64(roleID.(int)))
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.Tags = tags
 
-	params := ipam.NewIpamVlansCreateParams().WithData(&data)
+	params := ipam.NewIpamVlansCreateParams().WithContext(ctx).WithData(&data)
 	res, err := api.Ipam.IpamVlansCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxVlanRead(d, m)
+	return resourceNetboxVlanRead(ctx, d, m)
 }
 
-func resourceNetboxVlanRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVlanRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamVlansReadParams().WithID(id)
+	params := ipam.NewIpamVlansReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Ipam.IpamVlansRead(params, nil)
 	if err != nil {
@@ -127,7 +133,7 @@ func resourceNetboxVlanRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	vlan := res.GetPayload()
@@ -135,7 +141,7 @@ func resourceNetboxVlanRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("name", vlan.Name)
 	d.Set("vid", vlan.Vid)
 	d.Set("description", vlan.Description)
-	d.Set(tagsKey, getTagListFromNestedTagList(vlan.Tags))
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, vlan.Tags))
 
 	if vlan.Status != nil {
 		d.Set("status", vlan.Status.Value)
@@ -152,12 +158,13 @@ func resourceNetboxVlanRead(d *schema.ResourceData, m interface{}) error {
 	if vlan.Role != nil {
 		d.Set("role_id", vlan.Role.ID)
 	}
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxVlanUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVlanUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableVLAN{}
 	name := d.Get("name").(string)
@@ -186,20 +193,24 @@ func resourceNetboxVlanUpdate(d *schema.ResourceData, m interface{}) error {
 		data.Role = int64ToPtr(int64(roleID.(int)))
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.Tags = tags
 
-	params := ipam.NewIpamVlansUpdateParams().WithID(id).WithData(&data)
-	_, err := api.Ipam.IpamVlansUpdate(params, nil)
+	params := ipam.NewIpamVlansPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
+	_, err = api.Ipam.IpamVlansPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
-	return resourceNetboxVlanRead(d, m)
+	return resourceNetboxVlanRead(ctx, d, m)
 }
 
-func resourceNetboxVlanDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVlanDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamVlansDeleteParams().WithID(id)
+	params := ipam.NewIpamVlansDeleteParams().WithContext(ctx).WithID(id)
 	_, err := api.Ipam.IpamVlansDelete(params, nil)
 	if err != nil {
 		if errresp, ok := err.(*ipam.IpamVlansDeleteDefault); ok {
@@ -208,7 +219,7 @@ func resourceNetboxVlanDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
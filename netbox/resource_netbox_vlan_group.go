@@ -1,11 +1,12 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -14,10 +15,10 @@ var resourceNetboxVlanGroupScopeTypeOptions = []string{"dcim.location", "dcim.si
 
 func resourceNetboxVlanGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxVlanGroupCreate,
-		Read:   resourceNetboxVlanGroupRead,
-		Update: resourceNetboxVlanGroupUpdate,
-		Delete: resourceNetboxVlanGroupDelete,
+		CreateContext: resourceNetboxVlanGroupCreate,
+		ReadContext:   resourceNetboxVlanGroupRead,
+		UpdateContext: resourceNetboxVlanGroupUpdate,
+		DeleteContext: resourceNetboxVlanGroupDelete,
 
 		Description: `:meta:subcategory:IP Address Management (IPAM):
 
@@ -59,7 +60,8 @@ func resourceNetboxVlanGroup() *schema.Resource {
 				Optional: true,
 				Default:  "",
 			},
-			tagsKey: tagsSchema,
+			tagsKey:        tagsSchema,
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -67,8 +69,8 @@ func resourceNetboxVlanGroup() *schema.Resource {
 	}
 }
 
-func resourceNetboxVlanGroupCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVlanGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	data := models.VLANGroup{}
 
 	name := d.Get("name").(string)
@@ -91,22 +93,26 @@ func resourceNetboxVlanGroupCreate(d *schema.ResourceData, m interface{}) error
 		data.ScopeID = int64ToPtr(int64(scopeID.(int)))
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.Tags = tags
 
-	params := ipam.NewIpamVlanGroupsCreateParams().WithData(&data)
+	params := ipam.NewIpamVlanGroupsCreateParams().WithContext(ctx).WithData(&data)
 	res, err := api.Ipam.IpamVlanGroupsCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxVlanGroupRead(d, m)
+	return resourceNetboxVlanGroupRead(ctx, d, m)
 }
 
-func resourceNetboxVlanGroupRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVlanGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamVlanGroupsReadParams().WithID(id)
+	params := ipam.NewIpamVlanGroupsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Ipam.IpamVlanGroupsRead(params, nil)
 	if err != nil {
@@ -118,7 +124,7 @@ func resourceNetboxVlanGroupRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	vlanGroup := res.GetPayload()
@@ -128,7 +134,7 @@ func resourceNetboxVlanGroupRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("min_vid", vlanGroup.MinVid)
 	d.Set("max_vid", vlanGroup.MaxVid)
 	d.Set("description", vlanGroup.Description)
-	d.Set(tagsKey, getTagListFromNestedTagList(vlanGroup.Tags))
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, vlanGroup.Tags))
 
 	if vlanGroup.ScopeType != nil {
 		d.Set("scope_type", vlanGroup.ScopeType)
@@ -137,12 +143,13 @@ func resourceNetboxVlanGroupRead(d *schema.ResourceData, m interface{}) error {
 	if vlanGroup.ScopeID != nil {
 		d.Set("scope_id", vlanGroup.ScopeID)
 	}
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
 
 	return nil
 }
 
-func resourceNetboxVlanGroupUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVlanGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.VLANGroup{}
 
@@ -166,23 +173,27 @@ func resourceNetboxVlanGroupUpdate(d *schema.ResourceData, m interface{}) error
 		data.ScopeID = int64ToPtr(int64(scopeID.(int)))
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.Tags = tags
 
-	params := ipam.NewIpamVlanGroupsUpdateParams().WithID(id).WithData(&data)
-	_, err := api.Ipam.IpamVlanGroupsUpdate(params, nil)
+	params := ipam.NewIpamVlanGroupsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
+	_, err = api.Ipam.IpamVlanGroupsPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
-	return resourceNetboxVlanGroupRead(d, m)
+	return resourceNetboxVlanGroupRead(ctx, d, m)
 }
 
-func resourceNetboxVlanGroupDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVlanGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamVlanGroupsDeleteParams().WithID(id)
+	params := ipam.NewIpamVlanGroupsDeleteParams().WithContext(ctx).WithID(id)
 	_, err := api.Ipam.IpamVlanGroupsDelete(params, nil)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
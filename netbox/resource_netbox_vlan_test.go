@@ -9,6 +9,7 @@ import (
 	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func testAccNetboxVlanFullDependencies(testName string) string {
@@ -36,6 +37,92 @@ resource "netbox_vlan_group" "test_group" {
 }
 `, testName)
 }
+func TestUnitNetboxVlan_basic(t *testing.T) {
+	f := testFakeNetbox(t)
+	dependencies := `
+resource "netbox_vlan_group" "test" {
+  name       = "test"
+  slug       = "test"
+  min_vid    = 1
+  max_vid    = 4094
+  scope_type = "dcim.site"
+  scope_id   = netbox_site.test.id
+}
+
+resource "netbox_tenant" "test" {
+  name = "test"
+}
+
+resource "netbox_site" "test" {
+  name   = "test"
+  status = "active"
+}
+
+resource "netbox_ipam_role" "test" {
+  name = "test"
+}
+`
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testUnitProviders,
+		CheckDestroy: func(*terraform.State) error {
+			if n := f.count("ipam/vlans"); n != 0 {
+				return fmt.Errorf("%d VLANs left", n)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: dependencies + `
+resource "netbox_vlan" "test" {
+  name        = "test"
+  vid         = 100
+  status      = "reserved"
+  description = "description"
+  group_id    = netbox_vlan_group.test.id
+  tenant_id   = netbox_tenant.test.id
+  site_id     = netbox_site.test.id
+  role_id     = netbox_ipam_role.test.id
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_vlan.test", "vid", "100"),
+					resource.TestCheckResourceAttr("netbox_vlan.test", "status", "reserved"),
+					resource.TestCheckResourceAttrPair("netbox_vlan.test", "group_id", "netbox_vlan_group.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_vlan.test", "tenant_id", "netbox_tenant.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_vlan.test", "site_id", "netbox_site.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_vlan.test", "role_id", "netbox_ipam_role.test", "id"),
+				),
+			},
+			{
+				ResourceName:      "netbox_vlan.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "netbox_vlan.test",
+				ImportState:       true,
+				ImportStateId:     "test/test",
+				ImportStateVerify: true,
+			},
+			{
+				Config: dependencies + `
+resource "netbox_vlan" "test" {
+  name = "test"
+  vid  = 200
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_vlan.test", "vid", "200"),
+					resource.TestCheckResourceAttr("netbox_vlan.test", "status", "active"),
+					resource.TestCheckResourceAttr("netbox_vlan.test", "description", ""),
+					resource.TestCheckResourceAttr("netbox_vlan.test", "group_id", "0"),
+					resource.TestCheckResourceAttr("netbox_vlan.test", "tenant_id", "0"),
+					resource.TestCheckResourceAttr("netbox_vlan.test", "site_id", "0"),
+					resource.TestCheckResourceAttr("netbox_vlan.test", "role_id", "0"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccNetboxVlan_basic(t *testing.T) {
 	testSlug := "vlan_basic"
 	testName := testAccGetTestName(testSlug)
@@ -1,11 +1,12 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/vpn"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -14,10 +15,10 @@ var resourceNetboxVpnTunnelStatusOptions = []string{"planned", "active", "disabl
 
 func resourceNetboxVpnTunnel() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxVpnTunnelCreate,
-		Read:   resourceNetboxVpnTunnelRead,
-		Update: resourceNetboxVpnTunnelUpdate,
-		Delete: resourceNetboxVpnTunnelDelete,
+		CreateContext: resourceNetboxVpnTunnelCreate,
+		ReadContext:   resourceNetboxVpnTunnelRead,
+		UpdateContext: resourceNetboxVpnTunnelUpdate,
+		DeleteContext: resourceNetboxVpnTunnelDelete,
 
 		Description: `:meta:subcategory:VPN Tunnels:From the [official documentation](https://docs.netbox.dev/en/stable/features/vpn-tunnels/):
 
@@ -54,7 +55,8 @@ func resourceNetboxVpnTunnel() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
-			tagsKey: tagsSchema,
+			tagsKey:        tagsSchema,
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -62,8 +64,8 @@ func resourceNetboxVpnTunnel() *schema.Resource {
 	}
 }
 
-func resourceNetboxVpnTunnelCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVpnTunnelCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableTunnel{}
 
@@ -76,26 +78,29 @@ func resourceNetboxVpnTunnelCreate(d *schema.ResourceData, m interface{}) error
 	data.Tenant = getOptionalInt(d, "tenant_id")
 	data.TunnelID = getOptionalInt(d, "tunnel_id")
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	data.Tags = tags
 
-	params := vpn.NewVpnTunnelsCreateParams().WithData(&data)
+	params := vpn.NewVpnTunnelsCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Vpn.VpnTunnelsCreate(params, nil)
 	if err != nil {
 		//return errors.New(getTextFromError(err))
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxVpnTunnelRead(d, m)
+	return resourceNetboxVpnTunnelRead(ctx, d, m)
 }
 
-func resourceNetboxVpnTunnelRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVpnTunnelRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := vpn.NewVpnTunnelsReadParams().WithID(id)
+	params := vpn.NewVpnTunnelsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Vpn.VpnTunnelsRead(params, nil)
 	if err != nil {
@@ -107,7 +112,7 @@ func resourceNetboxVpnTunnelRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	tunnel := res.GetPayload()
@@ -131,12 +136,14 @@ func resourceNetboxVpnTunnelRead(d *schema.ResourceData, m interface{}) error {
 
 	d.Set("description", tunnel.Description)
 
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, res.GetPayload().Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
+
 	return nil
 }
 
-func resourceNetboxVpnTunnelUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVpnTunnelUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableTunnel{}
@@ -150,24 +157,27 @@ func resourceNetboxVpnTunnelUpdate(d *schema.ResourceData, m interface{}) error
 	data.Tenant = getOptionalInt(d, "tenant_id")
 	data.TunnelID = getOptionalInt(d, "tunnel_id")
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	data.Tags = tags
 
-	params := vpn.NewVpnTunnelsUpdateParams().WithID(id).WithData(&data)
+	params := vpn.NewVpnTunnelsUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Vpn.VpnTunnelsUpdate(params, nil)
+	_, err = api.Vpn.VpnTunnelsUpdate(params, nil, changedFieldsOnly(api, d, map[string][]string{"group": {"tunnel_group_id"}}))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxVpnTunnelRead(d, m)
+	return resourceNetboxVpnTunnelRead(ctx, d, m)
 }
 
-func resourceNetboxVpnTunnelDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVpnTunnelDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := vpn.NewVpnTunnelsDeleteParams().WithID(id)
+	params := vpn.NewVpnTunnelsDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Vpn.VpnTunnelsDelete(params, nil)
 	if err != nil {
@@ -177,7 +187,7 @@ func resourceNetboxVpnTunnelDelete(d *schema.ResourceData, m interface{}) error
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
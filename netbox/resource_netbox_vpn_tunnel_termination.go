@@ -1,11 +1,12 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/vpn"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -13,10 +14,10 @@ var resourceNetboxVpnTunnelTerminationRoleOptions = []string{"peer", "hub", "spo
 
 func resourceNetboxVpnTunnelTermination() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxVpnTunnelTerminationCreate,
-		Read:   resourceNetboxVpnTunnelTerminationRead,
-		Update: resourceNetboxVpnTunnelTerminationUpdate,
-		Delete: resourceNetboxVpnTunnelTerminationDelete,
+		CreateContext: resourceNetboxVpnTunnelTerminationCreate,
+		ReadContext:   resourceNetboxVpnTunnelTerminationRead,
+		UpdateContext: resourceNetboxVpnTunnelTerminationUpdate,
+		DeleteContext: resourceNetboxVpnTunnelTerminationDelete,
 
 		Description: `:meta:subcategory:VPN Tunnels:From the [official documentation](https://docs.netbox.dev/en/stable/features/vpn-tunnels/):
 
@@ -46,7 +47,8 @@ func resourceNetboxVpnTunnelTermination() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
-			tagsKey: tagsSchema,
+			tagsKey:        tagsSchema,
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -54,8 +56,8 @@ func resourceNetboxVpnTunnelTermination() *schema.Resource {
 	}
 }
 
-func resourceNetboxVpnTunnelTerminationCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVpnTunnelTerminationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableTunnelTermination{}
 
@@ -78,26 +80,29 @@ func resourceNetboxVpnTunnelTerminationCreate(d *schema.ResourceData, m interfac
 
 	data.OutsideIP = getOptionalInt(d, "outside_ip_address_id")
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	data.Tags = tags
 
-	params := vpn.NewVpnTunnelTerminationsCreateParams().WithData(&data)
+	params := vpn.NewVpnTunnelTerminationsCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Vpn.VpnTunnelTerminationsCreate(params, nil)
 	if err != nil {
 		//return errors.New(getTextFromError(err))
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxVpnTunnelTerminationRead(d, m)
+	return resourceNetboxVpnTunnelTerminationRead(ctx, d, m)
 }
 
-func resourceNetboxVpnTunnelTerminationRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVpnTunnelTerminationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := vpn.NewVpnTunnelTerminationsReadParams().WithID(id)
+	params := vpn.NewVpnTunnelTerminationsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Vpn.VpnTunnelTerminationsRead(params, nil)
 	if err != nil {
@@ -109,7 +114,7 @@ func resourceNetboxVpnTunnelTerminationRead(d *schema.ResourceData, m interface{
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	tunnelTermination := res.GetPayload()
@@ -130,12 +135,14 @@ func resourceNetboxVpnTunnelTerminationRead(d *schema.ResourceData, m interface{
 		d.Set("outside_ip_address_id", tunnelTermination.OutsideIP.ID)
 	}
 
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(tagsKey, getResourceTagListFromNestedTagList(api, d, res.GetPayload().Tags))
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
+
 	return nil
 }
 
-func resourceNetboxVpnTunnelTerminationUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVpnTunnelTerminationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableTunnelTermination{}
@@ -158,24 +165,31 @@ func resourceNetboxVpnTunnelTerminationUpdate(d *schema.ResourceData, m interfac
 
 	data.OutsideIP = getOptionalInt(d, "outside_ip_address_id")
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	data.Tags = tags
 
-	params := vpn.NewVpnTunnelTerminationsUpdateParams().WithID(id).WithData(&data)
+	params := vpn.NewVpnTunnelTerminationsUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Vpn.VpnTunnelTerminationsUpdate(params, nil)
+	_, err = api.Vpn.VpnTunnelTerminationsUpdate(params, nil, changedFieldsOnly(api, d, map[string][]string{
+		"termination_type": {"device_interface_id", "virtual_machine_interface_id"},
+		"termination_id":   {"device_interface_id", "virtual_machine_interface_id"},
+		"outside_ip":       {"outside_ip_address_id"},
+	}))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxVpnTunnelTerminationRead(d, m)
+	return resourceNetboxVpnTunnelTerminationRead(ctx, d, m)
 }
 
-func resourceNetboxVpnTunnelTerminationDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVpnTunnelTerminationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := vpn.NewVpnTunnelTerminationsDeleteParams().WithID(id)
+	params := vpn.NewVpnTunnelTerminationsDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Vpn.VpnTunnelTerminationsDelete(params, nil)
 	if err != nil {
@@ -185,7 +199,7 @@ func resourceNetboxVpnTunnelTerminationDelete(d *schema.ResourceData, m interfac
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
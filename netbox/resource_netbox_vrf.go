@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxVrf() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxVrfCreate,
-		Read:   resourceNetboxVrfRead,
-		Update: resourceNetboxVrfUpdate,
-		Delete: resourceNetboxVrfDelete,
+		CreateContext: resourceNetboxVrfCreate,
+		ReadContext:   resourceNetboxVrfRead,
+		UpdateContext: resourceNetboxVrfUpdate,
+		DeleteContext: resourceNetboxVrfDelete,
 
 		Description: `:meta:subcategory:IP Address Management (IPAM):From the [official documentation](https://docs.netbox.dev/en/stable/features/ipam/#virtual-routing-and-forwarding-vrf):
 
@@ -45,7 +46,8 @@ func resourceNetboxVrf() *schema.Resource {
 				ValidateFunc: validation.StringLenBetween(1, 21),
 			},
 
-			tagsKey: tagsSchema,
+			tagsKey:        tagsSchema,
+			lastUpdatedKey: lastUpdatedSchema,
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -53,8 +55,8 @@ func resourceNetboxVrf() *schema.Resource {
 	}
 }
 
-func resourceNetboxVrfCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVrfCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	data := models.WritableVRF{}
 
 	name := d.Get("name").(string)
@@ -73,27 +75,31 @@ func resourceNetboxVrfCreate(d *schema.ResourceData, m interface{}) error {
 		data.Rd = &rd
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.Tags = tags
 
 	data.ExportTargets = []int64{}
 	data.ImportTargets = []int64{}
 
-	params := ipam.NewIpamVrfsCreateParams().WithData(&data)
+	params := ipam.NewIpamVrfsCreateParams().WithContext(ctx).WithData(&data)
 
 	res, err := api.Ipam.IpamVrfsCreate(params, nil)
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxVrfRead(d, m)
+	return resourceNetboxVrfRead(ctx, d, m)
 }
 
-func resourceNetboxVrfRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVrfRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamVrfsReadParams().WithID(id)
+	params := ipam.NewIpamVrfsReadParams().WithContext(ctx).WithID(id)
 
 	res, err := api.Ipam.IpamVrfsRead(params, nil)
 	if err != nil {
@@ -105,7 +111,7 @@ func resourceNetboxVrfRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	vrf := res.GetPayload()
@@ -122,11 +128,13 @@ func resourceNetboxVrfRead(d *schema.ResourceData, m interface{}) error {
 	} else {
 		d.Set("tenant_id", nil)
 	}
+	d.Set(lastUpdatedKey, formatLastUpdated(res.GetPayload().LastUpdated))
+
 	return nil
 }
 
-func resourceNetboxVrfUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVrfUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableVRF{}
@@ -134,7 +142,10 @@ func resourceNetboxVrfUpdate(d *schema.ResourceData, m interface{}) error {
 	name := d.Get("name").(string)
 	enforceUnique := d.Get("enforce_unique").(bool)
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, err := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	data.Name = &name
 	data.Tags = tags
@@ -150,21 +161,21 @@ func resourceNetboxVrfUpdate(d *schema.ResourceData, m interface{}) error {
 	if tenantID, ok := d.GetOk("tenant_id"); ok {
 		data.Tenant = int64ToPtr(int64(tenantID.(int)))
 	}
-	params := ipam.NewIpamVrfsPartialUpdateParams().WithID(id).WithData(&data)
+	params := ipam.NewIpamVrfsPartialUpdateParams().WithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Ipam.IpamVrfsPartialUpdate(params, nil)
+	_, err = api.Ipam.IpamVrfsPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
 	if err != nil {
-		return err
+		return netboxErrorDiagnostics(err)
 	}
 
-	return resourceNetboxVrfRead(d, m)
+	return resourceNetboxVrfRead(ctx, d, m)
 }
 
-func resourceNetboxVrfDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVrfDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamVrfsDeleteParams().WithID(id)
+	params := ipam.NewIpamVrfsDeleteParams().WithContext(ctx).WithID(id)
 
 	_, err := api.Ipam.IpamVrfsDelete(params, nil)
 	if err != nil {
@@ -174,7 +185,7 @@ func resourceNetboxVrfDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -20,6 +20,7 @@ func TestAccNetboxWebhook_basic(t *testing.T) {
 	testAdditionalHeaders := "Authentication: Bearer abcdef123456"
 	resource.ParallelTest(t, resource.TestCase{
 		Providers:    testAccProviders,
+		PreCheck:     func() { testAccPreCheck(t) },
 		CheckDestroy: testAccCheckNetBoxWebhookDestroy,
 		Steps: []resource.TestStep{
 			{
@@ -124,7 +125,7 @@ resource "netbox_webhook" "test" {
 }
 
 func testAccCheckNetBoxWebhookDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*client.NetBoxAPI)
+	client := testAccProvider.Meta().(*providerState)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "netbox_webhook" {
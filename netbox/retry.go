@@ -0,0 +1,260 @@
+package netbox
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// retryTransport is a transport that retries requests to Netbox that failed
+// for transient reasons, e.g. because Netbox is being restarted behind a load
+// balancer or because the server asked us to slow down.
+type retryTransport struct {
+	original       http.RoundTripper
+	maxRetries     int
+	minWait        time.Duration
+	maxWait        time.Duration
+	attemptTimeout time.Duration
+	// logCtx contains the netbox_http subsystem logger used for requests
+	// whose context has no provider logger.
+	logCtx context.Context
+}
+
+// RoundTrip sends the request and retries it with exponential backoff as long
+// as it failed transiently and it is safe to send it again.
+func (t retryTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	// The body has to be replayable to send the request more than once
+	getBody := r.GetBody
+	if r.Body != nil && r.Body != http.NoBody && getBody == nil {
+		body, err := io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		getBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		req := r.Clone(r.Context())
+		if getBody != nil {
+			body, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+			req.GetBody = getBody
+		}
+
+		resp, err := t.roundTripAttempt(req)
+
+		if attempt >= t.maxRetries || r.Context().Err() != nil {
+			return resp, err
+		}
+
+		retry, reason := shouldRetryRequest(r, resp, err)
+		if !retry {
+			return resp, err
+		}
+
+		wait := retryBackoff(t.minWait, t.maxWait, attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				// The server must not make us wait longer than configured
+				wait = min(retryAfter, max(t.minWait, t.maxWait))
+			}
+		}
+		// Waiting past the deadline would fail the request anyway
+		if deadline, ok := r.Context().Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			return resp, err
+		}
+		if resp != nil {
+			// Drain the body so the underlying connection can be reused
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		tflog.SubsystemWarn(requestLogContext(r, t.logCtx), httpLogSubsystem, "Retrying Netbox API request after transient failure", map[string]interface{}{
+			"method":  r.Method,
+			"path":    r.URL.RequestURI(),
+			"attempt": attempt + 1,
+			"reason":  reason,
+			"wait":    wait.String(),
+		})
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-r.Context().Done():
+			timer.Stop()
+			return nil, r.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// roundTripAttempt sends the request once. If an attempt timeout is set, the
+// timeout covers the attempt including reading the response body. Requests
+// modifying data can take long, e.g. creating a device with hundreds of
+// components, so they are only bounded by the deadline of their context if
+// there is one, e.g. the timeout of the resource operation.
+func (t retryTransport) roundTripAttempt(r *http.Request) (*http.Response, error) {
+	_, hasDeadline := r.Context().Deadline()
+	modifying := r.Method != http.MethodGet && r.Method != http.MethodHead && r.Method != http.MethodOptions
+	if t.attemptTimeout <= 0 || (hasDeadline && modifying) {
+		return t.original.RoundTrip(r)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), t.attemptTimeout)
+	resp, err := t.original.RoundTrip(r.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = onCloseBody{ReadCloser: resp.Body, onClose: cancel}
+	return resp, nil
+}
+
+// onCloseBody calls onClose after the response body was closed, e.g. to
+// release resources that were held for the request.
+type onCloseBody struct {
+	io.ReadCloser
+	onClose func()
+}
+
+func (b onCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.onClose()
+	return err
+}
+
+// isIdempotentMethod returns true for HTTP methods that can be sent more than
+// once without changing the outcome.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// repeatableRequestKey is the context key marking requests that can be sent
+// more than once although their method is not idempotent.
+type repeatableRequestKey struct{}
+
+// withRepeatableRequest marks the requests sent with ctx as safe to repeat,
+// e.g. PATCH requests that only contain absolute values.
+func withRepeatableRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, repeatableRequestKey{}, true)
+}
+
+// isRepeatableRequest returns true if the request can be sent more than once
+// without changing the outcome.
+func isRepeatableRequest(r *http.Request) bool {
+	if isIdempotentMethod(r.Method) {
+		return true
+	}
+	repeatable, _ := r.Context().Value(repeatableRequestKey{}).(bool)
+	return repeatable
+}
+
+// shouldRetryRequest decides whether a failed attempt should be retried and
+// returns a human readable reason for the logs.
+func shouldRetryRequest(r *http.Request, resp *http.Response, err error) (bool, string) {
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return false, ""
+		}
+		// Certificate problems will not go away by trying again
+		var certErr *tls.CertificateVerificationError
+		if errors.As(err, &certErr) {
+			return false, ""
+		}
+		if isRepeatableRequest(r) {
+			return true, err.Error()
+		}
+		// Other requests are only retried if they never reached the server
+		if requestNeverSent(err) {
+			return true, err.Error()
+		}
+		return false, ""
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		// The request was refused before it was processed, so it is always safe to retry
+		return true, resp.Status
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if isRepeatableRequest(r) {
+			return true, resp.Status
+		}
+	}
+	return false, ""
+}
+
+// requestNeverSent returns true if err shows that no connection to the server
+// could be established, i.e. the request was certainly not processed.
+func requestNeverSent(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// retryBackoff returns the time to wait before the next attempt. The wait
+// grows exponentially from minWait up to maxWait and is jittered so parallel
+// requests do not retry in lockstep.
+func retryBackoff(minWait, maxWait time.Duration, attempt int) time.Duration {
+	if maxWait < minWait {
+		maxWait = minWait
+	}
+	wait := minWait
+	for i := 0; i < attempt && wait < maxWait; i++ {
+		wait *= 2
+	}
+	if wait > maxWait {
+		wait = maxWait
+	}
+	if wait <= 0 {
+		return 0
+	}
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(wait-half)+1))
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which can be
+// either a number of seconds or a HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := date.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
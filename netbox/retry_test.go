@@ -0,0 +1,206 @@
+package netbox
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fbreckle/go-netbox/netbox/client/dcim"
+	"github.com/fbreckle/go-netbox/netbox/client/status"
+	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryOnServiceUnavailable(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"netbox-version": "4.0.10"}`))
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:   "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL:  ts.URL,
+		MaxRetries: 3,
+	}
+
+	client, err := config.Client()
+	assert.NoError(t, err)
+
+	_, err = client.Status.StatusList(status.NewStatusListParams(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestRetryGivesUpAfterMaxRetries(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:   "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL:  ts.URL,
+		MaxRetries: 2,
+	}
+
+	client, err := config.Client()
+	assert.NoError(t, err)
+
+	_, err = client.Status.StatusList(status.NewStatusListParams(), nil)
+	assert.Error(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestRetryNonIdempotentOnlyWhenRefused(t *testing.T) {
+	var calls int32
+	var bodies []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:   "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL:  ts.URL,
+		MaxRetries: 5,
+	}
+
+	client, err := config.Client()
+	assert.NoError(t, err)
+
+	data := models.WritablePlatform{Name: strToPtr("foo"), Slug: strToPtr("foo")}
+	_, err = client.Dcim.DcimPlatformsCreate(dcim.NewDcimPlatformsCreateParams().WithData(&data), nil)
+	assert.Error(t, err)
+	// The 429 is retried, the 502 is not because the POST may have been processed
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.Len(t, bodies, 2)
+	assert.Equal(t, bodies[0], bodies[1])
+	assert.NotEmpty(t, bodies[0])
+}
+
+func TestRetryPartialUpdate(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1, "name": "foo", "slug": "foo"}`))
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:   "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL:  ts.URL,
+		MaxRetries: 2,
+	}
+
+	client, err := config.Client()
+	assert.NoError(t, err)
+	api := &providerState{NetBoxAPI: client, tags: newTagCache()}
+	d := schema.TestResourceDataRaw(t, resourceNetboxPlatform().Schema, map[string]interface{}{"name": "foo", "slug": "foo"})
+
+	data := models.WritablePlatform{Name: strToPtr("foo"), Slug: strToPtr("foo")}
+	params := dcim.NewDcimPlatformsPartialUpdateParams().WithID(1).WithData(&data)
+	_, err = client.Dcim.DcimPlatformsPartialUpdate(params, nil, changedFieldsOnly(api, d, nil))
+	assert.NoError(t, err)
+	// The 502 is retried because the partial update only contains absolute values
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestRetryAfterIsCapped(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	transport := retryTransport{
+		original:   http.DefaultTransport,
+		maxRetries: 1,
+		maxWait:    10 * time.Millisecond,
+		logCtx:     context.Background(),
+	}
+
+	// The wait is capped to the maximum wait
+	start := time.Now()
+	req, _ := http.NewRequest(http.MethodGet, ts.URL, nil)
+	resp, err := transport.RoundTrip(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.Less(t, time.Since(start), 10*time.Second)
+
+	// Requests are not retried if the wait exceeds their deadline
+	atomic.StoreInt32(&calls, 0)
+	transport.maxWait = time.Hour
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	start = time.Now()
+	req, _ = http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+	resp, err = transport.RoundTrip(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	resp.Body.Close()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Less(t, time.Since(start), 10*time.Second)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"", 0, false},
+		{"5", 5 * time.Second, true},
+		{"-1", 0, false},
+		{"Mon, 01 Jan 2024 12:00:30 GMT", 30 * time.Second, true},
+		{"Mon, 01 Jan 2024 11:00:00 GMT", 0, true},
+		{"soon", 0, false},
+	} {
+		wait, ok := parseRetryAfter(tt.value, now)
+		assert.Equal(t, tt.ok, ok, tt.value)
+		assert.Equal(t, tt.expected, wait, tt.value)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	for attempt := 0; attempt < 10; attempt++ {
+		wait := retryBackoff(time.Second, 8*time.Second, attempt)
+		assert.LessOrEqual(t, wait, 8*time.Second)
+		assert.GreaterOrEqual(t, wait, time.Second/2)
+	}
+	assert.Equal(t, time.Duration(0), retryBackoff(0, 0, 3))
+}
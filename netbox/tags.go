@@ -2,11 +2,13 @@ package netbox
 
 import (
 	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/extras"
 	"github.com/fbreckle/go-netbox/netbox/models"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -17,8 +19,9 @@ var tagsSchema = &schema.Schema{
 	Elem: &schema.Schema{
 		Type: schema.TypeString,
 	},
-	Optional: true,
-	Set:      schema.HashString,
+	Optional:    true,
+	Set:         schema.HashString,
+	Description: "Names or slugs of the tags of the object.",
 }
 
 var tagsSchemaRead = &schema.Schema{
@@ -30,50 +33,123 @@ var tagsSchemaRead = &schema.Schema{
 	Set:      schema.HashString,
 }
 
-func getNestedTagListFromResourceDataSet(client *client.NetBoxAPI, d interface{}) ([]*models.NestedTag, diag.Diagnostics) {
-	var diags diag.Diagnostics
+// tagCache caches the tags resolved while the provider runs, so that every
+// tag is looked up only once no matter how many resources use it.
+type tagCache struct {
+	mu sync.Mutex
+	// tags contains every resolved tag by name and by slug
+	tags map[string]*models.NestedTag
+}
 
-	tagList := d.(*schema.Set).List()
-	tags := []*models.NestedTag{}
+func newTagCache() *tagCache {
+	return &tagCache{tags: map[string]*models.NestedTag{}}
+}
+
+func (c *tagCache) get(nameOrSlug string) (*models.NestedTag, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tag, ok := c.tags[nameOrSlug]
+	return tag, ok
+}
+
+func (c *tagCache) add(tag *models.NestedTag) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tags[*tag.Name] = tag
+	c.tags[*tag.Slug] = tag
+}
+
+// getNestedTagListFromResourceDataSet resolves the tags in the set d, given by
+// name or slug, and the provider default tags. Tags that are not cached yet
+// are looked up with a single request, or two if some are given by slug. An
+// error is returned if any tag does not exist.
+func getNestedTagListFromResourceDataSet(api *providerState, d interface{}) ([]*models.NestedTag, error) {
+	tagList := toStringList(d)
+	// Add the provider default tags unless the resource sets them already
+	for _, tag := range api.defaultTags {
+		if !d.(*schema.Set).Contains(tag) {
+			tagList = append(tagList, tag)
+		}
+	}
+
+	var missing []string
 	for _, tag := range tagList {
-		tagString := tag.(string)
-		params := extras.NewExtrasTagsListParams()
-		params.Name = &tagString
-		limit := int64(2) // We search for a unique tag. Having two hits suffices to know its not unique.
-		params.Limit = &limit
-		res, err := client.Extras.ExtrasTagsList(params, nil)
+		if _, ok := api.tags.get(tag); !ok {
+			missing = append(missing, tag)
+		}
+	}
+	// Tags are looked up by name first, and the remaining ones by slug
+	for _, filter := range []string{"name", "slug"} {
+		if len(missing) == 0 {
+			break
+		}
+		resolved, err := listTags(api, filter, missing)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  fmt.Sprintf("Error retrieving tag %s from netbox", tag.(string)),
-				Detail:   fmt.Sprintf("API Error trying to retrieve tag %s from netbox", tag.(string)),
-			})
-			return tags, diags
+			return nil, err
 		}
-		payload := res.GetPayload()
-		switch *payload.Count {
-		case int64(0):
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  fmt.Sprintf("Error retrieving tag %s from netbox", tag.(string)),
-				Detail:   fmt.Sprintf("Could not locate referenced tag %s in netbox", tag.(string)),
-			})
-			return tags, diags
-		case int64(1):
-			tags = append(tags, &models.NestedTag{
-				Name: payload.Results[0].Name,
-				Slug: payload.Results[0].Slug,
-			})
-		default:
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Warning,
-				Summary:  fmt.Sprintf("Error retrieving tag %s from netbox", tag.(string)),
-				Detail:   fmt.Sprintf("Could not map tag %s to unique tag in netbox", tag.(string)),
-			})
+		var stillMissing []string
+		for _, tag := range missing {
+			if nestedTag, ok := resolved[tag]; ok {
+				api.tags.add(nestedTag)
+			} else {
+				stillMissing = append(stillMissing, tag)
+			}
+		}
+		missing = stillMissing
+	}
+
+	if len(missing) > 0 {
+		quoted := make([]string, len(missing))
+		for i, tag := range missing {
+			quoted[i] = strconv.Quote(tag)
 		}
+		return nil, fmt.Errorf("could not find tag %s in netbox, tags have to be given by name or slug", joinStringWithFinalConjunction(quoted, ", ", "or"))
 	}
 
-	return tags, diags
+	tags := []*models.NestedTag{}
+	seen := map[string]bool{}
+	for _, tag := range tagList {
+		nestedTag, _ := api.tags.get(tag)
+		if nestedTag == nil || seen[*nestedTag.Slug] {
+			continue
+		}
+		seen[*nestedTag.Slug] = true
+		tags = append(tags, nestedTag)
+	}
+	return tags, nil
+}
+
+// listTags looks up all given tags with a single request filtering by name or
+// slug. The resolved tags are returned by the given name or slug.
+func listTags(api *providerState, filter string, values []string) (map[string]*models.NestedTag, error) {
+	params := extras.NewExtrasTagsListParams()
+	limit := int64(len(values))
+	params.Limit = &limit
+
+	res, err := api.Extras.ExtrasTagsList(params, nil, withQueryParams(url.Values{filter: values}))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving tags from netbox: %w", err)
+	}
+
+	resolved := map[string]*models.NestedTag{}
+	for _, tag := range res.GetPayload().Results {
+		nestedTag := &models.NestedTag{
+			Name: tag.Name,
+			Slug: tag.Slug,
+		}
+		if filter == "name" {
+			resolved[*tag.Name] = nestedTag
+		} else {
+			resolved[*tag.Slug] = nestedTag
+		}
+	}
+	return resolved, nil
 }
 
 func getTagListFromNestedTagList(nestedTags []*models.NestedTag) []string {
@@ -83,3 +159,27 @@ func getTagListFromNestedTagList(nestedTags []*models.NestedTag) []string {
 	}
 	return tags
 }
+
+// getResourceTagListFromNestedTagList returns the tags of a resource as they
+// are stored in its state. Tags are returned by slug if the resource sets
+// them by slug and by name otherwise. Provider default tags and ignored tags
+// are left out unless they are also set on the resource, so that they do not
+// show up as drift.
+func getResourceTagListFromNestedTagList(api *providerState, d *schema.ResourceData, nestedTags []*models.NestedTag) []string {
+	resourceTags := d.Get(tagsKey).(*schema.Set)
+	tags := []string{}
+	for _, nestedTag := range nestedTags {
+		name, slug := *nestedTag.Name, *nestedTag.Slug
+		switch {
+		case resourceTags.Contains(name):
+			tags = append(tags, name)
+		case resourceTags.Contains(slug):
+			tags = append(tags, slug)
+		case slices.Contains(api.defaultTags, name), slices.Contains(api.defaultTags, slug), api.ignoredTags.matches(name):
+			continue
+		default:
+			tags = append(tags, name)
+		}
+	}
+	return tags
+}
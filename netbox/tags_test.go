@@ -1,9 +1,14 @@
 package netbox
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
 	"testing"
 
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -26,3 +31,75 @@ func TestGetTagListFromNestedTagList(t *testing.T) {
 	}
 	assert.Equal(t, flat, expected)
 }
+
+func TestGetResourceTagListFromNestedTagList(t *testing.T) {
+	r := &schema.Resource{Schema: map[string]*schema.Schema{tagsKey: tagsSchema}}
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		tagsKey: []interface{}{"Foo", "bar", "Team"},
+	})
+	api := &providerState{
+		defaultTags: []string{"Team", "Terraform"},
+		ignoredTags: ignoredTags{namePrefixes: []string{"scan-"}},
+	}
+
+	tags := []*models.NestedTag{
+		{Name: strToPtr("Foo"), Slug: strToPtr("foo")},
+		{Name: strToPtr("Bar"), Slug: strToPtr("bar")},
+		{Name: strToPtr("Team"), Slug: strToPtr("team")},
+		{Name: strToPtr("Terraform"), Slug: strToPtr("terraform")},
+		{Name: strToPtr("scan-2024"), Slug: strToPtr("scan-2024")},
+	}
+
+	// Terraform is a default tag and scan-2024 an ignored tag, neither is set
+	// on the resource, so both are hidden. Bar is set by slug.
+	assert.Equal(t, []string{"Foo", "bar", "Team"}, getResourceTagListFromNestedTagList(api, d, tags))
+	assert.Equal(t, []string{"Foo", "bar", "Team", "Terraform", "scan-2024"}, getResourceTagListFromNestedTagList(&providerState{}, d, tags))
+}
+
+func TestGetNestedTagListFromResourceDataSet(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RawQuery)
+		allTags := []map[string]interface{}{
+			{"id": 1, "name": "Foo", "slug": "foo"},
+			{"id": 2, "name": "Bar", "slug": "bar"},
+			{"id": 3, "name": "Team X", "slug": "team-x"},
+		}
+		results := []map[string]interface{}{}
+		for _, tag := range allTags {
+			if slices.Contains(r.URL.Query()["name"], tag["name"].(string)) || slices.Contains(r.URL.Query()["slug"], tag["slug"].(string)) {
+				results = append(results, tag)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"count": len(results), "results": results})
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL,
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+	api := &providerState{NetBoxAPI: client, tags: newTagCache(), defaultTags: []string{"team-x"}}
+
+	// All tags given by name are resolved with a single request, the ones
+	// given by slug with a second one
+	tags, err := getNestedTagListFromResourceDataSet(api, schema.NewSet(schema.HashString, []interface{}{"Foo", "Bar"}))
+	assert.NoError(t, err)
+	assert.Len(t, tags, 3)
+	assert.Len(t, requests, 2)
+
+	// Resolved tags are cached, also by their other identifier
+	tags, err = getNestedTagListFromResourceDataSet(api, schema.NewSet(schema.HashString, []interface{}{"foo", "Team X"}))
+	assert.NoError(t, err)
+	assert.Len(t, tags, 2)
+	assert.Len(t, requests, 2)
+
+	// Unknown tags are errors
+	_, err = getNestedTagListFromResourceDataSet(api, schema.NewSet(schema.HashString, []interface{}{"Foo", "Baz", "qux"}))
+	assert.EqualError(t, err, `could not find tag "Baz" or "qux" in netbox, tags have to be given by name or slug`)
+	assert.Len(t, requests, 4)
+	assert.Equal(t, "limit=2&name=Baz&name=qux", requests[2])
+}
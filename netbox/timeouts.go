@@ -0,0 +1,58 @@
+package netbox
+
+import (
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// defaultResourceTimeout is the default for every operation of a resource
+// that does not declare its own default.
+const defaultResourceTimeout = 20 * time.Minute
+
+// addResourceTimeouts makes the create, read, update and delete timeouts of
+// all resources configurable via a `timeouts` block. Defaults declared by a
+// resource are kept.
+func addResourceTimeouts(resources map[string]*schema.Resource) {
+	for _, r := range resources {
+		if r.Timeouts == nil {
+			r.Timeouts = &schema.ResourceTimeout{}
+		}
+		if r.Timeouts.Create == nil && r.CreateContext != nil {
+			r.Timeouts.Create = schema.DefaultTimeout(defaultResourceTimeout)
+		}
+		if r.Timeouts.Read == nil && r.ReadContext != nil {
+			r.Timeouts.Read = schema.DefaultTimeout(defaultResourceTimeout)
+		}
+		if r.Timeouts.Update == nil && r.UpdateContext != nil {
+			r.Timeouts.Update = schema.DefaultTimeout(defaultResourceTimeout)
+		}
+		if r.Timeouts.Delete == nil && r.DeleteContext != nil {
+			r.Timeouts.Delete = schema.DefaultTimeout(defaultResourceTimeout)
+		}
+	}
+}
+
+// contextDeadlineTransport removes the timeout that go-netbox sets on the
+// parameters of every request, which is 30 seconds unless set explicitly.
+// Requests are then only bounded by the deadline of their context, e.g. the
+// timeout of a resource operation, and by the `request_timeout` of every
+// attempt.
+type contextDeadlineTransport struct {
+	runtime.ClientTransport
+}
+
+func (t contextDeadlineTransport) Submit(op *runtime.ClientOperation) (interface{}, error) {
+	params := op.Params
+	op.Params = runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+		if params != nil {
+			if err := params.WriteToRequest(r, reg); err != nil {
+				return err
+			}
+		}
+		return r.SetTimeout(0)
+	})
+	return t.ClientTransport.Submit(op)
+}
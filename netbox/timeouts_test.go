@@ -0,0 +1,72 @@
+package netbox
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fbreckle/go-netbox/netbox/client/dcim"
+	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceTimeouts(t *testing.T) {
+	for resourceType, r := range Provider().ResourcesMap {
+		assert.Nil(t, r.Create, resourceType)
+		assert.Nil(t, r.Read, resourceType)
+		assert.Nil(t, r.Update, resourceType)
+		assert.Nil(t, r.Delete, resourceType)
+		if assert.NotNil(t, r.Timeouts, resourceType) {
+			assert.NotNil(t, r.Timeouts.Create, resourceType)
+			assert.NotNil(t, r.Timeouts.Read, resourceType)
+			assert.NotNil(t, r.Timeouts.Delete, resourceType)
+			assert.Equal(t, r.UpdateContext != nil, r.Timeouts.Update != nil, resourceType)
+		}
+	}
+
+	// Defaults declared by a resource are kept
+	r := Provider().ResourcesMap["netbox_branch"]
+	assert.Equal(t, 10*time.Minute, *r.Timeouts.Create)
+	assert.Equal(t, defaultResourceTimeout, *r.Timeouts.Read)
+}
+
+func TestRequestsRespectContextDeadline(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(1500 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": 1, "name": "test", "slug": "test"}`))
+			return
+		}
+		w.Write([]byte(`{"count": 0, "results": []}`))
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:       "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL:      ts.URL,
+		RequestTimeout: 1,
+	}
+
+	client, err := config.Client()
+	assert.NoError(t, err)
+
+	// A deadline of the context ends the request
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = client.Dcim.DcimSitesList(dcim.NewDcimSitesListParams().WithContext(ctx), nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+
+	// Requests modifying data with a deadline may take longer than the
+	// request timeout
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	data := models.WritableSite{Name: strToPtr("test"), Slug: strToPtr("test")}
+	_, err = client.Dcim.DcimSitesCreate(dcim.NewDcimSitesCreateParams().WithContext(ctx).WithData(&data), nil)
+	assert.NoError(t, err)
+}
@@ -3,9 +3,12 @@ package netbox
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"reflect"
 	"strings"
 
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -132,3 +135,23 @@ func jsonSemanticCompare(a, b string) (equal bool, err error) {
 
 	return reflect.DeepEqual(aDecoded, bDecoded), nil
 }
+
+// withQueryParams returns a client option adding query parameters to a
+// request. It allows filters not supported by the go-netbox parameters, like
+// filters with multiple values.
+func withQueryParams(values url.Values) func(*runtime.ClientOperation) {
+	return func(op *runtime.ClientOperation) {
+		params := op.Params
+		op.Params = runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, registry strfmt.Registry) error {
+			if err := params.WriteToRequest(r, registry); err != nil {
+				return err
+			}
+			for key, value := range values {
+				if err := r.SetQueryParam(key, value...); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+}
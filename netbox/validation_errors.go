@@ -0,0 +1,153 @@
+package netbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// netboxAPIError is implemented by the error responses of go-netbox and by
+// rawAPIError.
+type netboxAPIError interface {
+	error
+	Code() int
+	GetPayload() interface{}
+}
+
+// netboxErrorDiagnostics converts an error returned by the Netbox API to
+// diagnostics. If Netbox rejected the request with validation errors, there
+// is one diagnostic per rejected field, with the attribute path set to the
+// Netbox field name. addValidationErrorDiagnostics maps these paths to the
+// attributes of the resource.
+func netboxErrorDiagnostics(err error) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+
+	var apiErr netboxAPIError
+	if !errors.As(err, &apiErr) || apiErr.Code() != http.StatusBadRequest {
+		return diag.FromErr(err)
+	}
+	payload, ok := apiErr.GetPayload().(map[string]interface{})
+	if !ok || len(payload) == 0 {
+		return diag.FromErr(err)
+	}
+
+	// Sort the fields so that the diagnostics are deterministic
+	fields := make([]string, 0, len(payload))
+	for field := range payload {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var diags diag.Diagnostics
+	for _, field := range fields {
+		for _, message := range validationErrorMessages(payload[field]) {
+			d := diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  message,
+			}
+			switch field {
+			case "non_field_errors", "__all__", "detail":
+				d.Detail = "Netbox rejected the request."
+			default:
+				d.Detail = fmt.Sprintf("Netbox rejected the value of the `%s` field.", field)
+				d.AttributePath = cty.GetAttrPath(field)
+			}
+			diags = append(diags, d)
+		}
+	}
+	return diags
+}
+
+// validationErrorMessages flattens the messages Netbox returns for a field.
+// These are usually a list of strings, but nested objects like custom fields
+// have messages per nested field.
+func validationErrorMessages(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var messages []string
+		for _, item := range v {
+			messages = append(messages, validationErrorMessages(item)...)
+		}
+		return messages
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var messages []string
+		for _, key := range keys {
+			for _, message := range validationErrorMessages(v[key]) {
+				messages = append(messages, fmt.Sprintf("%s: %s", key, message))
+			}
+		}
+		return messages
+	}
+	encoded, _ := json.Marshal(value)
+	return []string{string(encoded)}
+}
+
+// netboxFieldAttribute returns the attribute of a resource corresponding to
+// a Netbox field. References to other objects are usually named like the
+// field with an `_id` or `_ids` suffix, e.g. `site_id` for `site`.
+func netboxFieldAttribute(s map[string]*schema.Schema, field string) (string, bool) {
+	for _, attribute := range []string{field, field + "_id", field + "_ids", strings.TrimSuffix(field, "s") + "_ids"} {
+		if _, ok := s[attribute]; ok {
+			return attribute, true
+		}
+	}
+	return "", false
+}
+
+// mapValidationErrorPaths maps the Netbox field names in the attribute paths
+// of diags to the attributes of a resource. Fields without a matching
+// attribute are named in the summary instead.
+func mapValidationErrorPaths(s map[string]*schema.Schema, diags diag.Diagnostics) diag.Diagnostics {
+	for i, d := range diags {
+		if len(d.AttributePath) != 1 {
+			continue
+		}
+		step, ok := d.AttributePath[0].(cty.GetAttrStep)
+		if !ok {
+			continue
+		}
+		if attribute, ok := netboxFieldAttribute(s, step.Name); ok {
+			diags[i].AttributePath = cty.GetAttrPath(attribute)
+		} else {
+			diags[i].AttributePath = nil
+			diags[i].Summary = fmt.Sprintf("%s: %s", step.Name, d.Summary)
+		}
+	}
+	return diags
+}
+
+// addValidationErrorDiagnostics wraps the create and update functions of all
+// resources, so that validation errors returned by Netbox are reported per
+// attribute.
+func addValidationErrorDiagnostics(resources map[string]*schema.Resource) {
+	for _, r := range resources {
+		s := r.Schema
+		if createContext := r.CreateContext; createContext != nil {
+			r.CreateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+				return mapValidationErrorPaths(s, createContext(ctx, d, m))
+			}
+		}
+		if updateContext := r.UpdateContext; updateContext != nil {
+			r.UpdateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+				return mapValidationErrorPaths(s, updateContext(ctx, d, m))
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package netbox
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationErrorDiagnostics(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{
+			"slug": ["site with this slug already exists."],
+			"tenant": ["Invalid pk \"99\" - object does not exist."],
+			"custom_fields": {"rating": ["Value must be an integer."]},
+			"facility": ["Ensure this field has no more than 50 characters."],
+			"non_field_errors": ["Something is wrong."]
+		}`))
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL,
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+	api := &providerState{NetBoxAPI: client}
+
+	r := Provider().ResourcesMap["netbox_site"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":      "test",
+		"slug":      "test",
+		"tenant_id": 99,
+	})
+
+	diags := r.CreateContext(context.Background(), d, api)
+	if !assert.Len(t, diags, 5) {
+		return
+	}
+
+	assert.Equal(t, "rating: Value must be an integer.", diags[0].Summary)
+	assert.Equal(t, cty.GetAttrPath("custom_fields"), diags[0].AttributePath)
+
+	assert.Equal(t, "Ensure this field has no more than 50 characters.", diags[1].Summary)
+	assert.Equal(t, cty.GetAttrPath("facility"), diags[1].AttributePath)
+
+	assert.Equal(t, "Something is wrong.", diags[2].Summary)
+	assert.Nil(t, diags[2].AttributePath)
+
+	assert.Equal(t, "site with this slug already exists.", diags[3].Summary)
+	assert.Equal(t, cty.GetAttrPath("slug"), diags[3].AttributePath)
+	assert.Equal(t, "Netbox rejected the value of the `slug` field.", diags[3].Detail)
+
+	assert.Equal(t, `Invalid pk "99" - object does not exist.`, diags[4].Summary)
+	assert.Equal(t, cty.GetAttrPath("tenant_id"), diags[4].AttributePath)
+}
+
+func TestMapValidationErrorPaths(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"name":         {Type: schema.TypeString},
+		"site_id":      {Type: schema.TypeInt},
+		"tagged_vlans": {Type: schema.TypeSet},
+		"vlan_ids":     {Type: schema.TypeSet},
+	}
+
+	diags := mapValidationErrorPaths(s, netboxErrorDiagnostics(&rawAPIError{
+		StatusCode: http.StatusBadRequest,
+		Body:       `{"name": ["Required."], "site": ["Invalid."], "tagged_vlans": ["Invalid."], "vlans": ["Invalid."], "color": ["Invalid."]}`,
+	}))
+	if assert.Len(t, diags, 5) {
+		assert.Equal(t, "color: Invalid.", diags[0].Summary)
+		assert.Nil(t, diags[0].AttributePath)
+		assert.Equal(t, cty.GetAttrPath("name"), diags[1].AttributePath)
+		assert.Equal(t, cty.GetAttrPath("site_id"), diags[2].AttributePath)
+		assert.Equal(t, cty.GetAttrPath("tagged_vlans"), diags[3].AttributePath)
+		assert.Equal(t, cty.GetAttrPath("vlan_ids"), diags[4].AttributePath)
+	}
+
+	// Other errors are passed through unchanged
+	diags = netboxErrorDiagnostics(errors.New("connection refused"))
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "connection refused", diags[0].Summary)
+	}
+	diags = netboxErrorDiagnostics(&rawAPIError{StatusCode: http.StatusInternalServerError, Body: `{"error": "boom"}`})
+	if assert.Len(t, diags, 1) {
+		assert.Nil(t, diags[0].AttributePath)
+	}
+}